@@ -0,0 +1,66 @@
+package beads
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// AssigneeFromIdentity returns the assignee string for id, which is
+// session.AssigneeString (e.g. "gastown/polecats/Toast"). The result is
+// checked to parse back to id with IdentityFromAssignee, which rejects the
+// rig/name shorthand for a polecat named like a role (e.g. "witness").
+func AssigneeFromIdentity(id *session.AgentIdentity) (string, error) {
+	if id == nil {
+		return "", fmt.Errorf("assignee from identity: nil identity")
+	}
+
+	var assignee string
+	switch id.Role {
+	case session.RoleMayor:
+		assignee = "mayor"
+	case session.RoleDeacon:
+		assignee = "deacon"
+		if id.Name == "boot" {
+			assignee = "deacon/boot"
+		}
+	case session.RoleWitness, session.RoleRefinery, session.RoleCrew, session.RolePolecat:
+		if id.Rig == "" {
+			return "", fmt.Errorf("assignee from identity: %s has no rig", id.Role)
+		}
+		if (id.Role == session.RoleCrew || id.Role == session.RolePolecat) && id.Name == "" {
+			return "", fmt.Errorf("assignee from identity: %s in %s has no name", id.Role, id.Rig)
+		}
+		if strings.Contains(id.Rig, "/") || strings.Contains(id.Name, "/") {
+			return "", fmt.Errorf("assignee from identity: rig %q and name %q must not contain '/'", id.Rig, id.Name)
+		}
+		assignee = session.AssigneeString(id)
+	default:
+		return "", fmt.Errorf("assignee from identity: role %q cannot be assigned work", id.Role)
+	}
+
+	back, err := IdentityFromAssignee(assignee)
+	if err != nil {
+		return "", fmt.Errorf("assignee %q does not parse back: %w", assignee, err)
+	}
+	if back.Role != id.Role || back.Rig != id.Rig || back.Name != id.Name {
+		return "", fmt.Errorf("assignee %q parses back as %s, not %s", assignee, back.Address(), id.Address())
+	}
+	return assignee, nil
+}
+
+// IdentityFromAssignee parses an assignee back into an agent identity.
+// Both polecat forms are accepted: the explicit "rig/polecats/name" and the
+// "rig/name" shorthand. "deacon/boot" is the boot watchdog.
+func IdentityFromAssignee(assignee string) (*session.AgentIdentity, error) {
+	assignee = strings.TrimSpace(assignee)
+	if strings.TrimSuffix(assignee, "/") == "deacon/boot" {
+		return &session.AgentIdentity{Role: session.RoleDeacon, Name: "boot"}, nil
+	}
+	id, err := session.ParseAddress(assignee)
+	if err != nil {
+		return nil, fmt.Errorf("parsing assignee %q: %w", assignee, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,105 @@
+package beads
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestAssigneeIdentityRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       session.AgentIdentity
+		assignee string
+	}{
+		{"mayor", session.AgentIdentity{Role: session.RoleMayor}, "mayor"},
+		{"deacon", session.AgentIdentity{Role: session.RoleDeacon}, "deacon"},
+		{"boot", session.AgentIdentity{Role: session.RoleDeacon, Name: "boot"}, "deacon/boot"},
+		{"witness", session.AgentIdentity{Role: session.RoleWitness, Rig: "gastown"}, "gastown/witness"},
+		{"refinery", session.AgentIdentity{Role: session.RoleRefinery, Rig: "gastown"}, "gastown/refinery"},
+		{"crew", session.AgentIdentity{Role: session.RoleCrew, Rig: "gastown", Name: "max"}, "gastown/crew/max"},
+		{"polecat", session.AgentIdentity{Role: session.RolePolecat, Rig: "gastown", Name: "Toast"}, "gastown/polecats/Toast"},
+		{"polecat named like a role", session.AgentIdentity{Role: session.RolePolecat, Rig: "gastown", Name: "witness"}, "gastown/polecats/witness"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AssigneeFromIdentity(&tt.id)
+			if err != nil {
+				t.Fatalf("AssigneeFromIdentity() error: %v", err)
+			}
+			if got != tt.assignee {
+				t.Errorf("AssigneeFromIdentity() = %q, want %q", got, tt.assignee)
+			}
+
+			back, err := IdentityFromAssignee(got)
+			if err != nil {
+				t.Fatalf("IdentityFromAssignee(%q) error: %v", got, err)
+			}
+			if back.Role != tt.id.Role || back.Rig != tt.id.Rig || back.Name != tt.id.Name {
+				t.Errorf("IdentityFromAssignee(%q) = %+v, want %+v", got, *back, tt.id)
+			}
+		})
+	}
+}
+
+func TestIdentityFromAssignee_PolecatShorthand(t *testing.T) {
+	short, err := IdentityFromAssignee("gastown/Toast")
+	if err != nil {
+		t.Fatalf("IdentityFromAssignee() error: %v", err)
+	}
+	explicit, err := IdentityFromAssignee("gastown/polecats/Toast")
+	if err != nil {
+		t.Fatalf("IdentityFromAssignee() error: %v", err)
+	}
+	if !short.Equal(explicit) {
+		t.Errorf("shorthand %+v and explicit %+v should be the same polecat", *short, *explicit)
+	}
+
+	// Formatting always picks the explicit form.
+	if got, _ := AssigneeFromIdentity(short); got != "gastown/polecats/Toast" {
+		t.Errorf("AssigneeFromIdentity(shorthand) = %q, want the explicit form", got)
+	}
+
+	// The shorthand can't express a polecat named like a rig role.
+	id, err := IdentityFromAssignee("gastown/witness")
+	if err != nil {
+		t.Fatalf("IdentityFromAssignee() error: %v", err)
+	}
+	if id.Role != session.RoleWitness {
+		t.Errorf("gastown/witness parsed as %s, want the witness", id.Role)
+	}
+}
+
+func TestAssigneeFromIdentity_Invalid(t *testing.T) {
+	for name, id := range map[string]*session.AgentIdentity{
+		"nil":            nil,
+		"overseer":       {Role: session.RoleOverseer},
+		"polecat no rig": {Role: session.RolePolecat, Name: "Toast"},
+		"crew no name":   {Role: session.RoleCrew, Rig: "gastown"},
+		"slash in name":  {Role: session.RolePolecat, Rig: "gastown", Name: "a/b"},
+		"unknown role":   {Role: "ghost", Rig: "gastown"},
+	} {
+		if got, err := AssigneeFromIdentity(id); err == nil {
+			t.Errorf("%s: AssigneeFromIdentity() = %q, want error", name, got)
+		}
+	}
+}
+
+func TestAssigneeFromIdentityFollowsFormat(t *testing.T) {
+	orig := session.AssigneeFormat
+	t.Cleanup(func() { session.AssigneeFormat = orig })
+	session.AssigneeFormat = session.AssigneeCanonical
+
+	got, err := AssigneeFromIdentity(&session.AgentIdentity{Role: session.RolePolecat, Rig: "gastown", Name: "Toast"})
+	if err != nil || got != "gastown/Toast" {
+		t.Errorf("canonical polecat = %q, %v; want gastown/Toast", got, err)
+	}
+	got, err = AssigneeFromIdentity(&session.AgentIdentity{Role: session.RoleCrew, Rig: "gastown", Name: "max"})
+	if err != nil || got != "gastown/crew/max" {
+		t.Errorf("canonical crew = %q, %v; want gastown/crew/max", got, err)
+	}
+	// The shorthand cannot express a polecat named like a role.
+	if got, err := AssigneeFromIdentity(&session.AgentIdentity{Role: session.RolePolecat, Rig: "gastown", Name: "witness"}); err == nil {
+		t.Errorf("canonical polecat named witness = %q, want an error", got)
+	}
+}
@@ -6,11 +6,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/runtime"
 )
@@ -87,6 +91,15 @@ type Issue struct {
 	Dependents   []IssueDep `json:"dependents,omitempty"`
 }
 
+// UpdatedTime parses UpdatedAt. bd emits RFC 3339, but older databases may
+// store timestamps without a zone, which are read as UTC.
+func (i *Issue) UpdatedTime() (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, i.UpdatedAt); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02T15:04:05", i.UpdatedAt)
+}
+
 // HasLabel checks if an issue has a specific label.
 func HasLabel(issue *Issue, label string) bool {
 	for _, l := range issue.Labels {
@@ -124,14 +137,25 @@ type IssueDep struct {
 
 // ListOptions specifies filters for listing issues.
 type ListOptions struct {
-	Status     string // "open", "closed", "all"
-	Type       string // Deprecated: use Label instead. "task", "bug", "feature", "epic"
-	Label      string // Label filter (e.g., "gt:agent", "gt:merge-request")
-	Priority   int    // 0-4, -1 for no filter
-	Parent     string // filter by parent ID
-	Assignee   string // filter by assignee (e.g., "gastown/Toast")
-	NoAssignee bool   // filter for issues with no assignee
-	Limit      int    // Max results (0 = unlimited, overrides bd default of 50)
+	Status        string // "open", "closed", "all"
+	Type          string // Deprecated: use Label instead. "task", "bug", "feature", "epic"
+	Label         string // Label filter (e.g., "gt:agent", "gt:merge-request")
+	Priority      int    // 0-4, -1 for no filter
+	Parent        string // filter by parent ID
+	Assignee      string // filter by assignee (e.g., "gastown/Toast")
+	NoAssignee    bool   // filter for issues with no assignee
+	TitleContains string // case-insensitive title substring filter
+	Limit         int    // Max results (0 = DefaultMaxResults, -1 = unlimited; overrides bd default of 50)
+}
+
+// DefaultMaxResults caps List and ListRaw when ListOptions.Limit is 0, so an
+// unfiltered list of a huge database cannot exhaust memory unnoticed.
+var DefaultMaxResults = 10000
+
+// Warnf reports non-fatal problems, such as a list truncated at
+// DefaultMaxResults. Replace it to route the warnings elsewhere.
+var Warnf = func(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
 }
 
 // CreateOptions specifies options for creating an issue.
@@ -141,8 +165,9 @@ type CreateOptions struct {
 	Priority    int    // 0-4
 	Description string
 	Parent      string
-	Actor       string // Who is creating this issue (populates created_by)
-	Ephemeral   bool   // Create as ephemeral (wisp) - not exported to JSONL
+	Actor       string   // Who is creating this issue (populates created_by)
+	Ephemeral   bool     // Create as ephemeral (wisp) - not exported to JSONL
+	Labels      []string // Labels to apply at creation
 }
 
 // UpdateOptions specifies options for updating an issue.
@@ -175,6 +200,26 @@ type Beads struct {
 	// Populated on first call to getTownRoot() to avoid filesystem walk on every operation.
 	townRoot     string
 	townRootOnce sync.Once
+
+	// StatusLabelRules optionally maps a status to label changes applied
+	// when SetStatus, Close or Reopen move an issue into that status (e.g.
+	// "closed": add "done"). Nil means no automatic label changes.
+	StatusLabelRules map[string]LabelChange
+
+	// EvidenceLabel, if set, is added to issues closed with
+	// CloseWithEvidence (e.g. "merged").
+	EvidenceLabel string
+
+	// Concurrency bounds how many bd calls batch helpers (AssignBatch,
+	// ReleaseAllForAssignee, ReparentBatch) run at once. Zero or one runs
+	// them one at a time, in order.
+	Concurrency int
+}
+
+// LabelChange is a set of labels to add to and remove from an issue.
+type LabelChange struct {
+	Add    []string
+	Remove []string
 }
 
 // New creates a new Beads wrapper for the given directory.
@@ -232,6 +277,12 @@ func (b *Beads) Init(prefix string) error {
 	return err
 }
 
+// isEmptyOutput reports whether bd printed nothing but whitespace, which bd
+// can do instead of "[]" on an empty repo.
+func isEmptyOutput(out []byte) bool {
+	return len(bytes.TrimSpace(out)) == 0
+}
+
 // run executes a bd command and returns stdout.
 func (b *Beads) run(args ...string) ([]byte, error) {
 	// Use --allow-stale to prevent failures when db is out of sync with JSONL
@@ -383,6 +434,55 @@ func filterBeadsEnv(environ []string) []string {
 
 // List returns issues matching the given options.
 func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
+	out, err := b.run(listArgs(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEmptyOutput(out) {
+		return nil, nil
+	}
+
+	var issues []*Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	}
+
+	warnIfCapped(opts, len(issues))
+	return issues, nil
+}
+
+// ListRaw is like List but returns each issue's undecoded JSON, so callers
+// can read fields bd emits that Issue does not model yet.
+func (b *Beads) ListRaw(opts ListOptions) ([]json.RawMessage, error) {
+	out, err := b.run(listArgs(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEmptyOutput(out) {
+		return nil, nil
+	}
+
+	var issues []json.RawMessage
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	}
+
+	warnIfCapped(opts, len(issues))
+	return issues, nil
+}
+
+// warnIfCapped warns when a list without an explicit limit came back at
+// DefaultMaxResults and so may be missing issues.
+func warnIfCapped(opts ListOptions, n int) {
+	if opts.Limit == 0 && DefaultMaxResults > 0 && n >= DefaultMaxResults {
+		Warnf("bd list returned %d issues, the default cap; results may be truncated (set ListOptions.Limit to paginate, or -1 for no limit)", n)
+	}
+}
+
+// listArgs builds the bd list command line for opts.
+func listArgs(opts ListOptions) []string {
 	args := []string{"list", "--json"}
 
 	if opts.Status != "" {
@@ -407,38 +507,97 @@ func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
 	if opts.NoAssignee {
 		args = append(args, "--no-assignee")
 	}
-	if opts.Limit > 0 {
+	if opts.TitleContains != "" {
+		args = append(args, "--title-contains="+opts.TitleContains)
+	}
+	switch {
+	case opts.Limit > 0:
 		args = append(args, fmt.Sprintf("--limit=%d", opts.Limit))
-	} else {
-		// Override bd's default limit of 50 to avoid silent truncation
+	case opts.Limit == 0 && DefaultMaxResults > 0:
+		// Override bd's default limit of 50; warnIfCapped flags truncation
+		args = append(args, fmt.Sprintf("--limit=%d", DefaultMaxResults))
+	default:
 		args = append(args, "--limit=0")
 	}
+	return args
+}
 
-	out, err := b.run(args...)
+// ListByAssignee returns all issues assigned to a specific assignee.
+// The assignee is typically in the format "rig/polecats/polecatName" (e.g., "gastown/polecats/Toast").
+func (b *Beads) ListByAssignee(assignee string) ([]*Issue, error) {
+	return b.List(ListOptions{
+		Status:   "all", // Include both open and closed for state derivation
+		Assignee: assignee,
+		Priority: -1, // No priority filter
+	})
+}
+
+// IssuesWithLabel returns open issues carrying label.
+func (b *Beads) IssuesWithLabel(label string) ([]*Issue, error) {
+	return b.List(ListOptions{Label: label, Priority: -1})
+}
+
+// FindByTitle returns issues of any status whose title matches title,
+// case-insensitively: equal to it when exact is set, containing it
+// otherwise. All matches are returned in bd's order so callers can
+// disambiguate, e.g. when resolving "close the 'Fix auth' bug".
+func (b *Beads) FindByTitle(title string, exact bool) ([]*Issue, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("finding issues by title: title is required")
+	}
+
+	issues, err := b.List(ListOptions{Status: "all", TitleContains: title, Priority: -1})
 	if err != nil {
 		return nil, err
 	}
 
-	var issues []*Issue
-	if err := json.Unmarshal(out, &issues); err != nil {
-		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	want := strings.ToLower(title)
+	var matches []*Issue
+	for _, issue := range issues {
+		got := strings.ToLower(strings.TrimSpace(issue.Title))
+		if got == want || (!exact && strings.Contains(got, want)) {
+			matches = append(matches, issue)
+		}
 	}
-
-	return issues, nil
+	return matches, nil
 }
 
-// ListByAssignee returns all issues assigned to a specific assignee.
-// The assignee is typically in the format "rig/polecats/polecatName" (e.g., "gastown/polecats/Toast").
-func (b *Beads) ListByAssignee(assignee string) ([]*Issue, error) {
+// InProgress returns all issues with status in_progress.
+func (b *Beads) InProgress() ([]*Issue, error) {
 	return b.List(ListOptions{
-		Status:   "all", // Include both open and closed for state derivation
-		Assignee: assignee,
+		Status:   "in_progress",
 		Priority: -1, // No priority filter
 	})
 }
 
-// GetAssignedIssue returns the first open or hooked issue assigned to the given assignee.
-// Returns nil if no open or hooked issue is assigned.
+// InProgressOlderThan returns in-progress issues not updated for at least
+// d, which are candidates for stuck or abandoned work. Issues whose
+// updated_at cannot be parsed are skipped with a warning rather than
+// reported as stale.
+func (b *Beads) InProgressOlderThan(d time.Duration) ([]*Issue, error) {
+	issues, err := b.InProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-d)
+	var stale []*Issue
+	for _, issue := range issues {
+		updated, err := issue.UpdatedTime()
+		if err != nil {
+			Warnf("skipping %s: unparseable updated_at %q", issue.ID, issue.UpdatedAt)
+			continue
+		}
+		if updated.Before(cutoff) {
+			stale = append(stale, issue)
+		}
+	}
+	return stale, nil
+}
+
+// GetAssignedIssue returns the first open, in_progress, hooked or in_review
+// issue assigned to the given assignee. Returns nil if none is assigned.
 func (b *Beads) GetAssignedIssue(assignee string) (*Issue, error) {
 	issues, err := b.List(ListOptions{
 		Status:   "open",
@@ -473,6 +632,18 @@ func (b *Beads) GetAssignedIssue(assignee string) (*Issue, error) {
 		}
 	}
 
+	// Also check in_review status - work awaiting review is still owned
+	if len(issues) == 0 {
+		issues, err = b.List(ListOptions{
+			Status:   StatusInReview,
+			Assignee: assignee,
+			Priority: -1,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if len(issues) == 0 {
 		return nil, nil
 	}
@@ -480,6 +651,41 @@ func (b *Beads) GetAssignedIssue(assignee string) (*Issue, error) {
 	return issues[0], nil
 }
 
+// OpenAssignees returns the distinct assignees holding open (non-closed)
+// work, sorted. Unassigned issues are ignored.
+func (b *Beads) OpenAssignees() ([]string, error) {
+	workload, err := b.AssigneeWorkload()
+	if err != nil {
+		return nil, err
+	}
+
+	assignees := make([]string, 0, len(workload))
+	for assignee := range workload {
+		assignees = append(assignees, assignee)
+	}
+	sort.Strings(assignees)
+	return assignees, nil
+}
+
+// AssigneeWorkload returns the number of open (non-closed) issues held by
+// each assignee. Unassigned issues are not counted.
+func (b *Beads) AssigneeWorkload() (map[string]int, error) {
+	// Empty status uses bd's default of all non-closed issues.
+	issues, err := b.List(ListOptions{Priority: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	workload := make(map[string]int)
+	for _, issue := range issues {
+		if issue.Assignee == "" || issue.Status == "closed" {
+			continue
+		}
+		workload[issue.Assignee]++
+	}
+	return workload, nil
+}
+
 // Ready returns issues that are ready to work (not blocked).
 func (b *Beads) Ready() ([]*Issue, error) {
 	out, err := b.run("ready", "--json")
@@ -487,6 +693,10 @@ func (b *Beads) Ready() ([]*Issue, error) {
 		return nil, err
 	}
 
+	if isEmptyOutput(out) {
+		return nil, nil
+	}
+
 	var issues []*Issue
 	if err := json.Unmarshal(out, &issues); err != nil {
 		return nil, fmt.Errorf("parsing bd ready output: %w", err)
@@ -505,6 +715,10 @@ func (b *Beads) ReadyForMol(moleculeID string) ([]*Issue, error) {
 		return nil, err
 	}
 
+	if isEmptyOutput(out) {
+		return nil, nil
+	}
+
 	var issues []*Issue
 	if err := json.Unmarshal(out, &issues); err != nil {
 		return nil, fmt.Errorf("parsing bd ready --mol output: %w", err)
@@ -522,6 +736,10 @@ func (b *Beads) ReadyWithType(issueType string) ([]*Issue, error) {
 		return nil, err
 	}
 
+	if isEmptyOutput(out) {
+		return nil, nil
+	}
+
 	var issues []*Issue
 	if err := json.Unmarshal(out, &issues); err != nil {
 		return nil, fmt.Errorf("parsing bd ready output: %w", err)
@@ -530,6 +748,35 @@ func (b *Beads) ReadyWithType(issueType string) ([]*Issue, error) {
 	return issues, nil
 }
 
+// readyCountLimit caps how many ready issues ReadyCount fetches. bd has no
+// count for ready work, so larger queues report this value.
+const readyCountLimit = 1000
+
+// ReadyCount returns how many issues are ready, for cheap "is there work?"
+// polling. A non-empty issueType filters by its gt:<type> label, as in
+// ReadyWithType. Only the length of bd's output array is decoded, and the
+// count saturates at readyCountLimit.
+func (b *Beads) ReadyCount(issueType string) (int, error) {
+	args := []string{"ready", "--json", "-n", strconv.Itoa(readyCountLimit)}
+	if issueType != "" {
+		args = append(args, "--label", "gt:"+issueType)
+	}
+	out, err := b.run(args...)
+	if err != nil {
+		return 0, err
+	}
+
+	if isEmptyOutput(out) {
+		return 0, nil
+	}
+
+	var issues []json.RawMessage
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return 0, fmt.Errorf("parsing bd ready output: %w", err)
+	}
+	return len(issues), nil
+}
+
 // Show returns detailed information about an issue.
 func (b *Beads) Show(id string) (*Issue, error) {
 	// Route cross-rig queries via routes.jsonl so that rig-level bead IDs
@@ -545,17 +792,61 @@ func (b *Beads) Show(id string) (*Issue, error) {
 		return nil, err
 	}
 
-	// bd show --json returns an array with one element
-	var issues []*Issue
-	if err := json.Unmarshal(out, &issues); err != nil {
+	raw, err := firstShowResult(out)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	if err := json.Unmarshal(raw, &issue); err != nil {
 		return nil, fmt.Errorf("parsing bd show output: %w", err)
 	}
+	return &issue, nil
+}
 
-	if len(issues) == 0 {
+// firstShowResult extracts the issue from `bd show <id> --json` output.
+// Current bd prints a one-element array; some versions print a bare object.
+// Empty output, an empty array or an empty object mean ErrNotFound.
+func firstShowResult(out []byte) (json.RawMessage, error) {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
 		return nil, ErrNotFound
 	}
 
-	return issues[0], nil
+	var issues []json.RawMessage
+	arrErr := json.Unmarshal(out, &issues)
+	if arrErr == nil {
+		if len(issues) == 0 {
+			return nil, ErrNotFound
+		}
+		return issues[0], nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(out, &object); err != nil {
+		return nil, fmt.Errorf("parsing bd show output: %w", arrErr)
+	}
+	if len(object) == 0 {
+		return nil, ErrNotFound
+	}
+	return json.RawMessage(out), nil
+}
+
+// ShowRaw is like Show but returns the issue's undecoded JSON, so callers
+// can read fields bd emits that Issue does not model yet.
+func (b *Beads) ShowRaw(id string) (json.RawMessage, error) {
+	targetDir := ResolveRoutingTarget(b.getTownRoot(), id, b.getResolvedBeadsDir())
+	if targetDir != b.getResolvedBeadsDir() {
+		target := NewWithBeadsDir(filepath.Dir(targetDir), targetDir)
+		return target.ShowRaw(id)
+	}
+
+	out, err := b.run("show", id, "--json")
+	if err != nil {
+		return nil, err
+	}
+
+	return firstShowResult(out)
 }
 
 // ShowMultiple fetches multiple issues by ID in a single bd call.
@@ -572,6 +863,10 @@ func (b *Beads) ShowMultiple(ids []string) (map[string]*Issue, error) {
 		return nil, fmt.Errorf("bd show: %w", err)
 	}
 
+	if isEmptyOutput(out) {
+		return make(map[string]*Issue), nil
+	}
+
 	var issues []*Issue
 	if err := json.Unmarshal(out, &issues); err != nil {
 		return nil, fmt.Errorf("parsing bd show output: %w", err)
@@ -592,6 +887,10 @@ func (b *Beads) Blocked() ([]*Issue, error) {
 		return nil, err
 	}
 
+	if isEmptyOutput(out) {
+		return nil, nil
+	}
+
 	var issues []*Issue
 	if err := json.Unmarshal(out, &issues); err != nil {
 		return nil, fmt.Errorf("parsing bd blocked output: %w", err)
@@ -600,6 +899,64 @@ func (b *Beads) Blocked() ([]*Issue, error) {
 	return issues, nil
 }
 
+// BlockerInfo summarizes an issue that is blocking another issue.
+type BlockerInfo struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+// BlockedIssue is a blocked issue together with its resolved blockers.
+type BlockedIssue struct {
+	Issue    *Issue        `json:"issue"`
+	Blockers []BlockerInfo `json:"blockers"`
+}
+
+// BlockedWithReasons returns blocked issues with each blocker's title and status
+// resolved, so callers can show e.g. "blocked by gt-123 'Fix auth' (open)".
+// Blockers are fetched with a single batched show call. Blockers that cannot be
+// resolved are still reported with their ID only.
+func (b *Beads) BlockedWithReasons() ([]BlockedIssue, error) {
+	issues, err := b.Blocked()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var blockerIDs []string
+	for _, issue := range issues {
+		for _, id := range issue.BlockedBy {
+			id = ExtractIssueID(id)
+			if !seen[id] {
+				seen[id] = true
+				blockerIDs = append(blockerIDs, id)
+			}
+		}
+	}
+
+	blockers, err := b.ShowMultiple(blockerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("resolving blockers: %w", err)
+	}
+
+	result := make([]BlockedIssue, 0, len(issues))
+	for _, issue := range issues {
+		entry := BlockedIssue{Issue: issue}
+		for _, id := range issue.BlockedBy {
+			id = ExtractIssueID(id)
+			info := BlockerInfo{ID: id}
+			if blocker, ok := blockers[id]; ok {
+				info.Title = blocker.Title
+				info.Status = blocker.Status
+			}
+			entry.Blockers = append(entry.Blockers, info)
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
 // Create creates a new issue and returns it.
 // If opts.Actor is empty, it defaults to the BD_ACTOR environment variable.
 // This ensures created_by is populated for issue provenance tracking.
@@ -608,6 +965,12 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 	if IsFlagLikeTitle(opts.Title) {
 		return nil, fmt.Errorf("refusing to create bead: %w (got %q)", ErrFlagTitle, opts.Title)
 	}
+	if opts.Type != "" && !isCreateType(opts.Type) {
+		return nil, fmt.Errorf("refusing to create bead: unknown type %q (must be one of %s)", opts.Type, strings.Join(CreateTypes, ", "))
+	}
+	if err := validateCreateLabels(opts.Labels); err != nil {
+		return nil, fmt.Errorf("refusing to create bead: %w", err)
+	}
 
 	args := []string{"create", "--json"}
 
@@ -618,6 +981,9 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 	if opts.Type != "" {
 		args = append(args, "--labels=gt:"+opts.Type)
 	}
+	for _, label := range opts.Labels {
+		args = append(args, "--labels="+label)
+	}
 	if opts.Priority >= 0 {
 		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
 	}
@@ -653,6 +1019,41 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 	return &issue, nil
 }
 
+// CreateBug creates an issue of type bug. opts.Type is ignored.
+func (b *Beads) CreateBug(opts CreateOptions) (*Issue, error) {
+	opts.Type = "bug"
+	return b.Create(opts)
+}
+
+// CreateFeature creates an issue of type feature. opts.Type is ignored.
+func (b *Beads) CreateFeature(opts CreateOptions) (*Issue, error) {
+	opts.Type = "feature"
+	return b.Create(opts)
+}
+
+// CreateTask creates an issue of type task. opts.Type is ignored.
+func (b *Beads) CreateTask(opts CreateOptions) (*Issue, error) {
+	opts.Type = "task"
+	return b.Create(opts)
+}
+
+// CreateEpic creates an issue of type epic. opts.Type is ignored.
+func (b *Beads) CreateEpic(opts CreateOptions) (*Issue, error) {
+	opts.Type = "epic"
+	return b.Create(opts)
+}
+
+// validateCreateLabels rejects blank labels, which bd would otherwise
+// store as an empty label.
+func validateCreateLabels(labels []string) error {
+	for i, label := range labels {
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("label %d is empty", i)
+		}
+	}
+	return nil
+}
+
 // CreateWithID creates an issue with a specific ID.
 // This is useful for agent beads, role beads, and other beads that need
 // deterministic IDs rather than auto-generated ones.
@@ -661,6 +1062,12 @@ func (b *Beads) CreateWithID(id string, opts CreateOptions) (*Issue, error) {
 	if IsFlagLikeTitle(opts.Title) {
 		return nil, fmt.Errorf("refusing to create bead: %w (got %q)", ErrFlagTitle, opts.Title)
 	}
+	if opts.Type != "" && !isCreateType(opts.Type) {
+		return nil, fmt.Errorf("refusing to create bead: unknown type %q (must be one of %s)", opts.Type, strings.Join(CreateTypes, ", "))
+	}
+	if err := validateCreateLabels(opts.Labels); err != nil {
+		return nil, fmt.Errorf("refusing to create bead: %w", err)
+	}
 
 	args := []string{"create", "--json", "--id=" + id}
 	if NeedsForceForID(id) {
@@ -674,6 +1081,9 @@ func (b *Beads) CreateWithID(id string, opts CreateOptions) (*Issue, error) {
 	if opts.Type != "" {
 		args = append(args, "--labels=gt:"+opts.Type)
 	}
+	for _, label := range opts.Labels {
+		args = append(args, "--labels="+label)
+	}
 	if opts.Priority >= 0 {
 		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
 	}
@@ -743,6 +1153,157 @@ func (b *Beads) Update(id string, opts UpdateOptions) error {
 	return err
 }
 
+// TouchLabel is the label Touch toggles to bump an issue's updated_at.
+const TouchLabel = "gt:heartbeat"
+
+// Touch bumps an issue's updated_at without changing any of its fields, as
+// a heartbeat for long-lived beads such as agent beads.
+//
+// Staleness is derived from UpdatedAt alone: the daemon's GUPP check, the
+// feed's stuck detection and doctor's patrol checks all compare it against
+// a cutoff of minutes, so a touched bead reads as live even when nothing
+// else changed. bd stores updated_at to the second, so two touches within
+// the same second may leave it unchanged; that is well inside any cutoff.
+//
+// bd has no touch command and ignores an update that changes nothing, so
+// Touch removes TouchLabel and adds it back. Each step is a real write on
+// every call, including the first (when the removal is a no-op and the add
+// is not). No real field is rewritten, so a concurrent update is never
+// undone.
+func (b *Beads) Touch(id string) error {
+	if _, err := b.run("update", id, "--remove-label="+TouchLabel); err != nil {
+		return fmt.Errorf("touching %s: %w", id, err)
+	}
+	if _, err := b.run("update", id, "--add-label="+TouchLabel); err != nil {
+		return fmt.Errorf("touching %s: %w", id, err)
+	}
+	return nil
+}
+
+// RenameLabel replaces label oldLabel with newLabel on every issue carrying it
+// and returns how many issues were changed. Issues are rewritten one at a
+// time, so an interrupted rename can simply be re-run: issues already
+// relabeled no longer match and are skipped.
+func (b *Beads) RenameLabel(oldLabel, newLabel string) (int, error) {
+	if oldLabel == "" || newLabel == "" {
+		return 0, fmt.Errorf("renaming label: old and new labels are required")
+	}
+	if oldLabel == newLabel {
+		return 0, nil
+	}
+
+	issues, err := b.List(ListOptions{
+		Status:   "all",
+		Label:    oldLabel,
+		Priority: -1,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("listing issues with label %s: %w", oldLabel, err)
+	}
+
+	changed := 0
+	for _, issue := range issues {
+		if err := b.Update(issue.ID, UpdateOptions{
+			AddLabels:    []string{newLabel},
+			RemoveLabels: []string{oldLabel},
+		}); err != nil {
+			return changed, fmt.Errorf("relabeling %s: %w", issue.ID, err)
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+// IssueTypes lists the issue types known to Gas Town.
+var IssueTypes = []string{"task", "bug", "feature", "epic", "agent"}
+
+// CreateTypes lists the types Create accepts: bd's built-in types plus the
+// Gas Town custom types, which are stored as gt:<type> labels.
+var CreateTypes = []string{
+	"task", "bug", "feature", "epic", "chore", "decision", "message", "event",
+	"agent", "role", "rig", "molecule", "gate", "convoy", "merge-request", "slot",
+}
+
+// isCreateType reports whether t is one of CreateTypes.
+func isCreateType(t string) bool {
+	for _, known := range CreateTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidIssueType reports whether t is one of IssueTypes.
+func IsValidIssueType(t string) bool {
+	for _, known := range IssueTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeType changes an issue's type, e.g. promoting a task that grew into an
+// epic. Agent beads have their own lifecycle, so changes to or from "agent"
+// are rejected. Demoting an epic that still has children is refused; use
+// ForceChangeType to move the children out first.
+func (b *Beads) ChangeType(id, newType string) error {
+	return b.changeType(id, newType, false)
+}
+
+// ForceChangeType is like ChangeType but, when demoting an epic with children,
+// reparents the children to the epic's own parent (or detaches them if the
+// epic is top-level) before changing the type.
+func (b *Beads) ForceChangeType(id, newType string) error {
+	return b.changeType(id, newType, true)
+}
+
+func (b *Beads) changeType(id, newType string, force bool) error {
+	if !IsValidIssueType(newType) {
+		return fmt.Errorf("invalid issue type %q: must be one of %s", newType, strings.Join(IssueTypes, ", "))
+	}
+	if newType == "agent" {
+		return fmt.Errorf("cannot change %s to type agent: agent beads are created via CreateAgentBead", id)
+	}
+
+	issue, err := b.Show(id)
+	if err != nil {
+		return err
+	}
+	if IsAgentBead(issue) {
+		return fmt.Errorf("cannot change type of agent bead %s", id)
+	}
+	if issue.Type == newType {
+		return nil
+	}
+
+	if issue.Type == "epic" {
+		children, err := b.List(ListOptions{Status: "all", Parent: id, Priority: -1})
+		if err != nil {
+			return fmt.Errorf("listing children of %s: %w", id, err)
+		}
+		if len(children) > 0 {
+			if !force {
+				return fmt.Errorf("epic %s has %d children: reparent or detach them first, or force the change", id, len(children))
+			}
+			for _, child := range children {
+				if _, err := b.run("update", child.ID, "--parent="+issue.Parent); err != nil {
+					return fmt.Errorf("moving child %s out of %s: %w", child.ID, id, err)
+				}
+			}
+		}
+	}
+
+	args := []string{"update", id, "--type=" + newType, "--add-label=gt:" + newType}
+	if issue.Type != "" {
+		args = append(args, "--remove-label=gt:"+issue.Type)
+	}
+	_, err = b.run(args...)
+	return err
+}
+
 // Close closes one or more issues.
 // If a runtime session ID is set in the environment, it is passed to bd close
 // for work attribution tracking (see decision 009-session-events-architecture.md).
@@ -758,8 +1319,11 @@ func (b *Beads) Close(ids ...string) error {
 		args = append(args, "--session="+sessionID)
 	}
 
-	_, err := b.run(args...)
-	return err
+	if _, err := b.run(args...); err != nil {
+		return err
+	}
+	b.applyStatusLabels("closed", ids...)
+	return nil
 }
 
 // CloseWithReason closes one or more issues with a reason.
@@ -778,7 +1342,55 @@ func (b *Beads) CloseWithReason(reason string, ids ...string) error {
 		args = append(args, "--session="+sessionID)
 	}
 
-	_, err := b.run(args...)
+	if _, err := b.run(args...); err != nil {
+		return err
+	}
+	b.applyStatusLabels("closed", ids...)
+	return nil
+}
+
+// CloseWithEvidence closes an issue with a reason after recording where the
+// work landed (a PR or commit URL) as an "Evidence: <url>" note, the local
+// counterpart of a wanted item's evidence_url. EvidenceLabel, if set, is
+// added in the same update. The URL must be an absolute http(s) URL; it is
+// checked before anything is changed.
+func (b *Beads) CloseWithEvidence(id, evidenceURL, reason string) error {
+	u, err := url.Parse(evidenceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid evidence URL %q: must be an absolute http(s) URL", evidenceURL)
+	}
+
+	args := []string{"update", id, "--append-notes=Evidence: " + evidenceURL}
+	if b.EvidenceLabel != "" {
+		args = append(args, "--add-label="+b.EvidenceLabel)
+	}
+	if _, err := b.run(args...); err != nil {
+		return fmt.Errorf("recording evidence: %w", err)
+	}
+
+	return b.CloseWithReason(reason, id)
+}
+
+// BlockedExternalLabel marks issues blocked by something outside beads,
+// such as a vendor fix or another team's release.
+const BlockedExternalLabel = "blocked-external"
+
+// MarkBlockedExternal labels an issue BlockedExternalLabel and appends a
+// "Blocked externally: <reason>" note, for blockers that can't be a
+// dependency. List with Label: BlockedExternalLabel to find such work.
+func (b *Beads) MarkBlockedExternal(id, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("marking %s blocked: reason is required", id)
+	}
+	_, err := b.run("update", id, "--add-label="+BlockedExternalLabel, "--append-notes=Blocked externally: "+reason)
+	return err
+}
+
+// ClearBlockedExternal removes BlockedExternalLabel from an issue and notes
+// that the external blocker cleared.
+func (b *Beads) ClearBlockedExternal(id string) error {
+	_, err := b.run("update", id, "--remove-label="+BlockedExternalLabel, "--append-notes=External blocker cleared")
 	return err
 }
 
@@ -798,10 +1410,66 @@ func (b *Beads) ForceCloseWithReason(reason string, ids ...string) error {
 		args = append(args, "--session="+sessionID)
 	}
 
+	if _, err := b.run(args...); err != nil {
+		return err
+	}
+	b.applyStatusLabels("closed", ids...)
+	return nil
+}
+
+// SetStatus sets an issue's status, applying any StatusLabelRules for the
+// new status in the same update.
+func (b *Beads) SetStatus(id, status string) error {
+	args := append([]string{"update", id, "--status=" + status}, b.statusLabelArgs(status)...)
 	_, err := b.run(args...)
 	return err
 }
 
+// Reopen reopens one or more closed issues, then applies any
+// StatusLabelRules for "open".
+func (b *Beads) Reopen(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := b.run(append([]string{"reopen"}, ids...)...); err != nil {
+		return err
+	}
+	b.applyStatusLabels("open", ids...)
+	return nil
+}
+
+// statusLabelArgs returns the bd update flags for the StatusLabelRules of
+// status, or nil if there is no rule.
+func (b *Beads) statusLabelArgs(status string) []string {
+	change, ok := b.StatusLabelRules[status]
+	if !ok {
+		return nil
+	}
+	var args []string
+	for _, label := range change.Add {
+		args = append(args, "--add-label="+label)
+	}
+	for _, label := range change.Remove {
+		args = append(args, "--remove-label="+label)
+	}
+	return args
+}
+
+// applyStatusLabels applies the StatusLabelRules of status to ids. bd close
+// and bd reopen take no label flags, so the change is a follow-up update.
+// The status change has already succeeded by then, so a failed label update
+// only warns: returning an error would make callers retry the close.
+func (b *Beads) applyStatusLabels(status string, ids ...string) {
+	labelArgs := b.statusLabelArgs(status)
+	if len(labelArgs) == 0 {
+		return
+	}
+	args := append(append([]string{"update"}, ids...), labelArgs...)
+	if _, err := b.run(args...); err != nil {
+		Warnf("applying %s labels to %s: %v", status, strings.Join(ids, ", "), err)
+	}
+}
+
 // Release moves an in_progress issue back to open status.
 // This is used to recover stuck steps when a worker dies mid-task.
 // It clears the assignee so the step can be claimed by another worker.
@@ -823,6 +1491,168 @@ func (b *Beads) ReleaseWithReason(id, reason string) error {
 	return err
 }
 
+// InReview moves an issue to in_review status, marking the work as done but
+// awaiting review. The assignee is kept so the issue stays with its owner.
+func (b *Beads) InReview(id string) error {
+	_, err := b.run("update", id, "--status="+StatusInReview)
+	return err
+}
+
+// ReleaseAllForAssignee releases every open or in_progress issue held by
+// assignee (e.g. a worker declared dead), reopening it with the assignee
+// cleared and the reason noted. Up to b.Concurrency releases run at once.
+// It keeps going past individual failures and returns the number released
+// along with the joined errors.
+func (b *Beads) ReleaseAllForAssignee(assignee, reason string) (int, error) {
+	if assignee == "" {
+		return 0, fmt.Errorf("releasing issues: assignee is required")
+	}
+
+	var issues []*Issue
+	for _, status := range []string{"in_progress", "open"} {
+		found, err := b.List(ListOptions{Status: status, Assignee: assignee, Priority: -1})
+		if err != nil {
+			return 0, fmt.Errorf("listing %s issues for %s: %w", status, assignee, err)
+		}
+		issues = append(issues, found...)
+	}
+
+	errs := make([]error, len(issues))
+	b.forEachConcurrently(len(issues), func(i int) {
+		if err := b.ReleaseWithReason(issues[i].ID, reason); err != nil {
+			errs[i] = fmt.Errorf("releasing %s: %w", issues[i].ID, err)
+		}
+	})
+
+	released := 0
+	for _, err := range errs {
+		if err == nil {
+			released++
+		}
+	}
+	return released, errors.Join(errs...)
+}
+
+// AssignBatch sets the assignee of each issue in assignments (issue ID to
+// assignee), e.g. to hand out a queue of ready work in one pass. Issues are
+// updated in ID order, or b.Concurrency at a time, and a failure does not
+// stop the rest; the returned map holds an error for each issue that could
+// not be assigned and is empty when all succeed. The error return is
+// reserved for invalid input, which is rejected before any update is made.
+func (b *Beads) AssignBatch(assignments map[string]string) (map[string]error, error) {
+	ids := make([]string, 0, len(assignments))
+	for id, assignee := range assignments {
+		if id == "" {
+			return nil, fmt.Errorf("assigning batch: empty issue ID")
+		}
+		if assignee == "" {
+			return nil, fmt.Errorf("assigning batch: empty assignee for %s", id)
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	errs := make([]error, len(ids))
+	b.forEachConcurrently(len(ids), func(i int) {
+		assignee := assignments[ids[i]]
+		errs[i] = b.Update(ids[i], UpdateOptions{Assignee: &assignee})
+	})
+
+	failed := make(map[string]error)
+	for i, err := range errs {
+		if err != nil {
+			failed[ids[i]] = err
+		}
+	}
+	return failed, nil
+}
+
+// UpdateMany applies the same opts to each issue in ids, e.g. to bump
+// priority or add a label across a set of issues. bd update takes one issue
+// at a time, so issues are updated in the given order, or b.Concurrency at a
+// time, and a failure does not stop the rest. The returned map holds an
+// error for each issue that could not be updated and is empty when all
+// succeed. The error return is reserved for invalid input, which is
+// rejected before any update is made. Repeated IDs are updated once.
+func (b *Beads) UpdateMany(ids []string, opts UpdateOptions) (map[string]error, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("updating batch: empty issue ID")
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	errs := make([]error, len(unique))
+	b.forEachConcurrently(len(unique), func(i int) {
+		errs[i] = b.Update(unique[i], opts)
+	})
+
+	failed := make(map[string]error)
+	for i, err := range errs {
+		if err != nil {
+			failed[unique[i]] = err
+		}
+	}
+	return failed, nil
+}
+
+// DistributeReady plans a round-robin assignment of unassigned ready issues
+// to assignees, in the order bd ready returns them. If issueType is set, only
+// ready issues with the gt:<issueType> label are considered. The plan is not
+// applied; pass it to AssignBatch.
+func (b *Beads) DistributeReady(assignees []string, issueType string) (map[string]string, error) {
+	if len(assignees) == 0 {
+		return nil, fmt.Errorf("distributing ready work: no assignees")
+	}
+
+	var ready []*Issue
+	var err error
+	if issueType != "" {
+		ready, err = b.ReadyWithType(issueType)
+	} else {
+		ready, err = b.Ready()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing ready issues: %w", err)
+	}
+
+	plan := make(map[string]string)
+	next := 0
+	for _, issue := range ready {
+		if issue.Assignee != "" {
+			continue
+		}
+		plan[issue.ID] = assignees[next%len(assignees)]
+		next++
+	}
+	return plan, nil
+}
+
+// Reassign hands an issue directly to a new assignee, e.g. when recovering
+// work from a dead worker. Unlike Release followed by a Claim, the status,
+// assignee and reason note are applied in a single bd update, so there is no
+// window in which the work is unowned.
+func (b *Beads) Reassign(id, newAssignee, reason string) error {
+	if newAssignee == "" {
+		return fmt.Errorf("reassigning %s: new assignee is required (use Release to unassign)", id)
+	}
+
+	args := []string{"update", id, "--status=in_progress", "--assignee=" + newAssignee}
+
+	// Add reason as a note if provided
+	if reason != "" {
+		args = append(args, "--notes=Reassigned to "+newAssignee+": "+reason)
+	}
+
+	_, err := b.run(args...)
+	return err
+}
+
 // AddDependency adds a dependency: issue depends on dependsOn.
 func (b *Beads) AddDependency(issue, dependsOn string) error {
 	_, err := b.run("dep", "add", issue, dependsOn)
@@ -835,14 +1665,66 @@ func (b *Beads) RemoveDependency(issue, dependsOn string) error {
 	return err
 }
 
-// Sync syncs beads with remote.
+// EnsureDependency makes issue depend on dependsOn, calling bd dep add only
+// if the dependency isn't already there, so reconciliation can be re-run.
+func (b *Beads) EnsureDependency(issue, dependsOn string) error {
+	present, err := b.hasDependency(issue, dependsOn)
+	if err != nil || present {
+		return err
+	}
+	return b.AddDependency(issue, dependsOn)
+}
+
+// EnsureNoDependency removes issue's dependency on dependsOn if it has one,
+// and does nothing otherwise.
+func (b *Beads) EnsureNoDependency(issue, dependsOn string) error {
+	present, err := b.hasDependency(issue, dependsOn)
+	if err != nil || !present {
+		return err
+	}
+	return b.RemoveDependency(issue, dependsOn)
+}
+
+// hasDependency reports whether issue has a blocking dependency on
+// dependsOn. Other dependency types, such as parent-child, do not count, so
+// a parent link is neither mistaken for a blocker nor removed as one.
+// Cross-rig dependencies are matched by their unwrapped ID. The untyped
+// depends_on list is only consulted when bd reports no typed dependencies.
+func (b *Beads) hasDependency(issue, dependsOn string) (bool, error) {
+	shown, err := b.Show(issue)
+	if err != nil {
+		return false, err
+	}
+	for _, dep := range shown.Dependencies {
+		if isBlockingDep(dep) && ExtractIssueID(dep.ID) == dependsOn {
+			return true, nil
+		}
+	}
+	if len(shown.Dependencies) > 0 {
+		return false, nil
+	}
+	for _, id := range shown.DependsOn {
+		if ExtractIssueID(id) == dependsOn {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Sync syncs beads with remote. It waits for running batch operations to
+// finish first.
 func (b *Beads) Sync() error {
+	batchSyncLock.Lock()
+	defer batchSyncLock.Unlock()
 	_, err := b.run("sync")
 	return err
 }
 
-// SyncFromMain syncs beads updates from main branch.
+// SyncFromMain syncs beads updates from main branch. It waits for running
+// batch operations to finish first.
 func (b *Beads) SyncFromMain() error {
+	batchSyncLock.Lock()
+	defer batchSyncLock.Unlock()
 	_, err := b.run("sync", "--from-main")
 	return err
 }
@@ -866,6 +1748,48 @@ func (b *Beads) GetSyncStatus() (*SyncStatus, error) {
 	return &status, nil
 }
 
+// Conflict resolution strategies accepted by ResolveConflict.
+const (
+	ConflictStrategyOurs   = "ours"   // Keep the local version of the bead
+	ConflictStrategyTheirs = "theirs" // Take the remote version of the bead
+	ConflictStrategyNewest = "newest" // Take whichever version was updated last
+)
+
+// SyncConflict describes a bead with unresolved sync conflicts.
+type SyncConflict struct {
+	BeadID string
+}
+
+// Conflicts returns the beads that currently have unresolved sync conflicts.
+// Returns an empty slice when the sync status reports no conflicts.
+func (b *Beads) Conflicts() ([]SyncConflict, error) {
+	status, err := b.GetSyncStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]SyncConflict, 0, len(status.Conflicts))
+	for _, id := range status.Conflicts {
+		conflicts = append(conflicts, SyncConflict{BeadID: id})
+	}
+	return conflicts, nil
+}
+
+// ResolveConflict resolves the sync conflict on a single bead using the given
+// strategy (ours, theirs, or newest). This lets automation settle trivial
+// conflicts, e.g. always taking the newest version of agent-state beads.
+func (b *Beads) ResolveConflict(beadID, strategy string) error {
+	switch strategy {
+	case ConflictStrategyOurs, ConflictStrategyTheirs, ConflictStrategyNewest:
+	default:
+		return fmt.Errorf("invalid conflict strategy %q: must be one of %s, %s, %s",
+			strategy, ConflictStrategyOurs, ConflictStrategyTheirs, ConflictStrategyNewest)
+	}
+
+	_, err := b.run("resolve-conflicts", beadID, "--strategy="+strategy)
+	return err
+}
+
 // Stats returns repository statistics.
 func (b *Beads) Stats() (string, error) {
 	out, err := b.run("stats")
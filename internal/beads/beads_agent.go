@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/gofrs/flock"
 
@@ -46,6 +49,32 @@ type AgentFields struct {
 	// See internal/config/roles/*.toml and config-based-roles.md.
 }
 
+// Known values for the enum-like AgentFields, checked by Validate.
+var (
+	AgentRoleTypes  = []string{"mayor", "deacon", "witness", "refinery", "polecat", "crew", "dog"}
+	AgentStates     = []string{"spawning", "working", "done", "stuck", "idle", "running", "awaiting-gate", "nuked"}
+	CleanupStatuses = []string{"clean", "has_uncommitted", "has_stash", "has_unpushed"}
+)
+
+// Validate checks RoleType, AgentState and CleanupStatus against their known
+// values, so typos like "polcat" are rejected instead of persisted. Empty
+// fields are allowed. All invalid fields are reported together.
+func (f *AgentFields) Validate() error {
+	if f == nil {
+		return nil
+	}
+	var errs []error
+	check := func(name, value string, known []string) {
+		if value != "" && !slices.Contains(known, value) {
+			errs = append(errs, fmt.Errorf("invalid %s %q: must be one of %s", name, value, strings.Join(known, ", ")))
+		}
+	}
+	check("role_type", f.RoleType, AgentRoleTypes)
+	check("agent_state", f.AgentState, AgentStates)
+	check("cleanup_status", f.CleanupStatus, CleanupStatuses)
+	return errors.Join(errs...)
+}
+
 // Notification level constants
 const (
 	NotifyVerbose = "verbose" // All notifications (mail, convoy events, etc.)
@@ -165,6 +194,9 @@ func (b *Beads) CreateAgentBead(id, title string, fields *AgentFields) (*Issue,
 	if IsFlagLikeTitle(title) {
 		return nil, fmt.Errorf("refusing to create agent bead: %w (got %q)", ErrFlagTitle, title)
 	}
+	if err := fields.Validate(); err != nil {
+		return nil, fmt.Errorf("refusing to create agent bead %s: %w", id, err)
+	}
 
 	// Resolve where this bead will actually be written (handles multi-repo routing)
 	targetDir := ResolveRoutingTarget(b.getTownRoot(), id, b.getResolvedBeadsDir())
@@ -422,6 +454,35 @@ func (b *Beads) UpdateAgentState(id string, state string, hookBead *string) erro
 	return nil
 }
 
+// AgentState returns an agent bead's state as UpdateAgentState records it,
+// via `bd show`. See AgentStateOf.
+func (b *Beads) AgentState(id string) (string, error) {
+	out, err := b.runWithRouting("show", id, "--json")
+	if err != nil {
+		return "", err
+	}
+	raw, err := firstShowResult(out)
+	if err != nil {
+		return "", err
+	}
+	var issue Issue
+	if err := json.Unmarshal(raw, &issue); err != nil {
+		return "", fmt.Errorf("parsing bd show output: %w", err)
+	}
+	return AgentStateOf(&issue), nil
+}
+
+// AgentStateOf returns the agent_state column of an agent bead shown by bd,
+// which `bd agent state` writes. The description copy is no longer
+// rewritten on state changes, so it is only used when the column is empty,
+// as on agent beads created before the column existed.
+func AgentStateOf(issue *Issue) string {
+	if issue.AgentState != "" {
+		return issue.AgentState
+	}
+	return ParseAgentFields(issue.Description).AgentState
+}
+
 // SetHookBead sets the hook_bead slot on an agent bead.
 // This is a convenience wrapper that only sets the hook without changing agent_state.
 // Per gt-zecmc: agent_state ("running", "dead", "idle") is observable from tmux
@@ -566,9 +627,63 @@ func (b *Beads) GetAgentBead(id string) (*Issue, *AgentFields, error) {
 	}
 
 	fields := ParseAgentFields(issue.Description)
+	fields.AgentState = AgentStateOf(issue)
+
+	// The hook slot, shown as hook_bead, is authoritative; the description
+	// copy is no longer rewritten on every state change and may be stale.
+	// An empty slot keeps the description value, which CreateAgentBead
+	// leaves as the backup when setting the slot fails.
+	if issue.HookBead != "" {
+		fields.HookBead = issue.HookBead
+	}
 	return issue, fields, nil
 }
 
+// AgentSlots holds an agent bead's slot values as stored by `bd slot set`.
+type AgentSlots struct {
+	Hook string
+	Role string
+}
+
+// AgentSlots reads the hook and role slots of an agent bead with
+// `bd slot show`. Empty slots are returned as "".
+func (b *Beads) AgentSlots(agentBeadID string) (*AgentSlots, error) {
+	out, err := b.runWithRouting("slot", "show", agentBeadID, "--json")
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Slots struct {
+			Hook *string `json:"hook"`
+			Role *string `json:"role"`
+		} `json:"slots"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("parsing bd slot show output: %w", err)
+	}
+
+	slots := &AgentSlots{}
+	if result.Slots.Hook != nil {
+		slots.Hook = *result.Slots.Hook
+	}
+	if result.Slots.Role != nil {
+		slots.Role = *result.Slots.Role
+	}
+	return slots, nil
+}
+
+// RoleBead returns the role slot of an agent bead. AgentFields no longer
+// carries a role bead (roles are config-based), so the slot is the only
+// place one is recorded, for agents created before that change.
+func (b *Beads) RoleBead(agentBeadID string) (string, error) {
+	slots, err := b.AgentSlots(agentBeadID)
+	if err != nil {
+		return "", err
+	}
+	return slots.Role, nil
+}
+
 // ListAgentBeads returns all agent beads in a single query.
 // Returns a map of agent bead ID to Issue.
 func (b *Beads) ListAgentBeads() (map[string]*Issue, error) {
@@ -589,3 +704,86 @@ func (b *Beads) ListAgentBeads() (map[string]*Issue, error) {
 
 	return result, nil
 }
+
+// terminalAgentStates are the agent states after which an agent bead no
+// longer tracks a live agent.
+var terminalAgentStates = []string{"done", "nuked"}
+
+// CloseStaleAgentBeads closes open agent beads whose agent_state is terminal
+// (done or nuked) and that have not been updated for olderThan, returning
+// their IDs sorted. Beads in any other state, including an unknown one, are
+// never touched. With dryRun, the IDs are returned but nothing is closed.
+func (b *Beads) CloseStaleAgentBeads(olderThan time.Duration, dryRun bool) ([]string, error) {
+	agents, err := b.ListAgentBeads()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var stale []string
+	for id, issue := range agents {
+		if !IsAgentSessionBead(id) {
+			continue
+		}
+		if !slices.Contains(terminalAgentStates, AgentStateOf(issue)) {
+			continue
+		}
+		updated, err := issue.UpdatedTime()
+		if err != nil || !updated.Before(cutoff) {
+			continue
+		}
+		stale = append(stale, id)
+	}
+	sort.Strings(stale)
+
+	if dryRun || len(stale) == 0 {
+		return stale, nil
+	}
+	if err := b.CloseWithReason(fmt.Sprintf("stale agent bead: terminal state, no update for %s", olderThan), stale...); err != nil {
+		return nil, fmt.Errorf("closing stale agent beads: %w", err)
+	}
+	return stale, nil
+}
+
+// AgentBead is an agent bead with its parsed fields and the role and name
+// encoded in its ID.
+type AgentBead struct {
+	Issue  *Issue
+	Fields *AgentFields
+	Role   string
+	Name   string // empty for singleton roles (witness, refinery)
+}
+
+// AgentRoster returns the agent beads of rig, sorted by role then name.
+// Membership comes from the rig encoded in each bead ID (see
+// ParseAgentBeadID), so town-level agents are never included. The bd
+// columns agent_state and hook_bead, when present, override the
+// description's copies.
+func (b *Beads) AgentRoster(rig string) ([]AgentBead, error) {
+	agents, err := b.ListAgentBeads()
+	if err != nil {
+		return nil, err
+	}
+
+	var roster []AgentBead
+	for id, issue := range agents {
+		agentRig, role, name, ok := ParseAgentBeadID(id)
+		if !ok || agentRig != rig {
+			continue
+		}
+		fields := ParseAgentFields(issue.Description)
+		fields.AgentState = AgentStateOf(issue)
+		if issue.HookBead != "" {
+			fields.HookBead = issue.HookBead
+		}
+		roster = append(roster, AgentBead{Issue: issue, Fields: fields, Role: role, Name: name})
+	}
+
+	sort.Slice(roster, func(i, j int) bool {
+		if roster[i].Role != roster[j].Role {
+			return roster[i].Role < roster[j].Role
+		}
+		return roster[i].Name < roster[j].Name
+	})
+	return roster, nil
+}
@@ -0,0 +1,218 @@
+package beads
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateAgentBeadRejectsInvalidFields(t *testing.T) {
+	fake := installFakeBd(t)
+
+	_, err := New(t.TempDir()).CreateAgentBead("gt-gastown-polecat-Toast", "Toast", &AgentFields{RoleType: "polcat"})
+	if err == nil || !strings.Contains(err.Error(), "invalid role_type") {
+		t.Errorf("CreateAgentBead() error = %v, want invalid role_type", err)
+	}
+	if creates := fake.callsMatching(t, "create"); len(creates) != 0 {
+		t.Errorf("invalid fields should not create a bead, got %v", creates)
+	}
+}
+
+// --- CloseStaleAgentBeads ---
+
+func staleAgentFixture(t *testing.T) *fakeBd {
+	t.Helper()
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	list := fmt.Sprintf(`[
+		{"id":"gt-gastown-polecat-old","agent_state":"done","updated_at":%q},
+		{"id":"gt-gastown-polecat-nuked","description":"agent_state: nuked","updated_at":%q},
+		{"id":"gt-gastown-polecat-recent","agent_state":"done","updated_at":%q},
+		{"id":"gt-gastown-polecat-busy","agent_state":"working","updated_at":%q},
+		{"id":"gt-gastown-polecat-unknown","updated_at":%q},
+		{"id":"gt-not-an-agent","agent_state":"done","updated_at":%q}
+	]`, old, old, recent, old, old, old)
+	return installFakeBd(t, fakeBdRule{Match: "list --label=gt:agent", Outputs: []string{list}})
+}
+
+func TestCloseStaleAgentBeads(t *testing.T) {
+	fake := staleAgentFixture(t)
+
+	closed, err := New(t.TempDir()).CloseStaleAgentBeads(24*time.Hour, false)
+	if err != nil {
+		t.Fatalf("CloseStaleAgentBeads() error: %v", err)
+	}
+	want := []string{"gt-gastown-polecat-nuked", "gt-gastown-polecat-old"}
+	if !reflect.DeepEqual(closed, want) {
+		t.Errorf("closed = %v, want %v", closed, want)
+	}
+	closes := fake.callsMatching(t, "close")
+	if len(closes) != 1 || !strings.Contains(closes[0], "close gt-gastown-polecat-nuked gt-gastown-polecat-old --reason=") {
+		t.Errorf("close calls = %v, want one close of both stale beads", closes)
+	}
+}
+
+func TestCloseStaleAgentBeads_DryRun(t *testing.T) {
+	fake := staleAgentFixture(t)
+
+	closed, err := New(t.TempDir()).CloseStaleAgentBeads(24*time.Hour, true)
+	if err != nil {
+		t.Fatalf("CloseStaleAgentBeads() error: %v", err)
+	}
+	if len(closed) != 2 {
+		t.Errorf("dry run reported %v, want the two stale beads", closed)
+	}
+	if closes := fake.callsMatching(t, "close"); len(closes) != 0 {
+		t.Errorf("dry run should not close anything, got %v", closes)
+	}
+}
+
+// --- GetAgentBead slots ---
+
+const slotAgentShow = `[{"id":"gt-gastown-polecat-Toast","issue_type":"agent","labels":["gt:agent"],` +
+	`"description":"role_type: polecat\nrig: gastown\nagent_state: working\nhook_bead: gt-stale"}]`
+
+func TestGetAgentBeadPrefersHookSlot(t *testing.T) {
+	show := strings.Replace(slotAgentShow, `"issue_type"`, `"hook_bead":"gt-current","issue_type"`, 1)
+	fake := installFakeBd(t, fakeBdRule{Match: "show gt-gastown-polecat-Toast --json", Outputs: []string{show}})
+
+	_, fields, err := New(t.TempDir()).GetAgentBead("gt-gastown-polecat-Toast")
+	if err != nil {
+		t.Fatalf("GetAgentBead() error: %v", err)
+	}
+	if fields.HookBead != "gt-current" {
+		t.Errorf("HookBead = %q, want slot value %q", fields.HookBead, "gt-current")
+	}
+	if fields.RoleType != "polecat" {
+		t.Errorf("RoleType = %q, want description value %q", fields.RoleType, "polecat")
+	}
+	if calls := fake.calls(t); len(calls) != 1 {
+		t.Errorf("GetAgentBead made %d bd calls, want 1: %v", len(calls), calls)
+	}
+}
+
+func TestGetAgentBeadEmptyHookSlotKeepsDescription(t *testing.T) {
+	// Setting the slot failed at creation, so only the description has the hook.
+	installFakeBd(t, fakeBdRule{Match: "show gt-gastown-polecat-Toast --json", Outputs: []string{slotAgentShow}})
+
+	_, fields, err := New(t.TempDir()).GetAgentBead("gt-gastown-polecat-Toast")
+	if err != nil {
+		t.Fatalf("GetAgentBead() error: %v", err)
+	}
+	if fields.HookBead != "gt-stale" {
+		t.Errorf("HookBead = %q, want the description backup when the slot is empty", fields.HookBead)
+	}
+}
+
+func TestRoleBeadReadsSlot(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "slot show gt-gastown-polecat-Toast --json", Outputs: []string{
+		`{"agent":"gt-gastown-polecat-Toast","slots":{"hook":"gt-current","role":"gt-polecat-role"}}`}})
+
+	role, err := New(t.TempDir()).RoleBead("gt-gastown-polecat-Toast")
+	if err != nil {
+		t.Fatalf("RoleBead() error: %v", err)
+	}
+	if role != "gt-polecat-role" {
+		t.Errorf("RoleBead() = %q, want %q", role, "gt-polecat-role")
+	}
+}
+
+func TestAgentStatePrefersColumn(t *testing.T) {
+	// The description still says working; bd agent state has since set stuck.
+	show := `[{"id":"gt-gastown-polecat-Toast","issue_type":"agent","labels":["gt:agent"],"agent_state":"stuck",` +
+		`"description":"role_type: polecat\nrig: gastown\nagent_state: working"}]`
+	installFakeBd(t, fakeBdRule{Match: "show gt-gastown-polecat-Toast --json", Outputs: []string{show}})
+
+	b := New(t.TempDir())
+	state, err := b.AgentState("gt-gastown-polecat-Toast")
+	if err != nil {
+		t.Fatalf("AgentState() error: %v", err)
+	}
+	if state != "stuck" {
+		t.Errorf("AgentState() = %q, want column value %q", state, "stuck")
+	}
+
+	_, fields, err := b.GetAgentBead("gt-gastown-polecat-Toast")
+	if err != nil {
+		t.Fatalf("GetAgentBead() error: %v", err)
+	}
+	if fields.AgentState != "stuck" {
+		t.Errorf("GetAgentBead() AgentState = %q, want column value %q", fields.AgentState, "stuck")
+	}
+}
+
+func TestAgentStateFallsBackToDescription(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "show gt-gastown-polecat-Toast --json", Outputs: []string{slotAgentShow}})
+
+	state, err := New(t.TempDir()).AgentState("gt-gastown-polecat-Toast")
+	if err != nil {
+		t.Fatalf("AgentState() error: %v", err)
+	}
+	if state != "working" {
+		t.Errorf("AgentState() = %q, want description value %q without a column", state, "working")
+	}
+}
+
+// --- AgentRoster ---
+
+func TestAgentRoster(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "list --label=gt:agent", Outputs: []string{`[
+		{"id":"gt-gastown-witness","description":"role_type: witness\nrig: gastown\nagent_state: working"},
+		{"id":"gt-gastown-polecat-Toast","agent_state":"done","description":"agent_state: working"},
+		{"id":"gt-gastown-polecat-Nux","hook_bead":"gt-abc"},
+		{"id":"gt-gastown-crew-max"},
+		{"id":"gt-gastown-refinery"},
+		{"id":"gt-beads-polecat-Toast"},
+		{"id":"gt-beads-witness"},
+		{"id":"hq-mayor"},
+		{"id":"hq-dog-alpha"}
+	]`}})
+
+	roster, err := New(t.TempDir()).AgentRoster("gastown")
+	if err != nil {
+		t.Fatalf("AgentRoster() error: %v", err)
+	}
+
+	var got []string
+	for _, a := range roster {
+		got = append(got, a.Issue.ID)
+	}
+	want := []string{
+		"gt-gastown-crew-max",
+		"gt-gastown-polecat-Nux",
+		"gt-gastown-polecat-Toast",
+		"gt-gastown-refinery",
+		"gt-gastown-witness",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("roster = %v, want %v", got, want)
+	}
+
+	byID := map[string]AgentBead{}
+	for _, a := range roster {
+		byID[a.Issue.ID] = a
+	}
+	if a := byID["gt-gastown-polecat-Toast"]; a.Role != "polecat" || a.Name != "Toast" || a.Fields.AgentState != "done" {
+		t.Errorf("Toast = role %q name %q state %q, want polecat Toast done (column wins)", a.Role, a.Name, a.Fields.AgentState)
+	}
+	if a := byID["gt-gastown-polecat-Nux"]; a.Fields.HookBead != "gt-abc" {
+		t.Errorf("Nux HookBead = %q, want gt-abc", a.Fields.HookBead)
+	}
+	if a := byID["gt-gastown-witness"]; a.Name != "" || a.Fields.AgentState != "working" {
+		t.Errorf("witness = name %q state %q, want singleton in state working", a.Name, a.Fields.AgentState)
+	}
+}
+
+func TestAgentRosterUnknownRig(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "list --label=gt:agent", Outputs: []string{`[{"id":"gt-gastown-witness"}]`}})
+
+	roster, err := New(t.TempDir()).AgentRoster("nowhere")
+	if err != nil {
+		t.Fatalf("AgentRoster() error: %v", err)
+	}
+	if len(roster) != 0 {
+		t.Errorf("roster = %v, want empty", roster)
+	}
+}
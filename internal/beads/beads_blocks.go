@@ -0,0 +1,54 @@
+// Package beads provides transitive blocking queries over dependencies.
+package beads
+
+// IsBlockedBy reports whether a is blocked by b, directly or through a chain
+// of blocking dependencies (a depends on x, x depends on b, ...). Closed
+// blockers still count: this answers whether the relationship exists, not
+// whether it currently gates work. Parent-child links are not followed.
+//
+// The walk fetches one dependency level per bd call and visits each issue
+// once, so dependency cycles terminate.
+func (b *Beads) IsBlockedBy(a, bID string) (bool, error) {
+	start, err := b.Show(a)
+	if err != nil {
+		return false, err
+	}
+
+	seen := map[string]bool{start.ID: true}
+	frontier := []*Issue{start}
+	for len(frontier) > 0 {
+		var next []string
+		for _, issue := range frontier {
+			for _, dep := range issue.Dependencies {
+				if !isBlockingDep(dep) {
+					continue
+				}
+				if dep.ID == bID {
+					return true, nil
+				}
+				if !seen[dep.ID] {
+					seen[dep.ID] = true
+					next = append(next, dep.ID)
+				}
+			}
+		}
+
+		issues, err := b.ShowMultiple(next)
+		if err != nil {
+			return false, err
+		}
+		frontier = frontier[:0]
+		for _, id := range next {
+			if issue, ok := issues[id]; ok {
+				frontier = append(frontier, issue)
+			}
+		}
+	}
+	return false, nil
+}
+
+// Blocks reports whether a blocks b, directly or transitively. It is
+// IsBlockedBy with the arguments swapped.
+func (b *Beads) Blocks(a, bID string) (bool, error) {
+	return b.IsBlockedBy(bID, a)
+}
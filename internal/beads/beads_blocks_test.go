@@ -0,0 +1,74 @@
+package beads
+
+import "testing"
+
+// installChainBd fakes gt-a depends on gt-b depends on gt-c, which depends
+// back on gt-a, plus a parent-child link from gt-a to gt-epic and an
+// unrelated gt-z.
+func installChainBd(t *testing.T) *fakeBd {
+	t.Helper()
+	return installFakeBd(t,
+		fakeBdRule{Match: "show gt-a --json", Outputs: []string{`[{"id":"gt-a","dependencies":[
+			{"id":"gt-b","status":"open","dependency_type":"blocks"},
+			{"id":"gt-epic","status":"open","dependency_type":"parent-child"}]}]`}},
+		fakeBdRule{Match: "show gt-z --json", Outputs: []string{`[{"id":"gt-z"}]`}},
+		fakeBdRule{Match: "show --json gt-b", Outputs: []string{`[{"id":"gt-b","dependencies":[
+			{"id":"gt-c","status":"closed","dependency_type":"blocks"}]}]`}},
+		fakeBdRule{Match: "show --json gt-c", Outputs: []string{`[{"id":"gt-c","dependencies":[
+			{"id":"gt-a","status":"open","dependency_type":"blocks"}]}]`}},
+	)
+}
+
+func TestIsBlockedBy(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"direct", "gt-a", "gt-b", true},
+		{"transitive through closed blocker", "gt-a", "gt-c", true},
+		{"parent-child is not blocking", "gt-a", "gt-epic", false},
+		{"unrelated", "gt-a", "gt-z", false},
+		{"no dependencies", "gt-z", "gt-a", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installChainBd(t)
+			got, err := New(t.TempDir()).IsBlockedBy(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("IsBlockedBy(%s, %s) error: %v", tt.a, tt.b, err)
+			}
+			if got != tt.want {
+				t.Errorf("IsBlockedBy(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBlockedBy_CycleTerminates(t *testing.T) {
+	fake := installChainBd(t)
+
+	// gt-a -> gt-b -> gt-c -> gt-a never reaches gt-z.
+	got, err := New(t.TempDir()).IsBlockedBy("gt-a", "gt-z")
+	if err != nil {
+		t.Fatalf("IsBlockedBy() error: %v", err)
+	}
+	if got {
+		t.Error("IsBlockedBy() = true, want false")
+	}
+	if shows := fake.callsMatching(t, "show"); len(shows) != 3 {
+		t.Errorf("show calls = %v, want each issue shown once", shows)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	installChainBd(t)
+	b := New(t.TempDir())
+
+	if got, err := b.Blocks("gt-c", "gt-a"); err != nil || !got {
+		t.Errorf("Blocks(gt-c, gt-a) = %v, %v; want true", got, err)
+	}
+	if got, err := b.Blocks("gt-a", "gt-z"); err != nil || got {
+		t.Errorf("Blocks(gt-a, gt-z) = %v, %v; want false", got, err)
+	}
+}
@@ -0,0 +1,79 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// forCache memoizes For by absolute work directory.
+var forCache = struct {
+	sync.Mutex
+	entries map[string]*forEntry
+}{entries: make(map[string]*forEntry)}
+
+// forEntry is a cached resolution and the redirect files it depended on.
+type forEntry struct {
+	beads *Beads
+	files []redirectStamp
+}
+
+// redirectStamp records a redirect file's state when it was resolved. A
+// missing file is recorded too, so creating one invalidates the entry.
+type redirectStamp struct {
+	path    string
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func stampRedirect(path string) redirectStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return redirectStamp{path: path}
+	}
+	return redirectStamp{path: path, exists: true, modTime: info.ModTime(), size: info.Size()}
+}
+
+func (e *forEntry) fresh() bool {
+	for _, stamp := range e.files {
+		if stampRedirect(stamp.path) != stamp {
+			return false
+		}
+	}
+	return true
+}
+
+// For returns a shared Beads for workDir with its beads directory resolved
+// once, instead of re-reading redirect files on every bd call. The
+// resolution is redone when any redirect file along the chain is created,
+// removed or modified. Safe for concurrent use.
+func For(workDir string) *Beads {
+	key, err := filepath.Abs(workDir)
+	if err != nil {
+		key = filepath.Clean(workDir)
+	}
+
+	forCache.Lock()
+	defer forCache.Unlock()
+
+	if entry, ok := forCache.entries[key]; ok && entry.fresh() {
+		return entry.beads
+	}
+
+	// ResolveBeadsDir reports warnings and removes errant redirects; the
+	// verbose trace supplies the files the result depends on.
+	beadsDir := ResolveBeadsDir(key)
+	res, _ := ResolveBeadsDirVerbose(key)
+
+	var files []redirectStamp
+	for _, hop := range res.Hops {
+		files = append(files, stampRedirect(hop.RedirectFile))
+	}
+	files = append(files, stampRedirect(filepath.Join(beadsDir, "redirect")))
+
+	entry := &forEntry{beads: NewWithBeadsDir(key, beadsDir), files: files}
+	forCache.entries[key] = entry
+	return entry.beads
+}
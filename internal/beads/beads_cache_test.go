@@ -0,0 +1,69 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestForCachesResolution(t *testing.T) {
+	root := t.TempDir()
+	work := filepath.Join(root, "crew")
+	for _, dir := range []string{filepath.Join(work, ".beads"), filepath.Join(root, "a", ".beads"), filepath.Join(root, "b", ".beads")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	redirect := filepath.Join(work, ".beads", "redirect")
+	if err := os.WriteFile(redirect, []byte("../a/.beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	first := For(work)
+	if got, want := first.getResolvedBeadsDir(), filepath.Join(root, "a", ".beads"); got != want {
+		t.Fatalf("resolved %q, want %q", got, want)
+	}
+	if For(work) != first {
+		t.Error("For() should return the cached instance while the redirect is unchanged")
+	}
+
+	if err := os.WriteFile(redirect, []byte("../b/.beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Same size, so force a distinct mtime in case the write landed in the
+	// same timestamp tick.
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(redirect, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	second := For(work)
+	if second == first {
+		t.Fatal("changed redirect should invalidate the cached instance")
+	}
+	if got, want := second.getResolvedBeadsDir(), filepath.Join(root, "b", ".beads"); got != want {
+		t.Errorf("after change resolved %q, want %q", got, want)
+	}
+}
+
+func TestForNoticesNewRedirect(t *testing.T) {
+	root := t.TempDir()
+	work := filepath.Join(root, "crew")
+	for _, dir := range []string{filepath.Join(work, ".beads"), filepath.Join(root, "a", ".beads")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, want := For(work).getResolvedBeadsDir(), filepath.Join(work, ".beads"); got != want {
+		t.Fatalf("resolved %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(work, ".beads", "redirect"), []byte("../a/.beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := For(work).getResolvedBeadsDir(), filepath.Join(root, "a", ".beads"); got != want {
+		t.Errorf("after adding a redirect resolved %q, want %q", got, want)
+	}
+}
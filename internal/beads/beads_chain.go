@@ -0,0 +1,96 @@
+// Package beads provides ancestor chains for breadcrumb display.
+package beads
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrDanglingParent means an issue's parent could not be found. The
+	// chain up to that point is still returned.
+	ErrDanglingParent = errors.New("parent issue not found")
+	// ErrParentCycle means following parents led back to an issue already
+	// in the chain.
+	ErrParentCycle = errors.New("parent cycle detected")
+)
+
+// ParentChain returns id and its ancestors, root first, following Parent via
+// Show until an issue has no parent. For breadcrumbs like
+// "Epic > Feature > Task" the last element is the issue itself.
+//
+// If a parent is missing (e.g. deleted), the walk stops there and the chain
+// collected so far is returned with an error wrapping ErrDanglingParent, so
+// callers can still render it with a warning. A cycle stops the walk the
+// same way with ErrParentCycle.
+func (b *Beads) ParentChain(id string) ([]*Issue, error) {
+	issue, err := b.Show(id)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := []*Issue{issue}
+	seen := map[string]bool{issue.ID: true}
+	for issue.Parent != "" {
+		if seen[issue.Parent] {
+			return reverseIssues(chain), fmt.Errorf("%w: %s has ancestor %s", ErrParentCycle, id, issue.Parent)
+		}
+
+		parent, err := b.Show(issue.Parent)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return reverseIssues(chain), fmt.Errorf("%w: %s (parent of %s)", ErrDanglingParent, issue.Parent, issue.ID)
+			}
+			return nil, fmt.Errorf("showing %s: %w", issue.Parent, err)
+		}
+
+		seen[parent.ID] = true
+		chain = append(chain, parent)
+		issue = parent
+	}
+
+	return reverseIssues(chain), nil
+}
+
+// reverseIssues reverses issues in place and returns it.
+func reverseIssues(issues []*Issue) []*Issue {
+	for i, j := 0, len(issues)-1; i < j; i, j = i+1, j-1 {
+		issues[i], issues[j] = issues[j], issues[i]
+	}
+	return issues
+}
+
+// ReparentBatch sets parentID as the parent of each child, e.g. to file a
+// pile of orphan tasks under a new epic. A child that is parentID itself or
+// one of its ancestors is rejected with ErrParentCycle rather than moved.
+// Failures are reported per child and don't stop the rest; the error return
+// is for problems with parentID itself.
+func (b *Beads) ReparentBatch(parentID string, childIDs ...string) (map[string]error, error) {
+	chain, err := b.ParentChain(parentID)
+	if err != nil && !errors.Is(err, ErrDanglingParent) && !errors.Is(err, ErrParentCycle) {
+		return nil, fmt.Errorf("loading ancestors of %s: %w", parentID, err)
+	}
+	ancestors := make(map[string]bool, len(chain))
+	for _, issue := range chain {
+		ancestors[issue.ID] = true
+	}
+	ancestors[parentID] = true
+
+	errs := make([]error, len(childIDs))
+	b.forEachConcurrently(len(childIDs), func(i int) {
+		id := childIDs[i]
+		if ancestors[id] {
+			errs[i] = fmt.Errorf("%w: %s is an ancestor of %s", ErrParentCycle, id, parentID)
+			return
+		}
+		_, errs[i] = b.run("update", id, "--parent="+parentID)
+	})
+
+	failed := make(map[string]error)
+	for i, err := range errs {
+		if err != nil {
+			failed[childIDs[i]] = err
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,88 @@
+package beads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func chainIDs(chain []*Issue) []string {
+	ids := make([]string, len(chain))
+	for i, issue := range chain {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func TestParentChain(t *testing.T) {
+	installFakeBd(t,
+		fakeBdRule{Match: "show gt-task --json", Outputs: []string{`[{"id":"gt-task","parent":"gt-feat"}]`}},
+		fakeBdRule{Match: "show gt-feat --json", Outputs: []string{`[{"id":"gt-feat","parent":"gt-epic"}]`}},
+		fakeBdRule{Match: "show gt-epic --json", Outputs: []string{`[{"id":"gt-epic"}]`}},
+	)
+
+	chain, err := New(t.TempDir()).ParentChain("gt-task")
+	if err != nil {
+		t.Fatalf("ParentChain() error: %v", err)
+	}
+	got, want := chainIDs(chain), []string{"gt-epic", "gt-feat", "gt-task"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("ParentChain() = %v, want %v", got, want)
+	}
+}
+
+func TestParentChain_DanglingParent(t *testing.T) {
+	installFakeBd(t,
+		fakeBdRule{Match: "show gt-task --json", Outputs: []string{`[{"id":"gt-task","parent":"gt-feat"}]`}},
+		fakeBdRule{Match: "show gt-feat --json", Outputs: []string{`[{"id":"gt-feat","parent":"gt-gone"}]`}},
+		fakeBdRule{Match: "show gt-gone --json", Stderr: "Error: issue not found: gt-gone", Exit: 1},
+	)
+
+	chain, err := New(t.TempDir()).ParentChain("gt-task")
+	if !errors.Is(err, ErrDanglingParent) {
+		t.Errorf("ParentChain() error = %v, want ErrDanglingParent", err)
+	}
+	if got := chainIDs(chain); len(got) != 2 || got[0] != "gt-feat" || got[1] != "gt-task" {
+		t.Errorf("ParentChain() = %v, want partial chain [gt-feat gt-task]", got)
+	}
+}
+
+func TestParentChain_Cycle(t *testing.T) {
+	installFakeBd(t,
+		fakeBdRule{Match: "show gt-a --json", Outputs: []string{`[{"id":"gt-a","parent":"gt-b"}]`}},
+		fakeBdRule{Match: "show gt-b --json", Outputs: []string{`[{"id":"gt-b","parent":"gt-a"}]`}},
+	)
+
+	chain, err := New(t.TempDir()).ParentChain("gt-a")
+	if !errors.Is(err, ErrParentCycle) {
+		t.Errorf("ParentChain() error = %v, want ErrParentCycle", err)
+	}
+	if len(chain) != 2 {
+		t.Errorf("ParentChain() = %v, want the two issues before the cycle", chainIDs(chain))
+	}
+}
+
+func TestReparentBatch(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{Match: "show gt-epic --json", Outputs: []string{`[{"id":"gt-epic","parent":"gt-root"}]`}},
+		fakeBdRule{Match: "show gt-root --json", Outputs: []string{`[{"id":"gt-root"}]`}},
+	)
+
+	failed, err := New(t.TempDir()).ReparentBatch("gt-epic", "gt-a", "gt-root", "gt-b")
+	if err != nil {
+		t.Fatalf("ReparentBatch() error: %v", err)
+	}
+	if len(failed) != 1 || !errors.Is(failed["gt-root"], ErrParentCycle) {
+		t.Errorf("failed = %v, want only gt-root rejected as a cycle", failed)
+	}
+
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 2 {
+		t.Fatalf("expected updates for gt-a and gt-b only, got %v", updates)
+	}
+	for i, id := range []string{"gt-a", "gt-b"} {
+		if !strings.Contains(updates[i], "update "+id+" --parent=gt-epic") {
+			t.Errorf("update %d = %q, want %s moved under gt-epic", i, updates[i], id)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package beads
+
+import "sync"
+
+// batchSyncLock keeps bd sync from running while batch operations are
+// writing. Batch workers hold it shared; Sync and SyncFromMain take it
+// exclusively, so a sync never interleaves with concurrent updates.
+var batchSyncLock sync.RWMutex
+
+// forEachConcurrently calls fn(i) for every i in [0, n), running at most
+// b.Concurrency calls at once. Callers write results into slots indexed by
+// i, which keeps output ordering deterministic regardless of scheduling.
+func (b *Beads) forEachConcurrently(n int, fn func(i int)) {
+	batchSyncLock.RLock()
+	defer batchSyncLock.RUnlock()
+
+	workers := b.Concurrency
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
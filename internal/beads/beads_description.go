@@ -0,0 +1,57 @@
+package beads
+
+import "fmt"
+
+// DefaultDescriptionSeparator separates existing description text from
+// text added by AppendDescription and PrependDescription.
+const DefaultDescriptionSeparator = "\n\n"
+
+// AppendDescription adds text to the end of an issue's description, after
+// separator (DefaultDescriptionSeparator if empty). The separator is
+// omitted when the description is empty.
+//
+// This reads the description, edits it and writes it back, so an update
+// between the read and the write is lost. For text that several agents may
+// add at once, use notes (bd update --append-notes), which bd appends
+// atomically.
+func (b *Beads) AppendDescription(id, text, separator string) error {
+	return b.editDescription(id, func(current string) string {
+		return joinDescription(current, text, separator)
+	})
+}
+
+// PrependDescription adds text to the start of an issue's description,
+// before separator. It has the same read-modify-write race as
+// AppendDescription.
+func (b *Beads) PrependDescription(id, text, separator string) error {
+	return b.editDescription(id, func(current string) string {
+		return joinDescription(text, current, separator)
+	})
+}
+
+func (b *Beads) editDescription(id string, edit func(string) string) error {
+	issue, err := b.Show(id)
+	if err != nil {
+		return fmt.Errorf("reading description of %s: %w", id, err)
+	}
+	description := edit(issue.Description)
+	if err := b.Update(id, UpdateOptions{Description: &description}); err != nil {
+		return fmt.Errorf("updating description of %s: %w", id, err)
+	}
+	return nil
+}
+
+// joinDescription joins first and second with separator, dropping the
+// separator when either is empty.
+func joinDescription(first, second, separator string) string {
+	if first == "" {
+		return second
+	}
+	if second == "" {
+		return first
+	}
+	if separator == "" {
+		separator = DefaultDescriptionSeparator
+	}
+	return first + separator + second
+}
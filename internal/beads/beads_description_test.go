@@ -0,0 +1,57 @@
+package beads
+
+import (
+	"strings"
+	"testing"
+)
+
+const describedShow = `[{"id":"gt-1","title":"Thing","description":"Existing body"}]`
+
+func TestAppendDescription(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "show gt-1 ", Outputs: []string{describedShow}})
+
+	if err := New(t.TempDir()).AppendDescription("gt-1", "More detail", " | "); err != nil {
+		t.Fatalf("AppendDescription() error: %v", err)
+	}
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 1 || !strings.HasSuffix(updates[0], "--description=Existing body | More detail") {
+		t.Errorf("update calls = %v, want the existing body kept before the new text", updates)
+	}
+}
+
+func TestPrependDescription(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "show gt-1 ", Outputs: []string{describedShow}})
+
+	if err := New(t.TempDir()).PrependDescription("gt-1", "Heads up", " | "); err != nil {
+		t.Fatalf("PrependDescription() error: %v", err)
+	}
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 1 || !strings.HasSuffix(updates[0], "--description=Heads up | Existing body") {
+		t.Errorf("update calls = %v, want the new text before the existing body", updates)
+	}
+}
+
+func TestAppendDescriptionDefaultSeparator(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "show gt-1 ", Outputs: []string{describedShow}})
+
+	if err := New(t.TempDir()).AppendDescription("gt-1", "More detail", ""); err != nil {
+		t.Fatalf("AppendDescription() error: %v", err)
+	}
+	// The blank line splits the logged update across log lines.
+	if log := strings.Join(fake.calls(t), "\n"); !strings.Contains(log, "--description=Existing body\n\nMore detail") {
+		t.Errorf("bd calls:\n%s\nwant a blank line between the existing body and the new text", log)
+	}
+}
+
+func TestJoinDescription(t *testing.T) {
+	tests := []struct{ first, second, want string }{
+		{"", "new", "new"},
+		{"old", "", "old"},
+		{"old", "new", "old--new"},
+	}
+	for _, tt := range tests {
+		if got := joinDescription(tt.first, tt.second, "--"); got != tt.want {
+			t.Errorf("joinDescription(%q, %q) = %q, want %q", tt.first, tt.second, got, tt.want)
+		}
+	}
+}
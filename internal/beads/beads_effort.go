@@ -0,0 +1,56 @@
+package beads
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EffortLevels are the accepted effort levels, smallest first. The
+// wasteland uses the same vocabulary for wanted items.
+var EffortLevels = []string{"trivial", "small", "medium", "large", "epic"}
+
+// EffortLabelPrefix prefixes the label that records a local issue's effort
+// (e.g. "effort:medium").
+const EffortLabelPrefix = "effort:"
+
+// EffortOf returns the effort recorded on issue by an effort: label. It
+// reports false when there is none or the level is not one of EffortLevels.
+func EffortOf(issue *Issue) (string, bool) {
+	for _, label := range issue.Labels {
+		if level, ok := strings.CutPrefix(label, EffortLabelPrefix); ok && slices.Contains(EffortLevels, level) {
+			return level, true
+		}
+	}
+	return "", false
+}
+
+// SetEffort records level as id's effort, replacing any effort: label it
+// already has.
+func (b *Beads) SetEffort(id, level string) error {
+	if !slices.Contains(EffortLevels, level) {
+		return fmt.Errorf("invalid effort %q: must be one of %s", level, strings.Join(EffortLevels, ", "))
+	}
+
+	issue, err := b.Show(id)
+	if err != nil {
+		return err
+	}
+
+	want := EffortLabelPrefix + level
+	var remove []string
+	for _, label := range issue.Labels {
+		if strings.HasPrefix(label, EffortLabelPrefix) && label != want {
+			remove = append(remove, label)
+		}
+	}
+	if HasLabel(issue, want) && len(remove) == 0 {
+		return nil
+	}
+
+	opts := UpdateOptions{RemoveLabels: remove}
+	if !HasLabel(issue, want) {
+		opts.AddLabels = []string{want}
+	}
+	return b.Update(id, opts)
+}
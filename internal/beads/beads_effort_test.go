@@ -0,0 +1,60 @@
+package beads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEffortOf(t *testing.T) {
+	tests := []struct {
+		labels []string
+		want   string
+		ok     bool
+	}{
+		{[]string{"gt:task", "effort:large"}, "large", true},
+		{[]string{"gt:task"}, "", false},
+		{[]string{"effort:huge"}, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := EffortOf(&Issue{Labels: tt.labels})
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("EffortOf(%v) = %q, %v; want %q, %v", tt.labels, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSetEffort(t *testing.T) {
+	t.Run("replaces existing", func(t *testing.T) {
+		fake := installFakeBd(t, fakeBdRule{Match: "show gt-1 --json", Outputs: []string{`[{"id":"gt-1","labels":["gt:task","effort:small"]}]`}})
+
+		if err := New(t.TempDir()).SetEffort("gt-1", "large"); err != nil {
+			t.Fatalf("SetEffort() error: %v", err)
+		}
+		calls := fake.callsMatching(t, "update")
+		if len(calls) != 1 || !strings.Contains(calls[0], "--add-label=effort:large") || !strings.Contains(calls[0], "--remove-label=effort:small") {
+			t.Errorf("update calls = %v, want effort:small swapped for effort:large", calls)
+		}
+	})
+
+	t.Run("already set", func(t *testing.T) {
+		fake := installFakeBd(t, fakeBdRule{Match: "show gt-1 --json", Outputs: []string{`[{"id":"gt-1","labels":["effort:large"]}]`}})
+
+		if err := New(t.TempDir()).SetEffort("gt-1", "large"); err != nil {
+			t.Fatalf("SetEffort() error: %v", err)
+		}
+		if calls := fake.callsMatching(t, "update"); len(calls) != 0 {
+			t.Errorf("unchanged effort should not update, got %v", calls)
+		}
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		fake := installFakeBd(t)
+
+		if err := New(t.TempDir()).SetEffort("gt-1", "huge"); err == nil {
+			t.Error("SetEffort() with an unknown level should fail")
+		}
+		if calls := fake.calls(t); len(calls) != 0 {
+			t.Errorf("invalid effort should not exec bd, got %v", calls)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+// Package beads provides JSON export and import of issues for archival.
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// exportPriorities partitions an export into one bd call per priority.
+// bd list has no offset, but bd only accepts priorities 0-4 and every issue
+// has exactly one, so these pages cover every issue without repeats.
+var exportPriorities = []int{0, 1, 2, 3, 4}
+
+// Export writes the issues matching opts to w as a JSON array. Issues are
+// streamed undecoded, one priority page at a time, so fields Issue does not
+// model survive and only one page is held in memory. An empty opts.Status
+// exports every status; opts.Priority >= 0 exports a single page.
+func (b *Beads) Export(opts ListOptions, w io.Writer) error {
+	if opts.Status == "" {
+		opts.Status = "all"
+	}
+	opts.Limit = -1
+
+	priorities := exportPriorities
+	if opts.Priority >= 0 {
+		priorities = []int{opts.Priority}
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	first := true
+	for _, p := range priorities {
+		opts.Priority = p
+		page, err := b.ListRaw(opts)
+		if err != nil {
+			return fmt.Errorf("exporting priority %d: %w", p, err)
+		}
+		for _, raw := range page {
+			sep := ",\n"
+			if first {
+				sep = "\n"
+				first = false
+			}
+			if _, err := io.WriteString(w, sep); err != nil {
+				return err
+			}
+			if _, err := w.Write(raw); err != nil {
+				return err
+			}
+		}
+	}
+	if !first {
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
+
+// Import recreates the issues in a JSON array written by Export and returns
+// how many were created. It is best-effort: issues whose ID already exists
+// are skipped, and a failed issue does not stop the rest (the failures are
+// returned joined). Each issue keeps its ID, title, type, priority,
+// description, status, assignee and labels; dependencies are not restored.
+func (b *Beads) Import(r io.Reader) (int, error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return 0, fmt.Errorf("reading import: %w", err)
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("reading import: expected a JSON array")
+	}
+
+	created := 0
+	var errs []error
+	for dec.More() {
+		var issue Issue
+		if err := dec.Decode(&issue); err != nil {
+			return created, errors.Join(append(errs, fmt.Errorf("parsing import: %w", err))...)
+		}
+		if issue.ID == "" {
+			errs = append(errs, fmt.Errorf("skipping issue %q without an ID", issue.Title))
+			continue
+		}
+
+		ok, err := b.importIssue(&issue)
+		if ok {
+			created++
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("importing %s: %w", issue.ID, err))
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// importIssue creates issue unless its ID already exists, reporting whether
+// it was created. An error after creation (restoring status, assignee or
+// labels) still reports the issue as created.
+func (b *Beads) importIssue(issue *Issue) (bool, error) {
+	if _, err := b.Show(issue.ID); err == nil {
+		return false, nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return false, err
+	}
+
+	opts := CreateOptions{
+		Title:       issue.Title,
+		Priority:    issue.Priority,
+		Description: issue.Description,
+	}
+	if isCreateType(issue.Type) {
+		opts.Type = issue.Type
+	}
+	if _, err := b.CreateWithID(issue.ID, opts); err != nil {
+		return false, err
+	}
+
+	update := UpdateOptions{AddLabels: issue.Labels}
+	if issue.Status != "" && issue.Status != "open" {
+		update.Status = &issue.Status
+	}
+	if issue.Assignee != "" {
+		update.Assignee = &issue.Assignee
+	}
+	if update.Status != nil || update.Assignee != nil || len(update.AddLabels) > 0 {
+		if err := b.Update(issue.ID, update); err != nil {
+			return true, fmt.Errorf("restoring fields: %w", err)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,107 @@
+package beads
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{Match: "list --json --status=all --priority=0 --limit=0", Outputs: []string{`[{"id":"gt-1","title":"A","priority":0,"future":true}]`}},
+		fakeBdRule{Match: "list --json --status=all --priority=2 --limit=0", Outputs: []string{`[{"id":"gt-2","priority":2},{"id":"gt-3","priority":2}]`}},
+	)
+
+	var buf bytes.Buffer
+	if err := New(t.TempDir()).Export(ListOptions{Priority: -1}, &buf); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+
+	var issues []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("export is not a JSON array: %v\n%s", err, buf.String())
+	}
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue["id"].(string))
+	}
+	if strings.Join(ids, ",") != "gt-1,gt-2,gt-3" {
+		t.Errorf("exported IDs = %v, want gt-1,gt-2,gt-3", ids)
+	}
+	if issues[0]["future"] != true {
+		t.Errorf("export dropped an unmodeled field: %v", issues[0])
+	}
+	if pages := fake.callsMatching(t, "list"); len(pages) != len(exportPriorities) {
+		t.Errorf("expected one list page per priority, got %v", pages)
+	}
+}
+
+func TestExport_Empty(t *testing.T) {
+	installFakeBd(t)
+
+	var buf bytes.Buffer
+	if err := New(t.TempDir()).Export(ListOptions{Priority: -1}, &buf); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	var issues []json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil || len(issues) != 0 {
+		t.Errorf("Export() = %q, want an empty JSON array", buf.String())
+	}
+}
+
+func TestImport_SkipsExisting(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{Match: "show gt-1 --json", Outputs: []string{`[{"id":"gt-1"}]`}},
+		fakeBdRule{Match: "show gt-2 --json", Stderr: "Error: issue not found: gt-2", Exit: 1},
+		fakeBdRule{Match: "create", Outputs: []string{`{"id":"gt-2"}`}},
+	)
+
+	input := `[
+		{"id":"gt-1","title":"Existing","priority":1},
+		{"id":"gt-2","title":"New","issue_type":"bug","priority":2,"status":"in_progress","labels":["keep"]}
+	]`
+	n, err := New(t.TempDir()).Import(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Import() created %d, want 1", n)
+	}
+
+	creates := fake.callsMatching(t, "create")
+	if len(creates) != 1 || !strings.Contains(creates[0], "--id=gt-2") || !strings.Contains(creates[0], "--labels=gt:bug") {
+		t.Errorf("creates = %v, want gt-2 created as a bug", creates)
+	}
+	updates := fake.callsMatching(t, "update gt-2")
+	if len(updates) != 1 || !strings.Contains(updates[0], "--status=in_progress") || !strings.Contains(updates[0], "--add-label=keep") {
+		t.Errorf("updates = %v, want status and labels restored", updates)
+	}
+}
+
+func TestImport_RejectsNonArray(t *testing.T) {
+	installFakeBd(t)
+
+	if _, err := New(t.TempDir()).Import(strings.NewReader(`{"id":"gt-1"}`)); err == nil {
+		t.Error("Import() should reject a non-array document")
+	}
+}
+
+func TestImport_CreatesWhenShowIsEmpty(t *testing.T) {
+	// bd show prints an empty array for an unknown ID.
+	fake := installFakeBd(t,
+		fakeBdRule{Match: "show gt-1 --json", Outputs: []string{`[]`}},
+		fakeBdRule{Match: "create", Outputs: []string{`{"id":"gt-1"}`}},
+	)
+
+	n, err := New(t.TempDir()).Import(strings.NewReader(`[{"id":"gt-1","title":"Fresh","priority":2}]`))
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Import() created %d, want 1", n)
+	}
+	if creates := fake.callsMatching(t, "create"); len(creates) != 1 {
+		t.Errorf("creates = %v, want gt-1 created", creates)
+	}
+}
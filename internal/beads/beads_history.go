@@ -0,0 +1,100 @@
+// Package beads provides issue change history derived from bd history.
+package beads
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// HistoryEntry is a single field change in an issue's history.
+type HistoryEntry struct {
+	Field    string    `json:"field"`
+	OldValue string    `json:"old_value"`
+	NewValue string    `json:"new_value"`
+	Actor    string    `json:"actor"`
+	At       time.Time `json:"at"`
+}
+
+// historySnapshot is one element of bd history --json: the issue as of a
+// Dolt commit. bd emits these fields without JSON tags.
+type historySnapshot struct {
+	CommitHash string    `json:"CommitHash"`
+	Committer  string    `json:"Committer"`
+	CommitDate time.Time `json:"CommitDate"`
+	Issue      *Issue    `json:"Issue"`
+}
+
+// historyFields are the issue fields tracked by History, in output order.
+var historyFields = []struct {
+	name  string
+	value func(*Issue) string
+}{
+	{"title", func(i *Issue) string { return i.Title }},
+	{"status", func(i *Issue) string { return i.Status }},
+	{"priority", func(i *Issue) string { return strconv.Itoa(i.Priority) }},
+	{"issue_type", func(i *Issue) string { return i.Type }},
+	{"assignee", func(i *Issue) string { return i.Assignee }},
+	{"description", func(i *Issue) string { return i.Description }},
+}
+
+// History returns the field changes recorded for an issue, oldest first.
+// bd history reports a snapshot of the issue per Dolt commit; consecutive
+// snapshots are diffed into entries, and the first snapshot's non-empty
+// fields are reported as changes from "". Requires the Dolt backend.
+// Returns ErrNotFound if bd has no history for the ID.
+func (b *Beads) History(id string) ([]HistoryEntry, error) {
+	out, err := b.run("history", id, "--json")
+	if err != nil {
+		return nil, err
+	}
+
+	// With no history bd prints a plain-text notice even in JSON mode.
+	if isEmptyOutput(out) || bytes.HasPrefix(bytes.TrimSpace(out), []byte("No history found")) {
+		return nil, ErrNotFound
+	}
+
+	var snapshots []historySnapshot
+	if err := json.Unmarshal(out, &snapshots); err != nil {
+		return nil, fmt.Errorf("parsing bd history output: %w", err)
+	}
+	if len(snapshots) == 0 {
+		return nil, ErrNotFound
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CommitDate.Before(snapshots[j].CommitDate)
+	})
+
+	var entries []HistoryEntry
+	prev := &Issue{}
+	first := true
+	for _, snap := range snapshots {
+		if snap.Issue == nil {
+			continue
+		}
+		for _, f := range historyFields {
+			oldValue, newValue := f.value(prev), f.value(snap.Issue)
+			if first {
+				oldValue = ""
+			}
+			if oldValue == newValue {
+				continue
+			}
+			entries = append(entries, HistoryEntry{
+				Field:    f.name,
+				OldValue: oldValue,
+				NewValue: newValue,
+				Actor:    snap.Committer,
+				At:       snap.CommitDate,
+			})
+		}
+		prev = snap.Issue
+		first = false
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,73 @@
+package beads
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// historyFixture is bd history --json output captured for a bug that was
+// claimed and then closed. bd lists the newest commit first.
+const historyFixture = `[
+  {
+    "CommitHash": "9k3j2h1g0f9e8d7c6b5a4",
+    "Committer": "gastown/polecats/Toast",
+    "CommitDate": "2026-02-03T16:45:00Z",
+    "Issue": {"id": "gt-abc", "title": "Fix auth", "status": "closed", "priority": 1, "issue_type": "bug", "assignee": "gastown/polecats/Toast"}
+  },
+  {
+    "CommitHash": "5f4e3d2c1b0a9z8y7x6w5",
+    "Committer": "gastown/witness",
+    "CommitDate": "2026-02-03T09:12:00Z",
+    "Issue": {"id": "gt-abc", "title": "Fix auth", "status": "in_progress", "priority": 1, "issue_type": "bug", "assignee": "gastown/polecats/Toast"}
+  },
+  {
+    "CommitHash": "1a2b3c4d5e6f7g8h9i0j1",
+    "Committer": "mayor",
+    "CommitDate": "2026-02-02T11:00:00Z",
+    "Issue": {"id": "gt-abc", "title": "Fix auth", "status": "open", "priority": 1, "issue_type": "bug"}
+  }
+]`
+
+func TestHistory(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "history gt-abc --json", Outputs: []string{historyFixture}})
+
+	entries, err := New(t.TempDir()).History("gt-abc")
+	if err != nil {
+		t.Fatalf("History() error: %v", err)
+	}
+
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	want := []HistoryEntry{
+		{Field: "title", NewValue: "Fix auth", Actor: "mayor", At: at("2026-02-02T11:00:00Z")},
+		{Field: "status", NewValue: "open", Actor: "mayor", At: at("2026-02-02T11:00:00Z")},
+		{Field: "priority", NewValue: "1", Actor: "mayor", At: at("2026-02-02T11:00:00Z")},
+		{Field: "issue_type", NewValue: "bug", Actor: "mayor", At: at("2026-02-02T11:00:00Z")},
+		{Field: "status", OldValue: "open", NewValue: "in_progress", Actor: "gastown/witness", At: at("2026-02-03T09:12:00Z")},
+		{Field: "assignee", OldValue: "", NewValue: "gastown/polecats/Toast", Actor: "gastown/witness", At: at("2026-02-03T09:12:00Z")},
+		{Field: "status", OldValue: "in_progress", NewValue: "closed", Actor: "gastown/polecats/Toast", At: at("2026-02-03T16:45:00Z")},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("History() returned %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestHistory_NotFound(t *testing.T) {
+	for _, out := range []string{"No history found for issue gt-nope\n", "", "[]"} {
+		installFakeBd(t, fakeBdRule{Match: "history", Outputs: []string{out}})
+		if _, err := New(t.TempDir()).History("gt-nope"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("History() with output %q: error = %v, want ErrNotFound", out, err)
+		}
+	}
+}
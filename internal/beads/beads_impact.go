@@ -0,0 +1,67 @@
+// Package beads provides closing-impact analysis over blocking dependencies.
+package beads
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ImpactReport describes what closing an issue would unblock.
+type ImpactReport struct {
+	ID string `json:"id"`
+	// Unblocked are open issues for which ID is the last open blocker, so
+	// they become ready when it closes.
+	Unblocked []*Issue `json:"unblocked"`
+	// StillBlocked are open issues ID blocks that have other open blockers.
+	StillBlocked []BlockedIssue `json:"still_blocked"`
+}
+
+// ClosingImpact reports which issues blocked by id would become ready if
+// id were closed: those with no other open blocking dependency. Only
+// "blocks" dependencies are considered; parent-child links do not gate
+// readiness here. Closed dependents are ignored.
+func (b *Beads) ClosingImpact(id string) (*ImpactReport, error) {
+	issue, err := b.Show(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependentIDs []string
+	for _, dep := range issue.Dependents {
+		if isBlockingDep(dep) && dep.Status != "closed" {
+			dependentIDs = append(dependentIDs, dep.ID)
+		}
+	}
+	dependents, err := b.ShowMultiple(dependentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("loading blocked issues: %w", err)
+	}
+
+	report := &ImpactReport{ID: issue.ID}
+	sort.Strings(dependentIDs)
+	for _, depID := range dependentIDs {
+		dependent, ok := dependents[depID]
+		if !ok {
+			continue
+		}
+		var remaining []BlockerInfo
+		for _, blocker := range dependent.Dependencies {
+			if blocker.ID == issue.ID || !isBlockingDep(blocker) || blocker.Status == "closed" {
+				continue
+			}
+			remaining = append(remaining, BlockerInfo{ID: blocker.ID, Title: blocker.Title, Status: blocker.Status})
+		}
+		if len(remaining) == 0 {
+			report.Unblocked = append(report.Unblocked, dependent)
+		} else {
+			report.StillBlocked = append(report.StillBlocked, BlockedIssue{Issue: dependent, Blockers: remaining})
+		}
+	}
+	return report, nil
+}
+
+// isBlockingDep reports whether a dependency gates readiness. bd omits the
+// type for plain blocking dependencies in some outputs.
+func isBlockingDep(dep IssueDep) bool {
+	return dep.DependencyType == "" || dep.DependencyType == "blocks"
+}
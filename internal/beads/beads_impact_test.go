@@ -0,0 +1,61 @@
+package beads
+
+import "testing"
+
+// Diamond: gt-a blocks gt-b and gt-c, which both block gt-d.
+func installDiamondBd(t *testing.T) {
+	t.Helper()
+	installFakeBd(t,
+		fakeBdRule{Match: "show gt-a --json", Outputs: []string{`[{"id":"gt-a","status":"open","dependents":[
+			{"id":"gt-b","status":"open","dependency_type":"blocks"},
+			{"id":"gt-c","status":"open","dependency_type":"blocks"},
+			{"id":"gt-epic","status":"open","dependency_type":"parent-child"}]}]`}},
+		fakeBdRule{Match: "show gt-b --json", Outputs: []string{`[{"id":"gt-b","status":"open","dependents":[
+			{"id":"gt-d","status":"open","dependency_type":"blocks"}]}]`}},
+		fakeBdRule{Match: "show --json gt-b gt-c", Outputs: []string{`[
+			{"id":"gt-b","status":"open","dependencies":[{"id":"gt-a","status":"open","dependency_type":"blocks"}]},
+			{"id":"gt-c","status":"open","dependencies":[{"id":"gt-a","status":"open","dependency_type":"blocks"},{"id":"gt-done","status":"closed","dependency_type":"blocks"}]}]`}},
+		fakeBdRule{Match: "show --json gt-d", Outputs: []string{`[
+			{"id":"gt-d","status":"open","dependencies":[
+				{"id":"gt-b","status":"open","dependency_type":"blocks"},
+				{"id":"gt-c","status":"open","dependency_type":"blocks"}]}]`}},
+	)
+}
+
+func TestClosingImpact_Unblocks(t *testing.T) {
+	installDiamondBd(t)
+
+	report, err := New(t.TempDir()).ClosingImpact("gt-a")
+	if err != nil {
+		t.Fatalf("ClosingImpact() error: %v", err)
+	}
+	var ids []string
+	for _, issue := range report.Unblocked {
+		ids = append(ids, issue.ID)
+	}
+	if len(ids) != 2 || ids[0] != "gt-b" || ids[1] != "gt-c" {
+		t.Errorf("Unblocked = %v, want [gt-b gt-c]", ids)
+	}
+	if len(report.StillBlocked) != 0 {
+		t.Errorf("StillBlocked = %v, want none", report.StillBlocked)
+	}
+}
+
+func TestClosingImpact_PartialUnblock(t *testing.T) {
+	installDiamondBd(t)
+
+	report, err := New(t.TempDir()).ClosingImpact("gt-b")
+	if err != nil {
+		t.Fatalf("ClosingImpact() error: %v", err)
+	}
+	if len(report.Unblocked) != 0 {
+		t.Errorf("Unblocked = %v, want none: gt-d is still blocked by gt-c", report.Unblocked)
+	}
+	if len(report.StillBlocked) != 1 {
+		t.Fatalf("StillBlocked = %v, want gt-d", report.StillBlocked)
+	}
+	still := report.StillBlocked[0]
+	if still.Issue.ID != "gt-d" || len(still.Blockers) != 1 || still.Blockers[0].ID != "gt-c" {
+		t.Errorf("StillBlocked[0] = %s blocked by %v, want gt-d blocked by gt-c", still.Issue.ID, still.Blockers)
+	}
+}
@@ -0,0 +1,174 @@
+// Package beads provides project views that annotate an epic's descendants.
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProjectNodeState is the work state of a node in a ProjectTree.
+type ProjectNodeState string
+
+const (
+	// ProjectNodeReady means the issue is open and bd ready lists it.
+	ProjectNodeReady ProjectNodeState = "ready"
+	// ProjectNodeBlocked means the issue has open blockers.
+	ProjectNodeBlocked ProjectNodeState = "blocked"
+	// ProjectNodeInProgress means someone is working on the issue
+	// (in_progress, hooked or in_review).
+	ProjectNodeInProgress ProjectNodeState = "in_progress"
+	// ProjectNodeDone means the issue is closed.
+	ProjectNodeDone ProjectNodeState = "done"
+	// ProjectNodeOpen means the issue is open but neither ready nor blocked
+	// (e.g., deferred).
+	ProjectNodeOpen ProjectNodeState = "open"
+)
+
+// ProjectNode is an issue in a ProjectTree with its work state.
+type ProjectNode struct {
+	Issue    *Issue           `json:"issue"`
+	State    ProjectNodeState `json:"state"`
+	Children []*ProjectNode   `json:"children,omitempty"`
+}
+
+// ProjectTree is the annotated descendant tree of a root issue (usually an epic).
+type ProjectTree struct {
+	RootID   string         `json:"root_id"`
+	Children []*ProjectNode `json:"children"`
+}
+
+// ProjectView returns the descendants of rootID as a tree, with each node
+// annotated as ready, blocked, in_progress or done.
+//
+// It makes exactly three bd calls (list, ready and blocked, each scoped with
+// --parent) rather than one per node. bd's --parent filter may return
+// deeper descendants as well as direct children, so each node hangs under
+// its Parent when that is in the result, and under the root otherwise.
+func (b *Beads) ProjectView(rootID string) (*ProjectTree, error) {
+	issues, err := b.List(ListOptions{Status: "all", Parent: rootID, Priority: -1})
+	if err != nil {
+		return nil, fmt.Errorf("listing descendants of %s: %w", rootID, err)
+	}
+
+	tree := &ProjectTree{RootID: rootID, Children: []*ProjectNode{}}
+	if len(issues) == 0 {
+		return tree, nil
+	}
+
+	limit := fmt.Sprintf("%d", len(issues))
+	ready, err := b.scopedIssueIDs("ready", "--json", "--parent", rootID, "-n", limit)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := b.scopedIssueIDs("blocked", "--json", "--parent", rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*ProjectNode, len(issues))
+	for _, issue := range issues {
+		nodes[issue.ID] = &ProjectNode{
+			Issue: issue,
+			State: projectNodeState(issue, ready[issue.ID], blocked[issue.ID]),
+		}
+	}
+
+	for _, issue := range issues {
+		node := nodes[issue.ID]
+		if parent := nodes[issue.Parent]; parent != nil && issue.Parent != issue.ID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			tree.Children = append(tree.Children, node)
+		}
+	}
+
+	return tree, nil
+}
+
+// Progress counts the direct children of an epic by status.
+type Progress struct {
+	Total      int `json:"total"`
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Closed     int `json:"closed"`
+	// Percent is Closed as a whole percentage of Total, rounded down.
+	// An epic with no children is 100% done.
+	Percent int `json:"percent"`
+}
+
+// EpicProgress counts the direct children of epicID for progress displays
+// like "3/8 done". Closed children count as done; in_progress, hooked and
+// in_review as in progress; every other status (open, blocked, deferred,
+// ...) as open.
+//
+// It makes one bd list call and counts only issues whose Parent is epicID,
+// skipping any deeper descendants the --parent filter also returns.
+func (b *Beads) EpicProgress(epicID string) (*Progress, error) {
+	issues, err := b.List(ListOptions{Status: "all", Parent: epicID, Priority: -1})
+	if err != nil {
+		return nil, fmt.Errorf("listing children of %s: %w", epicID, err)
+	}
+
+	p := &Progress{Percent: 100}
+	for _, issue := range issues {
+		if issue.Parent != epicID {
+			continue
+		}
+		p.Total++
+		switch issue.Status {
+		case "closed":
+			p.Closed++
+		case "in_progress", StatusHooked, StatusInReview:
+			p.InProgress++
+		default:
+			p.Open++
+		}
+	}
+	if p.Total > 0 {
+		p.Percent = p.Closed * 100 / p.Total
+	}
+	return p, nil
+}
+
+// scopedIssueIDs runs a bd command that prints a JSON issue array and returns
+// the set of issue IDs it lists.
+func (b *Beads) scopedIssueIDs(args ...string) (map[string]bool, error) {
+	out, err := b.run(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	if isEmptyOutput(out) {
+		return ids, nil
+	}
+
+	var issues []*Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd %s output: %w", args[0], err)
+	}
+
+	for _, issue := range issues {
+		ids[issue.ID] = true
+	}
+	return ids, nil
+}
+
+// projectNodeState derives a node's state. Status wins over the ready and
+// blocked sets, since bd ready/blocked only consider open work.
+func projectNodeState(issue *Issue, isReady, isBlocked bool) ProjectNodeState {
+	switch issue.Status {
+	case "closed":
+		return ProjectNodeDone
+	case "in_progress", StatusHooked, StatusInReview:
+		return ProjectNodeInProgress
+	}
+	switch {
+	case isBlocked || issue.Status == "blocked":
+		return ProjectNodeBlocked
+	case isReady:
+		return ProjectNodeReady
+	default:
+		return ProjectNodeOpen
+	}
+}
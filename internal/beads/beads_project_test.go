@@ -0,0 +1,131 @@
+package beads
+
+import "testing"
+
+func TestProjectView_Annotations(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{
+			Match: "list --json --status=all --parent=gt-epic",
+			Outputs: []string{`[
+				{"id":"gt-epic.1","status":"open","parent":"gt-epic"},
+				{"id":"gt-epic.1.1","status":"closed","parent":"gt-epic.1"},
+				{"id":"gt-sub","status":"in_progress","parent":"gt-epic.1"},
+				{"id":"gt-epic.2","status":"open","parent":"gt-epic"},
+				{"id":"gt-epic.2.1","status":"deferred","parent":"gt-epic"},
+				{"id":"gt-other","status":"hooked","parent":"gt-epic"}
+			]`},
+		},
+		fakeBdRule{
+			Match:   "ready --json --parent gt-epic",
+			Outputs: []string{`[{"id":"gt-epic.1"}]`},
+		},
+		fakeBdRule{
+			Match:   "blocked --json --parent gt-epic",
+			Outputs: []string{`[{"id":"gt-epic.2"}]`},
+		},
+	)
+
+	tree, err := New(t.TempDir()).ProjectView("gt-epic")
+	if err != nil {
+		t.Fatalf("ProjectView() error: %v", err)
+	}
+
+	if calls := fake.calls(t); len(calls) != 3 {
+		t.Errorf("ProjectView made %d bd calls, want 3: %v", len(calls), calls)
+	}
+
+	states := make(map[string]ProjectNodeState)
+	var walk func(nodes []*ProjectNode)
+	walk = func(nodes []*ProjectNode) {
+		for _, n := range nodes {
+			states[n.Issue.ID] = n.State
+			walk(n.Children)
+		}
+	}
+	walk(tree.Children)
+
+	want := map[string]ProjectNodeState{
+		"gt-epic.1":   ProjectNodeReady,
+		"gt-epic.1.1": ProjectNodeDone,
+		"gt-sub":      ProjectNodeInProgress,
+		"gt-epic.2":   ProjectNodeBlocked,
+		"gt-epic.2.1": ProjectNodeOpen,
+		"gt-other":    ProjectNodeInProgress,
+	}
+	for id, w := range want {
+		if got := states[id]; got != w {
+			t.Errorf("state[%s] = %q, want %q", id, got, w)
+		}
+	}
+
+	// Nodes nest under their Parent, whatever their IDs look like.
+	if len(tree.Children) != 4 {
+		t.Fatalf("root has %d children, want 4", len(tree.Children))
+	}
+	first := tree.Children[0]
+	if first.Issue.ID != "gt-epic.1" || len(first.Children) != 2 {
+		t.Errorf("gt-epic.1 children = %d, want gt-epic.1.1 and gt-sub", len(first.Children))
+	}
+	if second := tree.Children[1]; second.Issue.ID != "gt-epic.2" || len(second.Children) != 0 {
+		t.Errorf("gt-epic.2 children = %d, want none (gt-epic.2.1's parent is the root)", len(second.Children))
+	}
+}
+
+func TestProjectView_NoDescendants(t *testing.T) {
+	fake := installFakeBd(t)
+
+	tree, err := New(t.TempDir()).ProjectView("gt-empty")
+	if err != nil {
+		t.Fatalf("ProjectView() error: %v", err)
+	}
+	if tree.RootID != "gt-empty" || len(tree.Children) != 0 {
+		t.Errorf("ProjectView() = %+v, want empty tree", tree)
+	}
+	if calls := fake.calls(t); len(calls) != 1 {
+		t.Errorf("empty project should only list, got %v", calls)
+	}
+}
+
+func TestEpicProgress(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{
+		Match: "list --json --status=all --parent=gt-epic",
+		Outputs: []string{`[
+			{"id":"gt-epic.1","status":"closed","parent":"gt-epic"},
+			{"id":"gt-epic.1.1","status":"open","parent":"gt-epic.1"},
+			{"id":"gt-epic.2","status":"closed","parent":"gt-epic"},
+			{"id":"gt-epic.3","status":"in_progress","parent":"gt-epic"},
+			{"id":"gt-epic.4","status":"hooked","parent":"gt-epic"},
+			{"id":"gt-epic.5","status":"open","parent":"gt-epic"},
+			{"id":"gt-epic.6","status":"blocked","parent":"gt-epic"},
+			{"id":"gt-other","status":"closed","parent":"gt-epic"},
+			{"id":"gt-epic.7","status":"deferred","parent":"gt-epic"},
+			{"id":"gt-deep","status":"closed","parent":"gt-epic.3"}
+		]`},
+	})
+
+	got, err := New(t.TempDir()).EpicProgress("gt-epic")
+	if err != nil {
+		t.Fatalf("EpicProgress() error: %v", err)
+	}
+	if calls := fake.calls(t); len(calls) != 1 {
+		t.Errorf("EpicProgress made %d bd calls, want 1: %v", len(calls), calls)
+	}
+
+	// gt-epic.1.1 and gt-deep are grandchildren: their Parent is not gt-epic.
+	want := Progress{Total: 8, Open: 3, InProgress: 2, Closed: 3, Percent: 37}
+	if *got != want {
+		t.Errorf("EpicProgress() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestEpicProgress_NoChildren(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "list --json", Outputs: []string{`[]`}})
+
+	got, err := New(t.TempDir()).EpicProgress("gt-epic")
+	if err != nil {
+		t.Fatalf("EpicProgress() error: %v", err)
+	}
+	if want := (Progress{Percent: 100}); *got != want {
+		t.Errorf("EpicProgress() = %+v, want %+v", *got, want)
+	}
+}
@@ -2,12 +2,66 @@
 package beads
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
+// Ping errors. Both are detected from the filesystem, not from bd output.
+var (
+	ErrNoBeadsDir            = errors.New("no beads directory")
+	ErrRedirectTargetMissing = errors.New("beads redirect target does not exist")
+)
+
+// beadsRepoMarkers are the files bd leaves in a .beads directory it has
+// initialized: a local Dolt database, server-mode metadata, its config, or
+// a legacy SQLite database or JSONL export. A directory with none of them
+// is not a beads repo.
+var beadsRepoMarkers = []string{"dolt", "metadata.json", "config.yaml", "beads.db", "issues.jsonl"}
+
+// hasBeadsRepoMarker reports whether beadsDir holds any beadsRepoMarkers.
+func hasBeadsRepoMarker(beadsDir string) bool {
+	for _, marker := range beadsRepoMarkers {
+		if _, err := os.Stat(filepath.Join(beadsDir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Ping checks that the beads repo this wrapper resolves to (following any
+// redirect) exists and can be read, with a cheap bd list --limit=1. Errors
+// distinguish a missing bd (ErrNotInstalled), a missing repo
+// (ErrNoBeadsDir, also returned for a directory bd never initialized) and
+// a redirect to a shared repo that is not there (ErrRedirectTargetMissing).
+func (b *Beads) Ping() error {
+	beadsDir := b.getResolvedBeadsDir()
+
+	if _, err := os.Stat(beadsDir); err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("checking beads directory %s: %w", beadsDir, err)
+		}
+		redirectPath := filepath.Join(b.workDir, ".beads", "redirect")
+		if _, rerr := os.Stat(redirectPath); b.beadsDir == "" && rerr == nil {
+			return fmt.Errorf("%w: %s (from %s)", ErrRedirectTargetMissing, beadsDir, redirectPath)
+		}
+		return fmt.Errorf("%w: %s", ErrNoBeadsDir, beadsDir)
+	}
+	if !hasBeadsRepoMarker(beadsDir) {
+		return fmt.Errorf("%w: %s exists but holds no beads database", ErrNoBeadsDir, beadsDir)
+	}
+
+	if _, err := b.run("list", "--json", "--limit=1"); err != nil {
+		if errors.Is(err, ErrNotInstalled) {
+			return err
+		}
+		return fmt.Errorf("reading beads repo at %s: %w", beadsDir, err)
+	}
+	return nil
+}
+
 // ResolveBeadsDir returns the actual beads directory, following any redirect.
 // If workDir/.beads/redirect exists, it reads the redirect path and resolves it
 // relative to workDir (not the .beads directory). Otherwise, returns workDir/.beads.
@@ -22,83 +76,140 @@ import (
 // Circular redirect detection: If the resolved path equals the original beads directory,
 // this indicates an errant redirect file that should be removed. The function logs a
 // warning and returns the original beads directory.
+//
+// ResolveBeadsDirVerbose performs the resolution; this wrapper reports its
+// warnings on stderr and removes an errant self-redirect.
 func ResolveBeadsDir(workDir string) string {
-	if filepath.Base(workDir) == ".beads" {
-		workDir = filepath.Dir(workDir)
-	}
-	beadsDir := filepath.Join(workDir, ".beads")
-	redirectPath := filepath.Join(beadsDir, "redirect")
+	res, _ := ResolveBeadsDirVerbose(workDir)
 
-	// Check for redirect file
-	data, err := os.ReadFile(redirectPath) //nolint:gosec // G304: path is constructed internally
-	if err != nil {
-		// No redirect, use local .beads
-		return beadsDir
-	}
-
-	// Read and clean the redirect path
-	redirectTarget := strings.TrimSpace(string(data))
-	if redirectTarget == "" {
-		return beadsDir
+	for _, w := range res.Warnings {
+		// Escaped-root is informational and was never reported here.
+		if w.Kind == RedirectWarnEscapedRoot {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w.Message)
 	}
 
-	// Resolve relative to workDir (the redirect is written from the perspective
-	// of being inside workDir, not inside workDir/.beads)
-	// e.g., redirect contains "../../mayor/rig/.beads"
-	// from crew/max/, this resolves to mayor/rig/.beads
-	resolved := filepath.Join(workDir, redirectTarget)
-
-	// Clean the path to resolve .. components
-	resolved = filepath.Clean(resolved)
-
-	// Detect circular redirects: if resolved path equals original beads dir,
-	// this is an errant redirect file (e.g., redirect in mayor/rig/.beads pointing to itself)
-	if resolved == beadsDir {
-		fmt.Fprintf(os.Stderr, "Warning: circular redirect detected in %s (points to itself), ignoring redirect\n", redirectPath)
-		// Remove the errant redirect file to prevent future warnings
-		if err := os.Remove(redirectPath); err != nil {
+	// Remove the errant redirect file to prevent future warnings
+	if res.ErrantRedirect != "" {
+		if err := os.Remove(res.ErrantRedirect); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: could not remove errant redirect file: %v\n", err)
 		}
-		return beadsDir
 	}
 
-	// Follow redirect chains (e.g., crew/.beads -> rig/.beads -> mayor/rig/.beads)
-	// This is intentional for the rig-level redirect architecture.
-	// Limit depth to prevent infinite loops from misconfigured redirects.
-	return resolveBeadsDirWithDepth(resolved, 3)
+	return res.Final
 }
 
-// resolveBeadsDirWithDepth follows redirect chains with a depth limit.
-func resolveBeadsDirWithDepth(beadsDir string, maxDepth int) string {
-	if maxDepth <= 0 {
-		fmt.Fprintf(os.Stderr, "Warning: redirect chain too deep at %s, stopping\n", beadsDir)
-		return beadsDir
-	}
+// Redirect warning kinds reported in ResolveResult.Warnings.
+const (
+	RedirectWarnCircular    = "circular"
+	RedirectWarnChainDepth  = "chain-too-deep"
+	RedirectWarnEscapedRoot = "escaped-root"
+)
 
-	redirectPath := filepath.Join(beadsDir, "redirect")
-	data, err := os.ReadFile(redirectPath) //nolint:gosec // G304: path is constructed internally
-	if err != nil {
-		// No redirect, this is the final destination
-		return beadsDir
-	}
+// maxRedirectChain is how many redirects are followed after the first.
+const maxRedirectChain = 3
+
+// RedirectHop is one redirect followed during beads directory resolution.
+type RedirectHop struct {
+	RedirectFile string `json:"redirect_file"` // the redirect file read
+	Target       string `json:"target"`        // its contents, as written
+	Resolved     string `json:"resolved"`      // the beads directory it points to
+}
+
+// RedirectWarning is a problem noticed while resolving redirects.
+type RedirectWarning struct {
+	Kind    string `json:"kind"` // one of the RedirectWarn* constants
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ResolveResult traces how a work directory's beads directory was resolved.
+type ResolveResult struct {
+	WorkDir  string            `json:"work_dir"` // the directory resolution started from
+	Hops     []RedirectHop     `json:"hops,omitempty"`
+	Final    string            `json:"final"` // the beads directory to use
+	Warnings []RedirectWarning `json:"warnings,omitempty"`
 
-	redirectTarget := strings.TrimSpace(string(data))
-	if redirectTarget == "" {
-		return beadsDir
+	// ErrantRedirect is set when workDir's own redirect points back at
+	// workDir/.beads; ResolveBeadsDir deletes such files.
+	ErrantRedirect string `json:"errant_redirect,omitempty"`
+}
+
+// ResolveBeadsDirVerbose resolves workDir's beads directory like
+// ResolveBeadsDir, but returns the full trace (each redirect hop and any
+// circular, chain-too-deep or escaped-root warnings) instead of printing to
+// stderr, and never modifies the filesystem. An error is returned only when
+// a redirect file exists but cannot be read; Final is still usable.
+func ResolveBeadsDirVerbose(workDir string) (ResolveResult, error) {
+	res := ResolveResult{WorkDir: workDir}
+
+	if filepath.Base(workDir) == ".beads" {
+		workDir = filepath.Dir(workDir)
 	}
+	beadsDir := filepath.Join(workDir, ".beads")
+	res.Final = beadsDir
+
+	for depth := 0; ; depth++ {
+		redirectPath := filepath.Join(beadsDir, "redirect")
+		data, err := os.ReadFile(redirectPath) //nolint:gosec // G304: path is constructed internally
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return res, fmt.Errorf("reading redirect %s: %w", redirectPath, err)
+			}
+			break // No redirect, this is the final destination
+		}
+
+		redirectTarget := strings.TrimSpace(string(data))
+		if redirectTarget == "" {
+			break
+		}
+
+		// Follow redirect chains (e.g., crew/.beads -> rig/.beads -> mayor/rig/.beads)
+		// This is intentional for the rig-level redirect architecture.
+		// Limit depth to prevent infinite loops from misconfigured redirects.
+		if depth > maxRedirectChain {
+			res.Warnings = append(res.Warnings, RedirectWarning{
+				Kind:    RedirectWarnChainDepth,
+				Path:    beadsDir,
+				Message: fmt.Sprintf("redirect chain too deep at %s, stopping", beadsDir),
+			})
+			break
+		}
 
-	// Resolve relative to parent of beadsDir (the workDir)
-	workDir := filepath.Dir(beadsDir)
-	resolved := filepath.Clean(filepath.Join(workDir, redirectTarget))
+		// Resolve relative to the directory containing .beads (the redirect is
+		// written from the perspective of being inside workDir, not workDir/.beads)
+		resolved := filepath.Clean(filepath.Join(filepath.Dir(beadsDir), redirectTarget))
 
-	// Detect circular redirect
-	if resolved == beadsDir {
-		fmt.Fprintf(os.Stderr, "Warning: circular redirect detected in %s, stopping\n", redirectPath)
-		return beadsDir
+		// Detect circular redirects: a redirect pointing at its own beads dir
+		if resolved == beadsDir {
+			msg := fmt.Sprintf("circular redirect detected in %s, stopping", redirectPath)
+			if depth == 0 {
+				msg = fmt.Sprintf("circular redirect detected in %s (points to itself), ignoring redirect", redirectPath)
+				res.ErrantRedirect = redirectPath
+			}
+			res.Warnings = append(res.Warnings, RedirectWarning{Kind: RedirectWarnCircular, Path: redirectPath, Message: msg})
+			break
+		}
+
+		res.Hops = append(res.Hops, RedirectHop{RedirectFile: redirectPath, Target: redirectTarget, Resolved: resolved})
+		beadsDir = resolved
+		res.Final = beadsDir
+	}
+
+	if len(res.Hops) > 0 {
+		if townRoot := FindTownRoot(workDir); townRoot != "" {
+			if rel, err := filepath.Rel(townRoot, res.Final); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				res.Warnings = append(res.Warnings, RedirectWarning{
+					Kind:    RedirectWarnEscapedRoot,
+					Path:    res.Final,
+					Message: fmt.Sprintf("redirect resolves to %s, outside town root %s", res.Final, townRoot),
+				})
+			}
+		}
 	}
 
-	// Recursively follow
-	return resolveBeadsDirWithDepth(resolved, maxDepth-1)
+	return res, nil
 }
 
 // cleanBeadsRuntimeFiles removes gitignored runtime files from a .beads directory
@@ -0,0 +1,136 @@
+// Package beads provides a town-wide audit of beads redirects.
+package beads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Redirect problem kinds reported by AuditRedirects.
+const (
+	RedirectProblemCircular   = RedirectWarnCircular
+	RedirectProblemChained    = "chained"
+	RedirectProblemDangling   = "dangling"
+	RedirectProblemEscaped    = RedirectWarnEscapedRoot
+	RedirectProblemUnreadable = "unreadable"
+)
+
+// RedirectAudit reports the problems found with one working directory's
+// beads redirect.
+type RedirectAudit struct {
+	WorkDir  string        `json:"work_dir"`
+	Result   ResolveResult `json:"result"`
+	Problems []string      `json:"problems"` // RedirectProblem* kinds
+	Details  []string      `json:"details"`  // one human-readable line per problem
+}
+
+// AuditRedirects resolves the .beads/redirect of every agent working
+// directory in the town (each rig's root, crew workspaces, polecat clones
+// and the refinery clone) and returns an audit for each redirect that is
+// circular, chained (bd does not follow chains), dangling (the target does
+// not exist) or resolves outside townRoot. Directories without a redirect
+// are skipped. Results are sorted by working directory.
+func AuditRedirects(townRoot string) ([]RedirectAudit, error) {
+	townRoot, err := filepath.Abs(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("resolving town root: %w", err)
+	}
+	entries, err := os.ReadDir(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("reading town root: %w", err)
+	}
+
+	var workDirs []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || strings.HasPrefix(name, ".") || name == "mayor" || name == "docs" {
+			continue
+		}
+		workDirs = append(workDirs, redirectWorkDirs(filepath.Join(townRoot, name))...)
+	}
+	sort.Strings(workDirs)
+
+	var audits []RedirectAudit
+	for _, workDir := range workDirs {
+		if audit, ok := auditRedirect(townRoot, workDir); ok {
+			audits = append(audits, audit)
+		}
+	}
+	return audits, nil
+}
+
+// redirectWorkDirs returns the working directories in rigDir that have a
+// beads redirect file.
+func redirectWorkDirs(rigDir string) []string {
+	candidates := []string{rigDir, filepath.Join(rigDir, "refinery", "rig")}
+	for _, sub := range []string{"crew", "polecats"} {
+		entries, err := os.ReadDir(filepath.Join(rigDir, sub))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			dir := filepath.Join(rigDir, sub, entry.Name())
+			candidates = append(candidates, dir)
+			if sub == "polecats" {
+				// New layout: polecats/<name>/<rig>/
+				candidates = append(candidates, filepath.Join(dir, filepath.Base(rigDir)))
+			}
+		}
+	}
+
+	var dirs []string
+	for _, dir := range candidates {
+		if _, err := os.Stat(filepath.Join(dir, ".beads", "redirect")); err == nil {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// auditRedirect resolves workDir's redirect and reports whether it has any
+// problems.
+func auditRedirect(townRoot, workDir string) (RedirectAudit, bool) {
+	audit := RedirectAudit{WorkDir: workDir}
+	add := func(kind, format string, args ...any) {
+		audit.Problems = append(audit.Problems, kind)
+		audit.Details = append(audit.Details, fmt.Sprintf(format, args...))
+	}
+
+	res, err := ResolveBeadsDirVerbose(workDir)
+	audit.Result = res
+	if err != nil {
+		add(RedirectProblemUnreadable, "%v", err)
+		return audit, true
+	}
+
+	chained := len(res.Hops) > 1
+	for _, w := range res.Warnings {
+		switch w.Kind {
+		case RedirectWarnCircular:
+			add(RedirectProblemCircular, "%s", w.Message)
+		case RedirectWarnChainDepth:
+			chained = true
+		}
+	}
+	if chained {
+		add(RedirectProblemChained, "redirect chain of %d hops; point directly at %s", len(res.Hops), res.Final)
+	}
+	if len(res.Hops) > 0 {
+		if _, err := os.Stat(res.Final); os.IsNotExist(err) {
+			add(RedirectProblemDangling, "redirect target %s does not exist", res.Final)
+		}
+		// Checked here rather than via the escaped-root warning, which
+		// locates the town root itself and may not agree with townRoot.
+		if rel, err := filepath.Rel(townRoot, res.Final); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			add(RedirectProblemEscaped, "redirect resolves to %s, outside town root %s", res.Final, townRoot)
+		}
+	}
+
+	return audit, len(audit.Problems) > 0
+}
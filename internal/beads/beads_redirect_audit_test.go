@@ -0,0 +1,67 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAuditRedirects(t *testing.T) {
+	base := t.TempDir()
+	townRoot := filepath.Join(base, "town")
+	rig := filepath.Join(townRoot, "alpha")
+
+	mkdir := func(path string) {
+		t.Helper()
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	redirect := func(workDir, target string) {
+		t.Helper()
+		mkdir(filepath.Join(workDir, ".beads"))
+		if err := os.WriteFile(filepath.Join(workDir, ".beads", "redirect"), []byte(target+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mkdir(filepath.Join(rig, "mayor", "rig", ".beads"))
+	mkdir(filepath.Join(base, "outside", ".beads"))
+	mkdir(filepath.Join(rig, "crew", "nobeads"))
+	redirect(rig, "mayor/rig/.beads")
+	redirect(filepath.Join(rig, "crew", "good"), "../../mayor/rig/.beads")
+	redirect(filepath.Join(rig, "crew", "chained"), "../../.beads")
+	redirect(filepath.Join(rig, "crew", "loop"), ".beads")
+	redirect(filepath.Join(rig, "polecats", "Toast", "alpha"), "../../../missing/.beads")
+	redirect(filepath.Join(rig, "refinery", "rig"), "../../../../outside/.beads")
+
+	audits, err := AuditRedirects(townRoot)
+	if err != nil {
+		t.Fatalf("AuditRedirects() error: %v", err)
+	}
+
+	got := map[string][]string{}
+	for _, a := range audits {
+		rel, _ := filepath.Rel(townRoot, a.WorkDir)
+		got[filepath.ToSlash(rel)] = a.Problems
+		if len(a.Details) != len(a.Problems) {
+			t.Errorf("%s: %d details for %d problems", rel, len(a.Details), len(a.Problems))
+		}
+	}
+	want := map[string][]string{
+		"alpha/crew/chained":         {RedirectProblemChained},
+		"alpha/crew/loop":            {RedirectProblemCircular},
+		"alpha/polecats/Toast/alpha": {RedirectProblemDangling},
+		"alpha/refinery/rig":         {RedirectProblemEscaped},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AuditRedirects() problems = %v, want %v", got, want)
+	}
+}
+
+func TestAuditRedirects_MissingTown(t *testing.T) {
+	if _, err := AuditRedirects(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("AuditRedirects() should fail for a missing town root")
+	}
+}
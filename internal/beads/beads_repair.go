@@ -0,0 +1,107 @@
+package beads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Redirect problems reported by ValidateAndRepair, beyond the
+// RedirectWarn* kinds it passes through.
+const (
+	RepairDanglingRedirect = "dangling-redirect" // a redirect points at a missing directory
+	RepairNotBeadsRepo     = "not-beads-repo"    // the resolved directory holds no beads database
+)
+
+// RepairOptions controls ValidateAndRepair.
+type RepairOptions struct {
+	// Repair removes dangling and circular redirect files. Without it the
+	// setup is only checked.
+	Repair bool
+}
+
+// RepairReport is what ValidateAndRepair found and fixed.
+type RepairReport struct {
+	Found   []RedirectWarning `json:"found,omitempty"`   // problems, before any repair
+	Removed []string          `json:"removed,omitempty"` // redirect files deleted
+	Final   string            `json:"final"`             // the beads directory now resolved
+	Healthy bool              `json:"healthy"`           // Final exists and is a beads repo
+}
+
+// ValidateAndRepair checks that workDir's beads directory, after following
+// redirects, exists and holds a beads database, and reports dangling,
+// circular and too-deep redirects along the way. With opts.Repair it
+// removes redirect files that are circular or point at a missing
+// directory, so resolution falls back to the directory holding them, and
+// checks the result again. The error return is for filesystem failures.
+func ValidateAndRepair(workDir string, opts RepairOptions) (*RepairReport, error) {
+	res, err := ResolveBeadsDirVerbose(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RepairReport{}
+	var remove []string
+	for _, w := range res.Warnings {
+		report.Found = append(report.Found, w)
+		if w.Kind == RedirectWarnCircular {
+			remove = append(remove, w.Path)
+		}
+	}
+	for _, hop := range res.Hops {
+		if _, err := os.Stat(hop.Resolved); os.IsNotExist(err) {
+			report.Found = append(report.Found, RedirectWarning{
+				Kind:    RepairDanglingRedirect,
+				Path:    hop.RedirectFile,
+				Message: fmt.Sprintf("redirect %s points to missing %s", hop.RedirectFile, hop.Resolved),
+			})
+			remove = append(remove, hop.RedirectFile)
+			break // later hops cannot exist
+		}
+	}
+
+	if opts.Repair && len(remove) > 0 {
+		for _, path := range remove {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return report, fmt.Errorf("removing redirect %s: %w", path, err)
+			}
+			report.Removed = append(report.Removed, path)
+		}
+		if res, err = ResolveBeadsDirVerbose(workDir); err != nil {
+			return report, err
+		}
+	}
+
+	report.Final = res.Final
+	report.Healthy = isBeadsRepo(res.Final)
+	// An unrepaired dangling redirect already explains a missing Final.
+	if !report.Healthy && !(hasDangling(report.Found) && len(report.Removed) == 0) {
+		report.Found = append(report.Found, RedirectWarning{
+			Kind:    RepairNotBeadsRepo,
+			Path:    res.Final,
+			Message: fmt.Sprintf("%s is not a beads repo", res.Final),
+		})
+	}
+	return report, nil
+}
+
+func hasDangling(found []RedirectWarning) bool {
+	for _, w := range found {
+		if w.Kind == RepairDanglingRedirect {
+			return true
+		}
+	}
+	return false
+}
+
+// isBeadsRepo reports whether beadsDir holds a beads database in any of the
+// layouts bd uses: embedded Dolt, server-mode metadata, legacy SQLite, or
+// an initialized config.
+func isBeadsRepo(beadsDir string) bool {
+	for _, marker := range []string{"dolt", "metadata.json", "beads.db", "config.yaml"} {
+		if _, err := os.Stat(filepath.Join(beadsDir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
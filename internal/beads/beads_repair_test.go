@@ -0,0 +1,121 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// repairFixture makes a work directory whose .beads holds a config.yaml and
+// a redirect with the given contents.
+func repairFixture(t *testing.T, redirect string) (workDir, redirectPath string) {
+	t.Helper()
+	workDir = filepath.Join(t.TempDir(), "crew", "max")
+	beadsDir := filepath.Join(workDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte("prefix: gt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	redirectPath = filepath.Join(beadsDir, "redirect")
+	if err := os.WriteFile(redirectPath, []byte(redirect+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return workDir, redirectPath
+}
+
+func foundKinds(report *RepairReport) []string {
+	var kinds []string
+	for _, w := range report.Found {
+		kinds = append(kinds, w.Kind)
+	}
+	return kinds
+}
+
+func TestValidateAndRepairDangling(t *testing.T) {
+	for _, repair := range []bool{false, true} {
+		workDir, redirectPath := repairFixture(t, "../../mayor/rig/.beads")
+
+		report, err := ValidateAndRepair(workDir, RepairOptions{Repair: repair})
+		if err != nil {
+			t.Fatalf("repair=%v: ValidateAndRepair() error: %v", repair, err)
+		}
+		if kinds := foundKinds(report); len(kinds) != 1 || kinds[0] != RepairDanglingRedirect {
+			t.Errorf("repair=%v: found %v, want one dangling redirect", repair, kinds)
+		}
+
+		_, statErr := os.Stat(redirectPath)
+		if repair {
+			if !os.IsNotExist(statErr) || len(report.Removed) != 1 {
+				t.Errorf("repair on: redirect should be removed (removed %v)", report.Removed)
+			}
+			if !report.Healthy || report.Final != filepath.Join(workDir, ".beads") {
+				t.Errorf("repair on: report = %+v, want healthy local .beads", report)
+			}
+		} else {
+			if statErr != nil || len(report.Removed) != 0 {
+				t.Errorf("repair off: redirect should be kept (removed %v)", report.Removed)
+			}
+			if report.Healthy {
+				t.Errorf("repair off: dangling setup reported healthy")
+			}
+		}
+	}
+}
+
+func TestValidateAndRepairCircular(t *testing.T) {
+	for _, repair := range []bool{false, true} {
+		workDir, redirectPath := repairFixture(t, ".beads")
+
+		report, err := ValidateAndRepair(workDir, RepairOptions{Repair: repair})
+		if err != nil {
+			t.Fatalf("repair=%v: ValidateAndRepair() error: %v", repair, err)
+		}
+		if kinds := foundKinds(report); len(kinds) != 1 || kinds[0] != RedirectWarnCircular {
+			t.Errorf("repair=%v: found %v, want one circular redirect", repair, kinds)
+		}
+		// Resolution already ignores the self-redirect, so the repo is usable.
+		if !report.Healthy {
+			t.Errorf("repair=%v: report = %+v, want healthy", repair, report)
+		}
+
+		_, statErr := os.Stat(redirectPath)
+		if repair && !os.IsNotExist(statErr) {
+			t.Error("repair on: circular redirect should be removed")
+		}
+		if !repair && statErr != nil {
+			t.Errorf("repair off: circular redirect should be kept: %v", statErr)
+		}
+	}
+}
+
+func TestValidateAndRepairHealthyAndNotARepo(t *testing.T) {
+	workDir, _ := repairFixture(t, "../../mayor/rig/.beads")
+	shared := filepath.Join(workDir, "..", "..", "mayor", "rig", ".beads")
+	if err := os.MkdirAll(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ValidateAndRepair(workDir, RepairOptions{Repair: true})
+	if err != nil {
+		t.Fatalf("ValidateAndRepair() error: %v", err)
+	}
+	if report.Healthy || len(report.Removed) != 0 {
+		t.Errorf("empty target: report = %+v, want unhealthy and nothing removed", report)
+	}
+	if kinds := foundKinds(report); len(kinds) != 1 || kinds[0] != RepairNotBeadsRepo {
+		t.Errorf("empty target: found %v, want not-beads-repo", kinds)
+	}
+
+	if err := os.Mkdir(filepath.Join(shared, "dolt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	report, err = ValidateAndRepair(workDir, RepairOptions{Repair: true})
+	if err != nil {
+		t.Fatalf("ValidateAndRepair() error: %v", err)
+	}
+	if !report.Healthy || len(report.Found) != 0 || report.Final != filepath.Clean(shared) {
+		t.Errorf("valid target: report = %+v, want healthy %s", report, shared)
+	}
+}
@@ -0,0 +1,52 @@
+package beads
+
+// IssueDetail is an issue with its direct dependencies and dependents
+// expanded to full issues.
+type IssueDetail struct {
+	*Issue
+
+	// DependencyIssues and DependentIssues parallel Issue.Dependencies and
+	// Issue.Dependents. An entry bd could not return is built from the
+	// summary show gave for it.
+	DependencyIssues []*Issue
+	DependentIssues  []*Issue
+}
+
+// ShowDeep shows id and fetches its direct dependencies and dependents in
+// one more bd call. The expansion is not transitive: related issues carry
+// their own Dependencies summaries but are not expanded further.
+func (b *Beads) ShowDeep(id string) (*IssueDetail, error) {
+	issue, err := b.Show(id)
+	if err != nil {
+		return nil, err
+	}
+	detail := &IssueDetail{Issue: issue}
+
+	var related []string
+	seen := make(map[string]bool)
+	for _, dep := range append(append([]IssueDep(nil), issue.Dependencies...), issue.Dependents...) {
+		if dep.ID != "" && !seen[dep.ID] {
+			seen[dep.ID] = true
+			related = append(related, dep.ID)
+		}
+	}
+
+	full, err := b.ShowMultiple(related)
+	if err != nil {
+		return nil, err
+	}
+	expand := func(deps []IssueDep) []*Issue {
+		issues := make([]*Issue, 0, len(deps))
+		for _, dep := range deps {
+			if fetched, ok := full[dep.ID]; ok {
+				issues = append(issues, fetched)
+				continue
+			}
+			issues = append(issues, &Issue{ID: dep.ID, Title: dep.Title, Status: dep.Status, Priority: dep.Priority, Type: dep.Type})
+		}
+		return issues
+	}
+	detail.DependencyIssues = expand(issue.Dependencies)
+	detail.DependentIssues = expand(issue.Dependents)
+	return detail, nil
+}
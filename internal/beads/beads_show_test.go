@@ -0,0 +1,67 @@
+package beads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShowDeep(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{Match: "show gt-1 ", Outputs: []string{`[{"id":"gt-1","title":"Epic",
+			"dependencies":[{"id":"gt-2","title":"Dep","status":"open"},{"id":"gt-gone","title":"Deleted","status":"closed"}],
+			"dependents":[{"id":"gt-3","title":"Follow-up"},{"id":"gt-2","title":"Dep"}]}]`}},
+		fakeBdRule{Match: "show --json", Outputs: []string{`[
+			{"id":"gt-2","title":"Dep","description":"full body","status":"open","assignee":"gastown/polecats/Toast","labels":["federate"],
+			 "dependencies":[{"id":"gt-9","title":"Deeper"}]},
+			{"id":"gt-3","title":"Follow-up","description":"later","status":"open"}]`}},
+	)
+
+	detail, err := New(t.TempDir()).ShowDeep("gt-1")
+	if err != nil {
+		t.Fatalf("ShowDeep() error: %v", err)
+	}
+	if detail.ID != "gt-1" || detail.Title != "Epic" {
+		t.Errorf("issue = %+v, want gt-1", detail.Issue)
+	}
+
+	if len(detail.DependencyIssues) != 2 {
+		t.Fatalf("DependencyIssues = %+v, want 2", detail.DependencyIssues)
+	}
+	dep := detail.DependencyIssues[0]
+	if dep.Description != "full body" || dep.Assignee != "gastown/polecats/Toast" || len(dep.Labels) != 1 {
+		t.Errorf("dependency gt-2 not fully populated: %+v", dep)
+	}
+	if gone := detail.DependencyIssues[1]; gone.ID != "gt-gone" || gone.Title != "Deleted" || gone.Status != "closed" {
+		t.Errorf("missing dependency should fall back to its summary, got %+v", gone)
+	}
+	if len(detail.DependentIssues) != 2 || detail.DependentIssues[0].Description != "later" || detail.DependentIssues[1] != dep {
+		t.Errorf("DependentIssues = %+v", detail.DependentIssues)
+	}
+
+	batch := fake.callsMatching(t, "show --json")
+	if len(batch) != 1 {
+		t.Fatalf("batch show calls = %v, want 1", batch)
+	}
+	// Each related ID is fetched once, and the expansion stops at direct deps.
+	if strings.Count(batch[0], "gt-2") != 1 || !strings.Contains(batch[0], "gt-3") || strings.Contains(batch[0], "gt-9") {
+		t.Errorf("batch show = %q, want gt-2, gt-gone and gt-3 only", batch[0])
+	}
+	if calls := fake.callsMatching(t, "show"); len(calls) != 2 {
+		t.Errorf("show calls = %v, want 2", calls)
+	}
+}
+
+func TestShowDeepWithoutRelated(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "show gt-1 ", Outputs: []string{`[{"id":"gt-1","title":"Alone"}]`}})
+
+	detail, err := New(t.TempDir()).ShowDeep("gt-1")
+	if err != nil {
+		t.Fatalf("ShowDeep() error: %v", err)
+	}
+	if len(detail.DependencyIssues) != 0 || len(detail.DependentIssues) != 0 {
+		t.Errorf("detail = %+v, want no related issues", detail)
+	}
+	if calls := fake.callsMatching(t, "show"); len(calls) != 1 {
+		t.Errorf("show calls = %v, want just the one show", calls)
+	}
+}
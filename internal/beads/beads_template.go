@@ -0,0 +1,114 @@
+// Package beads provides templated creation of recurring issue trees.
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Template describes an issue, and optionally its children, to create with
+// CreateFromTemplate. Title and Description may contain {{var}} placeholders,
+// expanded the same way as molecule steps (see ExpandTemplateVars).
+type Template struct {
+	Title       string
+	Description string
+	Type        string
+	Priority    int // 0-4, -1 to leave it to bd's default
+	Children    []Template
+}
+
+// CreateFromTemplate creates the issue described by tpl, then its children
+// (recursively) parented to it, expanding {{var}} placeholders from vars.
+// Returns the top-level issue.
+//
+// Every placeholder must have a value in vars; missing ones are reported
+// before anything is created. If any create fails, the issues created so
+// far are deleted, children first.
+func (b *Beads) CreateFromTemplate(tpl Template, vars map[string]string) (*Issue, error) {
+	expanded, err := expandTemplate(tpl, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []string
+	root, err := b.createTemplateTree(expanded, "", &created)
+	if err != nil {
+		if rbErr := b.rollbackTemplate(created); rbErr != nil {
+			return nil, errors.Join(err, rbErr)
+		}
+		return nil, err
+	}
+	return root, nil
+}
+
+// createTemplateTree creates tpl under parent and then its children,
+// appending each created ID to created.
+func (b *Beads) createTemplateTree(tpl Template, parent string, created *[]string) (*Issue, error) {
+	issue, err := b.Create(CreateOptions{
+		Title:       tpl.Title,
+		Type:        tpl.Type,
+		Priority:    tpl.Priority,
+		Description: tpl.Description,
+		Parent:      parent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating %q from template: %w", tpl.Title, err)
+	}
+	*created = append(*created, issue.ID)
+
+	for _, child := range tpl.Children {
+		if _, err := b.createTemplateTree(child, issue.ID, created); err != nil {
+			return nil, err
+		}
+	}
+	return issue, nil
+}
+
+// rollbackTemplate deletes the issues in created, newest (deepest) first.
+func (b *Beads) rollbackTemplate(created []string) error {
+	var errs []error
+	for _, id := range slices.Backward(created) {
+		if _, err := b.run("delete", id, "--hard", "--force"); err != nil {
+			errs = append(errs, fmt.Errorf("rolling back %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// expandTemplate returns a copy of tpl with placeholders in every title and
+// description replaced from vars, or an error naming any missing variables.
+func expandTemplate(tpl Template, vars map[string]string) (Template, error) {
+	missing := map[string]bool{}
+	expanded := expandTemplateNode(tpl, vars, missing)
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return Template{}, fmt.Errorf("template variables not set: %s", strings.Join(names, ", "))
+	}
+	return expanded, nil
+}
+
+func expandTemplateNode(tpl Template, vars map[string]string, missing map[string]bool) Template {
+	expand := func(s string) string {
+		for _, m := range templateVarRegex.FindAllStringSubmatch(s, -1) {
+			if _, ok := vars[m[1]]; !ok {
+				missing[m[1]] = true
+			}
+		}
+		return ExpandTemplateVars(s, vars)
+	}
+
+	out := tpl
+	out.Title = expand(tpl.Title)
+	out.Description = expand(tpl.Description)
+	out.Children = nil
+	for _, child := range tpl.Children {
+		out.Children = append(out.Children, expandTemplateNode(child, vars, missing))
+	}
+	return out
+}
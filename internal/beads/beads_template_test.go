@@ -0,0 +1,116 @@
+package beads
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var releaseTemplate = Template{
+	Title:       "Release {{version}}",
+	Description: "Ship {{version}} of {{project}}",
+	Type:        "epic",
+	Priority:    1,
+	Children: []Template{
+		{Title: "Tag {{version}}", Type: "task", Priority: 1},
+		{Title: "Announce {{version}}", Type: "task", Priority: -1},
+	},
+}
+
+func TestExpandTemplate(t *testing.T) {
+	got, err := expandTemplate(releaseTemplate, map[string]string{"version": "v1.2", "project": "gastown"})
+	if err != nil {
+		t.Fatalf("expandTemplate() error: %v", err)
+	}
+	if got.Title != "Release v1.2" || got.Description != "Ship v1.2 of gastown" {
+		t.Errorf("expanded parent = %q / %q", got.Title, got.Description)
+	}
+	var childTitles []string
+	for _, c := range got.Children {
+		childTitles = append(childTitles, c.Title)
+	}
+	if want := []string{"Tag v1.2", "Announce v1.2"}; !reflect.DeepEqual(childTitles, want) {
+		t.Errorf("child titles = %v, want %v", childTitles, want)
+	}
+	if releaseTemplate.Children[0].Title != "Tag {{version}}" {
+		t.Error("expandTemplate modified the input template")
+	}
+}
+
+func TestExpandTemplate_MissingVars(t *testing.T) {
+	_, err := expandTemplate(releaseTemplate, map[string]string{})
+	if err == nil || !strings.Contains(err.Error(), "project, version") {
+		t.Errorf("expandTemplate() error = %v, want missing project, version", err)
+	}
+}
+
+func TestCreateFromTemplate(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{Match: "create", Outputs: []string{
+			`{"id":"gt-epic","title":"Release v1.2"}`,
+			`{"id":"gt-tag"}`,
+			`{"id":"gt-announce"}`,
+		}},
+	)
+
+	root, err := New(t.TempDir()).CreateFromTemplate(releaseTemplate, map[string]string{"version": "v1.2", "project": "gastown"})
+	if err != nil {
+		t.Fatalf("CreateFromTemplate() error: %v", err)
+	}
+	if root.ID != "gt-epic" {
+		t.Errorf("root ID = %q, want gt-epic", root.ID)
+	}
+
+	creates := fake.callsMatching(t, "create")
+	if len(creates) != 3 {
+		t.Fatalf("creates = %v, want 3", creates)
+	}
+	if !strings.Contains(creates[0], "--title=Release v1.2") || strings.Contains(creates[0], "--parent=") {
+		t.Errorf("parent create = %q", creates[0])
+	}
+	for i, title := range []string{"Tag v1.2", "Announce v1.2"} {
+		if !strings.Contains(creates[i+1], "--title="+title) || !strings.Contains(creates[i+1], "--parent=gt-epic") {
+			t.Errorf("child create = %q, want %q under gt-epic", creates[i+1], title)
+		}
+	}
+	if !strings.Contains(creates[1], "--priority=1") {
+		t.Errorf("child create = %q, want --priority=1", creates[1])
+	}
+	if strings.Contains(creates[2], "--priority") {
+		t.Errorf("child create = %q, want no --priority for unset (-1) priority", creates[2])
+	}
+}
+
+func TestCreateFromTemplate_MissingVarsCreatesNothing(t *testing.T) {
+	fake := installFakeBd(t)
+
+	if _, err := New(t.TempDir()).CreateFromTemplate(releaseTemplate, nil); err == nil {
+		t.Fatal("CreateFromTemplate() should fail with unset variables")
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("bd calls = %v, want none", calls)
+	}
+}
+
+func TestCreateFromTemplate_RollsBackOnFailure(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{Match: "--title=Announce", Stderr: "Error: database is locked", Exit: 1},
+		fakeBdRule{Match: "create", Outputs: []string{`{"id":"gt-epic"}`, `{"id":"gt-tag"}`}},
+	)
+
+	_, err := New(t.TempDir()).CreateFromTemplate(releaseTemplate, map[string]string{"version": "v1.2", "project": "gastown"})
+	if err == nil || !strings.Contains(err.Error(), "Announce v1.2") {
+		t.Fatalf("CreateFromTemplate() error = %v, want failure creating Announce v1.2", err)
+	}
+
+	deletes := fake.callsMatching(t, "delete")
+	want := []string{"delete gt-tag --hard --force", "delete gt-epic --hard --force"}
+	if len(deletes) != len(want) {
+		t.Fatalf("deletes = %v, want %v", deletes, want)
+	}
+	for i := range want {
+		if !strings.Contains(deletes[i], want[i]) {
+			t.Errorf("delete[%d] = %q, want %q", i, deletes[i], want[i])
+		}
+	}
+}
@@ -2,11 +2,15 @@ package beads
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestNew verifies the constructor.
@@ -1120,6 +1124,131 @@ func TestResolveBeadsDir(t *testing.T) {
 	})
 }
 
+// TestResolveBeadsDirVerbose tests the redirect trace.
+func TestResolveBeadsDirVerbose(t *testing.T) {
+	writeRedirect := func(t *testing.T, workDir, target string) string {
+		t.Helper()
+		beadsDir := filepath.Join(workDir, ".beads")
+		if err := os.MkdirAll(beadsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(beadsDir, "redirect")
+		if target != "" {
+			if err := os.WriteFile(path, []byte(target+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return path
+	}
+
+	t.Run("simple redirect", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		workDir := filepath.Join(tmpDir, "crew", "max")
+		redirectPath := writeRedirect(t, workDir, "../../mayor/rig/.beads")
+		writeRedirect(t, filepath.Join(tmpDir, "mayor", "rig"), "")
+
+		res, err := ResolveBeadsDirVerbose(workDir)
+		if err != nil {
+			t.Fatalf("ResolveBeadsDirVerbose: %v", err)
+		}
+		want := filepath.Join(tmpDir, "mayor", "rig", ".beads")
+		if res.WorkDir != workDir {
+			t.Errorf("WorkDir = %q, want %q", res.WorkDir, workDir)
+		}
+		if res.Final != want {
+			t.Errorf("Final = %q, want %q", res.Final, want)
+		}
+		wantHops := []RedirectHop{{RedirectFile: redirectPath, Target: "../../mayor/rig/.beads", Resolved: want}}
+		if !reflect.DeepEqual(res.Hops, wantHops) {
+			t.Errorf("Hops = %+v, want %+v", res.Hops, wantHops)
+		}
+		if len(res.Warnings) != 0 {
+			t.Errorf("Warnings = %+v, want none", res.Warnings)
+		}
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		// crew/max -> rig -> mayor/rig
+		tmpDir := t.TempDir()
+		crewDir := filepath.Join(tmpDir, "rig", "crew", "max")
+		rigDir := filepath.Join(tmpDir, "rig")
+		mayorDir := filepath.Join(tmpDir, "rig", "mayor", "rig")
+		crewRedirect := writeRedirect(t, crewDir, "../../.beads")
+		rigRedirect := writeRedirect(t, rigDir, "mayor/rig/.beads")
+		writeRedirect(t, mayorDir, "")
+
+		res, err := ResolveBeadsDirVerbose(crewDir)
+		if err != nil {
+			t.Fatalf("ResolveBeadsDirVerbose: %v", err)
+		}
+		wantHops := []RedirectHop{
+			{RedirectFile: crewRedirect, Target: "../../.beads", Resolved: filepath.Join(rigDir, ".beads")},
+			{RedirectFile: rigRedirect, Target: "mayor/rig/.beads", Resolved: filepath.Join(mayorDir, ".beads")},
+		}
+		if !reflect.DeepEqual(res.Hops, wantHops) {
+			t.Errorf("Hops = %+v, want %+v", res.Hops, wantHops)
+		}
+		if res.Final != filepath.Join(mayorDir, ".beads") {
+			t.Errorf("Final = %q, want %q", res.Final, filepath.Join(mayorDir, ".beads"))
+		}
+	})
+
+	t.Run("circular redirect", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		workDir := filepath.Join(tmpDir, "mayor", "rig")
+		redirectPath := writeRedirect(t, workDir, "../../mayor/rig/.beads")
+
+		res, err := ResolveBeadsDirVerbose(workDir)
+		if err != nil {
+			t.Fatalf("ResolveBeadsDirVerbose: %v", err)
+		}
+		if res.Final != filepath.Join(workDir, ".beads") {
+			t.Errorf("Final = %q, want %q", res.Final, filepath.Join(workDir, ".beads"))
+		}
+		if len(res.Hops) != 0 {
+			t.Errorf("Hops = %+v, want none", res.Hops)
+		}
+		if len(res.Warnings) != 1 || res.Warnings[0].Kind != RedirectWarnCircular || res.Warnings[0].Path != redirectPath {
+			t.Errorf("Warnings = %+v, want one circular warning for %s", res.Warnings, redirectPath)
+		}
+		if res.ErrantRedirect != redirectPath {
+			t.Errorf("ErrantRedirect = %q, want %q", res.ErrantRedirect, redirectPath)
+		}
+		// Unlike ResolveBeadsDir, the verbose form must not remove the file.
+		if _, err := os.Stat(redirectPath); err != nil {
+			t.Errorf("redirect file should be left in place: %v", err)
+		}
+	})
+
+	t.Run("chain too deep", func(t *testing.T) {
+		// a -> b -> c -> d -> e -> f: four redirects are followed, then the
+		// fifth is reported and not taken.
+		tmpDir := t.TempDir()
+		names := []string{"a", "b", "c", "d", "e", "f"}
+		for i, name := range names {
+			target := ""
+			if i+1 < len(names) {
+				target = "../" + names[i+1] + "/.beads"
+			}
+			writeRedirect(t, filepath.Join(tmpDir, name), target)
+		}
+
+		res, err := ResolveBeadsDirVerbose(filepath.Join(tmpDir, "a"))
+		if err != nil {
+			t.Fatalf("ResolveBeadsDirVerbose: %v", err)
+		}
+		if len(res.Hops) != 4 {
+			t.Errorf("len(Hops) = %d, want 4", len(res.Hops))
+		}
+		if want := filepath.Join(tmpDir, "e", ".beads"); res.Final != want {
+			t.Errorf("Final = %q, want %q", res.Final, want)
+		}
+		if len(res.Warnings) != 1 || res.Warnings[0].Kind != RedirectWarnChainDepth {
+			t.Errorf("Warnings = %+v, want one chain-too-deep warning", res.Warnings)
+		}
+	})
+}
+
 func TestParseAgentBeadID(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -2359,3 +2488,1220 @@ func TestIsAgentBead(t *testing.T) {
 		})
 	}
 }
+
+func TestConflicts(t *testing.T) {
+	installFakeBd(t, fakeBdRule{
+		Match:   "sync --status --json",
+		Outputs: []string{`{"Branch":"beads-sync","Conflicts":["gt-abc","gt-def"]}`},
+	})
+
+	conflicts, err := New(t.TempDir()).Conflicts()
+	if err != nil {
+		t.Fatalf("Conflicts() error: %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[0].BeadID != "gt-abc" || conflicts[1].BeadID != "gt-def" {
+		t.Errorf("Conflicts() = %+v, want gt-abc and gt-def", conflicts)
+	}
+}
+
+func TestResolveConflict_StrategyArgv(t *testing.T) {
+	for _, strategy := range []string{ConflictStrategyOurs, ConflictStrategyTheirs, ConflictStrategyNewest} {
+		t.Run(strategy, func(t *testing.T) {
+			fake := installFakeBd(t)
+
+			if err := New(t.TempDir()).ResolveConflict("gt-abc", strategy); err != nil {
+				t.Fatalf("ResolveConflict() error: %v", err)
+			}
+
+			calls := fake.callsMatching(t, "resolve-conflicts")
+			if len(calls) != 1 {
+				t.Fatalf("expected 1 resolve-conflicts call, got %v", fake.calls(t))
+			}
+			if !strings.Contains(calls[0], "resolve-conflicts gt-abc --strategy="+strategy) {
+				t.Errorf("argv = %q, want resolve-conflicts gt-abc --strategy=%s", calls[0], strategy)
+			}
+		})
+	}
+}
+
+func TestResolveConflict_InvalidStrategy(t *testing.T) {
+	fake := installFakeBd(t)
+
+	if err := New(t.TempDir()).ResolveConflict("gt-abc", "mine"); err == nil {
+		t.Fatal("ResolveConflict() with invalid strategy should error")
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("invalid strategy should not exec bd, got %v", calls)
+	}
+}
+
+func TestBlockedWithReasons(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{
+			Match: "blocked --json",
+			Outputs: []string{`[
+				{"id":"gt-1","title":"Deploy","status":"open","blocked_by":["gt-10","external:hq:hq-20"]},
+				{"id":"gt-2","title":"Docs","status":"open","blocked_by":["gt-10"]}
+			]`},
+		},
+		fakeBdRule{
+			Match:   "show --json",
+			Outputs: []string{`[{"id":"gt-10","title":"Fix auth","status":"open"}]`},
+		},
+	)
+
+	blocked, err := New(t.TempDir()).BlockedWithReasons()
+	if err != nil {
+		t.Fatalf("BlockedWithReasons() error: %v", err)
+	}
+	if len(blocked) != 2 {
+		t.Fatalf("got %d blocked issues, want 2", len(blocked))
+	}
+
+	first := blocked[0]
+	if first.Issue.ID != "gt-1" || len(first.Blockers) != 2 {
+		t.Fatalf("first = %+v, want gt-1 with 2 blockers", first)
+	}
+	if got := first.Blockers[0]; got != (BlockerInfo{ID: "gt-10", Title: "Fix auth", Status: "open"}) {
+		t.Errorf("first blocker = %+v, want resolved gt-10", got)
+	}
+	if got := first.Blockers[1]; got != (BlockerInfo{ID: "hq-20"}) {
+		t.Errorf("unresolved blocker = %+v, want ID only", got)
+	}
+	if got := blocked[1].Blockers; len(got) != 1 || got[0].Title != "Fix auth" {
+		t.Errorf("second blockers = %+v, want gt-10 'Fix auth'", got)
+	}
+
+	// Blockers are deduplicated and fetched in a single batched show.
+	shows := fake.callsMatching(t, "show --json")
+	if len(shows) != 1 || !strings.HasSuffix(shows[0], "show --json gt-10 hq-20") {
+		t.Errorf("show calls = %v, want one batched show of gt-10 hq-20", shows)
+	}
+}
+
+func TestReassign_SingleCombinedUpdate(t *testing.T) {
+	fake := installFakeBd(t)
+
+	if err := New(t.TempDir()).Reassign("gt-abc", "gastown/polecats/Toast", "worker died"); err != nil {
+		t.Fatalf("Reassign() error: %v", err)
+	}
+
+	calls := fake.calls(t)
+	if len(calls) != 1 {
+		t.Fatalf("expected exactly 1 bd call, got %v", calls)
+	}
+	for _, want := range []string{
+		"update gt-abc",
+		"--status=in_progress",
+		"--assignee=gastown/polecats/Toast",
+		"--notes=Reassigned to gastown/polecats/Toast: worker died",
+	} {
+		if !strings.Contains(calls[0], want) {
+			t.Errorf("argv %q missing %q", calls[0], want)
+		}
+	}
+}
+
+func TestReassign_RequiresAssignee(t *testing.T) {
+	fake := installFakeBd(t)
+
+	if err := New(t.TempDir()).Reassign("gt-abc", "", "worker died"); err == nil {
+		t.Fatal("Reassign() with empty assignee should error")
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("empty assignee should not exec bd, got %v", calls)
+	}
+}
+
+func TestInReview(t *testing.T) {
+	fake := installFakeBd(t)
+
+	if err := New(t.TempDir()).InReview("gt-abc"); err != nil {
+		t.Fatalf("InReview() error: %v", err)
+	}
+
+	calls := fake.calls(t)
+	if len(calls) != 1 || !strings.HasSuffix(calls[0], "update gt-abc --status=in_review") {
+		t.Errorf("bd calls = %v, want single update to in_review", calls)
+	}
+	if strings.Contains(calls[0], "--assignee") {
+		t.Errorf("InReview should keep the assignee, got %q", calls[0])
+	}
+}
+
+func TestGetAssignedIssue_IncludesInReview(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{
+		Match:   "--status=in_review --assignee=gastown/polecats/Toast",
+		Outputs: []string{`[{"id":"gt-rev","status":"in_review","assignee":"gastown/polecats/Toast"}]`},
+	})
+
+	issue, err := New(t.TempDir()).GetAssignedIssue("gastown/polecats/Toast")
+	if err != nil {
+		t.Fatalf("GetAssignedIssue() error: %v", err)
+	}
+	if issue == nil || issue.ID != "gt-rev" {
+		t.Fatalf("GetAssignedIssue() = %+v, want gt-rev", issue)
+	}
+	if got := fake.callsMatching(t, "list --json"); len(got) != 4 {
+		t.Errorf("expected open, in_progress, hooked and in_review lookups, got %v", got)
+	}
+}
+
+func TestOpenAssignees(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{
+		Match: "list --json --limit=",
+		Outputs: []string{`[
+			{"id":"gt-1","status":"open","assignee":"gastown/polecats/Toast"},
+			{"id":"gt-2","status":"in_progress","assignee":"gastown/crew/max"},
+			{"id":"gt-3","status":"open"},
+			{"id":"gt-4","status":"hooked","assignee":"gastown/polecats/Toast"},
+			{"id":"gt-5","status":"open","assignee":""},
+			{"id":"gt-6","status":"open","assignee":"beads/polecats/Nux"}
+		]`},
+	})
+	b := New(t.TempDir())
+
+	got, err := b.OpenAssignees()
+	if err != nil {
+		t.Fatalf("OpenAssignees() error: %v", err)
+	}
+	want := []string{"beads/polecats/Nux", "gastown/crew/max", "gastown/polecats/Toast"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OpenAssignees() = %v, want %v", got, want)
+	}
+
+	workload, err := b.AssigneeWorkload()
+	if err != nil {
+		t.Fatalf("AssigneeWorkload() error: %v", err)
+	}
+	wantLoad := map[string]int{
+		"gastown/polecats/Toast": 2,
+		"gastown/crew/max":       1,
+		"beads/polecats/Nux":     1,
+	}
+	if !reflect.DeepEqual(workload, wantLoad) {
+		t.Errorf("AssigneeWorkload() = %v, want %v", workload, wantLoad)
+	}
+
+	// Open work only: no status filter means bd's default (non-closed).
+	for _, call := range fake.callsMatching(t, "list") {
+		if strings.Contains(call, "--status") {
+			t.Errorf("expected default status filter, got %q", call)
+		}
+	}
+}
+
+func TestEmptyOutputIsEmptyResult(t *testing.T) {
+	for _, out := range []string{"", "  \n\t\n"} {
+		installFakeBd(t, fakeBdRule{Match: "--json", Outputs: []string{out}})
+		b := New(t.TempDir())
+
+		listers := map[string]func() ([]*Issue, error){
+			"List":          func() ([]*Issue, error) { return b.List(ListOptions{Priority: -1}) },
+			"Ready":         b.Ready,
+			"ReadyForMol":   func() ([]*Issue, error) { return b.ReadyForMol("gt-mol") },
+			"ReadyWithType": func() ([]*Issue, error) { return b.ReadyWithType("task") },
+			"Blocked":       b.Blocked,
+		}
+		for name, fn := range listers {
+			issues, err := fn()
+			if err != nil {
+				t.Errorf("%s() with output %q: error %v, want empty result", name, out, err)
+			}
+			if len(issues) != 0 {
+				t.Errorf("%s() with output %q = %v, want empty", name, out, issues)
+			}
+		}
+
+		if _, err := b.Show("gt-abc"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Show() with output %q: error %v, want ErrNotFound", out, err)
+		}
+		issues, err := b.ShowMultiple([]string{"gt-a", "gt-b"})
+		if err != nil || len(issues) != 0 {
+			t.Errorf("ShowMultiple() with output %q = %v, %v; want empty map", out, issues, err)
+		}
+	}
+}
+
+func TestReleaseAllForAssignee(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{
+			Match:   "list --json --status=in_progress --assignee=gastown/polecats/Toast",
+			Outputs: []string{`[{"id":"gt-1","status":"in_progress"},{"id":"gt-2","status":"in_progress"}]`},
+		},
+		fakeBdRule{
+			Match:   "list --json --status=open --assignee=gastown/polecats/Toast",
+			Outputs: []string{`[{"id":"gt-3","status":"open"}]`},
+		},
+		fakeBdRule{Match: "update gt-2 ", Stderr: "database is locked", Exit: 1},
+	)
+
+	n, err := New(t.TempDir()).ReleaseAllForAssignee("gastown/polecats/Toast", "worker died")
+	if n != 2 {
+		t.Errorf("released = %d, want 2", n)
+	}
+	if err == nil || !strings.Contains(err.Error(), "gt-2") {
+		t.Errorf("error = %v, want aggregated failure mentioning gt-2", err)
+	}
+
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 3 {
+		t.Fatalf("expected an update per issue despite the failure, got %v", updates)
+	}
+	for _, call := range updates {
+		for _, want := range []string{"--status=open", "--assignee=", "--notes=Released: worker died"} {
+			if !strings.Contains(call, want) {
+				t.Errorf("argv %q missing %q", call, want)
+			}
+		}
+	}
+}
+
+func TestRenameLabel(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{
+		Match: "list --json --status=all --label=wip",
+		Outputs: []string{
+			`[{"id":"gt-1","labels":["wip"]},{"id":"gt-2","labels":["wip","bug"]},{"id":"gt-3","labels":["wip"]}]`,
+			`[]`,
+		},
+	})
+	b := New(t.TempDir())
+
+	n, err := b.RenameLabel("wip", "in-flight")
+	if err != nil {
+		t.Fatalf("RenameLabel() error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("RenameLabel() = %d, want 3", n)
+	}
+
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 3 {
+		t.Fatalf("expected 3 updates, got %v", updates)
+	}
+	for i, id := range []string{"gt-1", "gt-2", "gt-3"} {
+		if !strings.Contains(updates[i], "update "+id+" --add-label=in-flight --remove-label=wip") {
+			t.Errorf("update[%d] = %q, want relabel of %s", i, updates[i], id)
+		}
+	}
+
+	// Re-running finds nothing left to do.
+	n, err = b.RenameLabel("wip", "in-flight")
+	if err != nil {
+		t.Fatalf("second RenameLabel() error: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("second RenameLabel() = %d, want 0", n)
+	}
+	if got := len(fake.callsMatching(t, "update")); got != 3 {
+		t.Errorf("second run issued updates: total %d, want 3", got)
+	}
+}
+
+func TestChangeType_TaskToEpic(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{
+		Match:   "show gt-1 --json",
+		Outputs: []string{`[{"id":"gt-1","issue_type":"task"}]`},
+	})
+
+	if err := New(t.TempDir()).ChangeType("gt-1", "epic"); err != nil {
+		t.Fatalf("ChangeType() error: %v", err)
+	}
+
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 update, got %v", fake.calls(t))
+	}
+	if !strings.Contains(updates[0], "update gt-1 --type=epic --add-label=gt:epic --remove-label=gt:task") {
+		t.Errorf("update argv = %q", updates[0])
+	}
+}
+
+func TestChangeType_RejectsInvalidAndAgent(t *testing.T) {
+	fake := installFakeBd(t)
+	b := New(t.TempDir())
+
+	for _, typ := range []string{"story", "agent", ""} {
+		if err := b.ChangeType("gt-1", typ); err == nil {
+			t.Errorf("ChangeType(%q) should error", typ)
+		}
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("rejected types should not exec bd, got %v", calls)
+	}
+}
+
+func TestChangeType_EpicWithChildrenGuard(t *testing.T) {
+	rules := []fakeBdRule{
+		{Match: "show gt-epic --json", Outputs: []string{`[{"id":"gt-epic","issue_type":"epic","parent":"gt-root"}]`}},
+		{Match: "--parent=gt-epic", Outputs: []string{`[{"id":"gt-c1"},{"id":"gt-c2"}]`}},
+	}
+
+	t.Run("refused without force", func(t *testing.T) {
+		fake := installFakeBd(t, rules...)
+
+		err := New(t.TempDir()).ChangeType("gt-epic", "task")
+		if err == nil || !strings.Contains(err.Error(), "2 children") {
+			t.Fatalf("ChangeType() error = %v, want children guard", err)
+		}
+		if updates := fake.callsMatching(t, "update"); len(updates) != 0 {
+			t.Errorf("guarded change should not update, got %v", updates)
+		}
+	})
+
+	t.Run("force reparents children", func(t *testing.T) {
+		fake := installFakeBd(t, rules...)
+
+		if err := New(t.TempDir()).ForceChangeType("gt-epic", "task"); err != nil {
+			t.Fatalf("ForceChangeType() error: %v", err)
+		}
+		updates := fake.callsMatching(t, "update")
+		if len(updates) != 3 {
+			t.Fatalf("expected 3 updates, got %v", updates)
+		}
+		if !strings.Contains(updates[0], "update gt-c1 --parent=gt-root") ||
+			!strings.Contains(updates[1], "update gt-c2 --parent=gt-root") {
+			t.Errorf("children not reparented to gt-root: %v", updates)
+		}
+		if !strings.Contains(updates[2], "update gt-epic --type=task") {
+			t.Errorf("type change missing: %q", updates[2])
+		}
+	})
+}
+
+// makeBeadsRepo creates workDir/.beads with the metadata.json bd writes
+// when it initializes a server-mode repo.
+func makeBeadsRepo(t *testing.T, workDir string) {
+	t.Helper()
+	beadsDir := filepath.Join(workDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(`{"backend":"dolt"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		fake := installFakeBd(t)
+		workDir := t.TempDir()
+		makeBeadsRepo(t, workDir)
+
+		if err := New(workDir).Ping(); err != nil {
+			t.Fatalf("Ping() error: %v", err)
+		}
+		if calls := fake.callsMatching(t, "list --json --limit=1"); len(calls) != 1 {
+			t.Errorf("expected one cheap list, got %v", fake.calls(t))
+		}
+	})
+
+	t.Run("not installed", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		workDir := t.TempDir()
+		makeBeadsRepo(t, workDir)
+
+		if err := New(workDir).Ping(); !errors.Is(err, ErrNotInstalled) {
+			t.Errorf("Ping() error = %v, want ErrNotInstalled", err)
+		}
+	})
+
+	t.Run("not a repo", func(t *testing.T) {
+		fake := installFakeBd(t)
+
+		err := New(t.TempDir()).Ping()
+		if !errors.Is(err, ErrNoBeadsDir) {
+			t.Errorf("Ping() error = %v, want ErrNoBeadsDir", err)
+		}
+		if calls := fake.calls(t); len(calls) != 0 {
+			t.Errorf("missing repo should not exec bd, got %v", calls)
+		}
+	})
+
+	t.Run("dir without beads data", func(t *testing.T) {
+		fake := installFakeBd(t)
+		workDir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(workDir, ".beads"), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		err := New(workDir).Ping()
+		if !errors.Is(err, ErrNoBeadsDir) {
+			t.Errorf("Ping() error = %v, want ErrNoBeadsDir", err)
+		}
+		if calls := fake.calls(t); len(calls) != 0 {
+			t.Errorf("uninitialized repo should not exec bd, got %v", calls)
+		}
+	})
+
+	t.Run("redirect target missing", func(t *testing.T) {
+		installFakeBd(t)
+		workDir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(workDir, ".beads"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		redirect := filepath.Join(workDir, ".beads", "redirect")
+		if err := os.WriteFile(redirect, []byte("../../mayor/rig/.beads\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err := New(workDir).Ping()
+		if !errors.Is(err, ErrRedirectTargetMissing) {
+			t.Errorf("Ping() error = %v, want ErrRedirectTargetMissing", err)
+		}
+	})
+
+	t.Run("bd read fails", func(t *testing.T) {
+		installFakeBd(t, fakeBdRule{Match: "list", Stderr: "database is locked", Exit: 1})
+		workDir := t.TempDir()
+		makeBeadsRepo(t, workDir)
+
+		err := New(workDir).Ping()
+		if err == nil || !strings.Contains(err.Error(), "database is locked") {
+			t.Errorf("Ping() error = %v, want bd failure", err)
+		}
+		for _, sentinel := range []error{ErrNotInstalled, ErrNoBeadsDir, ErrRedirectTargetMissing} {
+			if errors.Is(err, sentinel) {
+				t.Errorf("bd failure misclassified as %v", sentinel)
+			}
+		}
+	})
+}
+
+func TestListRawAndShowRawPreserveUnknownFields(t *testing.T) {
+	installFakeBd(t,
+		fakeBdRule{Match: "list --json", Outputs: []string{`[{"id":"gt-1","title":"A","future_field":{"x":1}}]`}},
+		fakeBdRule{Match: "show gt-1 --json", Outputs: []string{`[{"id":"gt-1","title":"A","future_field":{"x":1}}]`}},
+	)
+	b := New(t.TempDir())
+
+	decode := func(raw json.RawMessage) map[string]json.RawMessage {
+		t.Helper()
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			t.Fatalf("decoding raw issue: %v", err)
+		}
+		return fields
+	}
+
+	list, err := b.ListRaw(ListOptions{Priority: -1})
+	if err != nil {
+		t.Fatalf("ListRaw() error: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("ListRaw() returned %d issues, want 1", len(list))
+	}
+	if got := string(decode(list[0])["future_field"]); got != `{"x":1}` {
+		t.Errorf("ListRaw() future_field = %s, want {\"x\":1}", got)
+	}
+
+	shown, err := b.ShowRaw("gt-1")
+	if err != nil {
+		t.Fatalf("ShowRaw() error: %v", err)
+	}
+	if got := string(decode(shown)["future_field"]); got != `{"x":1}` {
+		t.Errorf("ShowRaw() future_field = %s, want {\"x\":1}", got)
+	}
+}
+
+func TestCreateRejectsUnknownType(t *testing.T) {
+	fake := installFakeBd(t)
+
+	_, err := New(t.TempDir()).Create(CreateOptions{Title: "Fix it", Type: "bgu", Priority: -1})
+	if err == nil || !strings.Contains(err.Error(), `unknown type "bgu"`) {
+		t.Errorf("Create() error = %v, want unknown type", err)
+	}
+	_, err = New(t.TempDir()).CreateWithID("gt-x", CreateOptions{Title: "Fix it", Type: "bgu", Priority: -1})
+	if err == nil || !strings.Contains(err.Error(), `unknown type "bgu"`) {
+		t.Errorf("CreateWithID() error = %v, want unknown type", err)
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("unknown type should not exec bd, got %v", calls)
+	}
+}
+
+func TestCreateWithLabels(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "create", Outputs: []string{`{"id":"gt-1"}`}})
+
+	b := New(t.TempDir())
+	opts := CreateOptions{Title: "Thing", Type: "task", Priority: -1, Labels: []string{"federate", "effort:small"}}
+	if _, err := b.Create(opts); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if _, err := b.CreateWithID("gt-x", opts); err != nil {
+		t.Fatalf("CreateWithID() error: %v", err)
+	}
+
+	calls := fake.callsMatching(t, "create")
+	if len(calls) != 2 {
+		t.Fatalf("create calls = %v, want 2", calls)
+	}
+	for _, call := range calls {
+		for _, want := range []string{"--labels=gt:task", "--labels=federate", "--labels=effort:small"} {
+			if !strings.Contains(call, want) {
+				t.Errorf("create call %q missing %s", call, want)
+			}
+		}
+	}
+	if updates := fake.callsMatching(t, "update"); len(updates) != 0 {
+		t.Errorf("labels should not need a follow-up update, got %v", updates)
+	}
+}
+
+func TestCreateRejectsEmptyLabel(t *testing.T) {
+	fake := installFakeBd(t)
+
+	opts := CreateOptions{Title: "Thing", Priority: -1, Labels: []string{"ok", " "}}
+	if _, err := New(t.TempDir()).Create(opts); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("Create() error = %v, want empty label", err)
+	}
+	if _, err := New(t.TempDir()).CreateWithID("gt-x", opts); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("CreateWithID() error = %v, want empty label", err)
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("empty label should not exec bd, got %v", calls)
+	}
+}
+
+func TestTypedCreateHelpers(t *testing.T) {
+	helpers := map[string]func(*Beads, CreateOptions) (*Issue, error){
+		"bug":     (*Beads).CreateBug,
+		"feature": (*Beads).CreateFeature,
+		"task":    (*Beads).CreateTask,
+		"epic":    (*Beads).CreateEpic,
+	}
+
+	for wantType, create := range helpers {
+		t.Run(wantType, func(t *testing.T) {
+			fake := installFakeBd(t, fakeBdRule{Match: "create", Outputs: []string{`{"id":"gt-1"}`}})
+
+			// A conflicting Type in opts is overridden by the helper.
+			if _, err := create(New(t.TempDir()), CreateOptions{Title: "Thing", Type: "chore", Priority: -1}); err != nil {
+				t.Fatalf("create error: %v", err)
+			}
+			calls := fake.callsMatching(t, "create")
+			if len(calls) != 1 || !strings.Contains(calls[0], "--labels=gt:"+wantType) {
+				t.Errorf("create calls = %v, want --labels=gt:%s", calls, wantType)
+			}
+		})
+	}
+}
+
+func TestAssignBatch(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "update gt-2 ", Stderr: "issue not found", Exit: 1})
+
+	failed, err := New(t.TempDir()).AssignBatch(map[string]string{
+		"gt-1": "gastown/polecats/Toast",
+		"gt-2": "gastown/polecats/Nux",
+		"gt-3": "gastown/polecats/Toast",
+	})
+	if err != nil {
+		t.Fatalf("AssignBatch() error: %v", err)
+	}
+	if len(failed) != 1 || failed["gt-2"] == nil {
+		t.Errorf("failed = %v, want only gt-2", failed)
+	}
+
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 3 {
+		t.Fatalf("expected an update per issue despite the failure, got %v", updates)
+	}
+	if !strings.Contains(updates[2], "update gt-3 --assignee=gastown/polecats/Toast") {
+		t.Errorf("third update = %q, want gt-3 assigned to Toast", updates[2])
+	}
+}
+
+func TestAssignBatchConcurrent(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "update gt-5 ", Stderr: "issue not found", Exit: 1})
+
+	assignments := make(map[string]string)
+	for i := 1; i <= 10; i++ {
+		assignments[fmt.Sprintf("gt-%d", i)] = fmt.Sprintf("gastown/polecats/p%d", i)
+	}
+
+	b := New(t.TempDir())
+	b.Concurrency = 4
+	failed, err := b.AssignBatch(assignments)
+	if err != nil {
+		t.Fatalf("AssignBatch() error: %v", err)
+	}
+	if len(failed) != 1 || failed["gt-5"] == nil {
+		t.Errorf("failed = %v, want only gt-5", failed)
+	}
+
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != len(assignments) {
+		t.Fatalf("expected %d updates, got %v", len(assignments), updates)
+	}
+	for id, assignee := range assignments {
+		want := "update " + id + " --assignee=" + assignee
+		found := false
+		for _, call := range updates {
+			if strings.Contains(call, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("no update for %s, calls = %v", id, updates)
+		}
+	}
+}
+
+func TestUpdateMany(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "update gt-2 ", Stderr: "issue not found", Exit: 1})
+
+	failed, err := New(t.TempDir()).UpdateMany([]string{"gt-1", "gt-2", "gt-3", "gt-1"},
+		UpdateOptions{AddLabels: []string{"federate"}})
+	if err != nil {
+		t.Fatalf("UpdateMany() error: %v", err)
+	}
+	if len(failed) != 1 || failed["gt-2"] == nil {
+		t.Errorf("failed = %v, want only gt-2", failed)
+	}
+
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 3 {
+		t.Fatalf("update calls = %v, want one per distinct issue", updates)
+	}
+	for i, id := range []string{"gt-1", "gt-2", "gt-3"} {
+		if !strings.Contains(updates[i], "update "+id+" --add-label=federate") {
+			t.Errorf("update %d = %q, want %s labeled federate", i, updates[i], id)
+		}
+	}
+}
+
+func TestUpdateManyRejectsEmptyID(t *testing.T) {
+	fake := installFakeBd(t)
+
+	if _, err := New(t.TempDir()).UpdateMany([]string{"gt-1", ""}, UpdateOptions{}); err == nil {
+		t.Error("UpdateMany() with an empty ID should fail")
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("invalid input should not exec bd, got %v", calls)
+	}
+}
+
+func TestAssignBatchRejectsEmptyAssignee(t *testing.T) {
+	fake := installFakeBd(t)
+
+	_, err := New(t.TempDir()).AssignBatch(map[string]string{"gt-1": "gastown/polecats/Toast", "gt-2": ""})
+	if err == nil || !strings.Contains(err.Error(), "gt-2") {
+		t.Errorf("AssignBatch() error = %v, want empty assignee for gt-2", err)
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("invalid batch should not exec bd, got %v", calls)
+	}
+}
+
+func TestDistributeReady(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{
+		Match: "ready --json --label gt:task",
+		Outputs: []string{`[
+			{"id":"gt-1"},
+			{"id":"gt-2","assignee":"gastown/polecats/Busy"},
+			{"id":"gt-3"},
+			{"id":"gt-4"},
+			{"id":"gt-5"}
+		]`},
+	})
+
+	plan, err := New(t.TempDir()).DistributeReady([]string{"a", "b"}, "task")
+	if err != nil {
+		t.Fatalf("DistributeReady() error: %v", err)
+	}
+	want := map[string]string{"gt-1": "a", "gt-3": "b", "gt-4": "a", "gt-5": "b"}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("plan = %v, want %v", plan, want)
+	}
+	if updates := fake.callsMatching(t, "update"); len(updates) != 0 {
+		t.Errorf("DistributeReady should only plan, got updates %v", updates)
+	}
+}
+
+func TestStatusLabelRules(t *testing.T) {
+	rules := map[string]LabelChange{
+		"closed":      {Add: []string{"done"}, Remove: []string{"wip"}},
+		"open":        {Remove: []string{"done"}},
+		"in_progress": {Add: []string{"wip"}},
+	}
+
+	t.Run("close", func(t *testing.T) {
+		fake := installFakeBd(t)
+		b := New(t.TempDir())
+		b.StatusLabelRules = rules
+
+		if err := b.CloseWithReason("shipped", "gt-1", "gt-2"); err != nil {
+			t.Fatalf("CloseWithReason() error: %v", err)
+		}
+		updates := fake.callsMatching(t, "update")
+		if len(updates) != 1 || !strings.Contains(updates[0], "update gt-1 gt-2 --add-label=done --remove-label=wip") {
+			t.Errorf("updates = %v, want label change for both issues", updates)
+		}
+	})
+
+	t.Run("set status", func(t *testing.T) {
+		fake := installFakeBd(t)
+		b := New(t.TempDir())
+		b.StatusLabelRules = rules
+
+		if err := b.SetStatus("gt-1", "in_progress"); err != nil {
+			t.Fatalf("SetStatus() error: %v", err)
+		}
+		calls := fake.calls(t)
+		if len(calls) != 1 || !strings.Contains(calls[0], "update gt-1 --status=in_progress --add-label=wip") {
+			t.Errorf("calls = %v, want a single update with the label change", calls)
+		}
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		fake := installFakeBd(t)
+		b := New(t.TempDir())
+		b.StatusLabelRules = rules
+
+		if err := b.Reopen("gt-1"); err != nil {
+			t.Fatalf("Reopen() error: %v", err)
+		}
+		updates := fake.callsMatching(t, "update")
+		if len(updates) != 1 || !strings.Contains(updates[0], "update gt-1 --remove-label=done") {
+			t.Errorf("updates = %v, want done removed", updates)
+		}
+	})
+
+	t.Run("label failure after close only warns", func(t *testing.T) {
+		fake := installFakeBd(t, fakeBdRule{Match: "update", Stderr: "Error: database is locked", Exit: 1})
+		origWarnf := Warnf
+		t.Cleanup(func() { Warnf = origWarnf })
+		var warnings []string
+		Warnf = func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+		b := New(t.TempDir())
+		b.StatusLabelRules = rules
+
+		if err := b.Close("gt-1"); err != nil {
+			t.Fatalf("Close() error = %v, want nil once the close succeeded", err)
+		}
+		if closes := fake.callsMatching(t, "close"); len(closes) != 1 {
+			t.Errorf("closes = %v, want exactly one", closes)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "applying closed labels to gt-1") {
+			t.Errorf("warnings = %v, want the label failure reported through Warnf", warnings)
+		}
+	})
+
+	t.Run("no rules", func(t *testing.T) {
+		fake := installFakeBd(t)
+
+		if err := New(t.TempDir()).Close("gt-1"); err != nil {
+			t.Fatalf("Close() error: %v", err)
+		}
+		if updates := fake.callsMatching(t, "update"); len(updates) != 0 {
+			t.Errorf("nil rules should not update labels, got %v", updates)
+		}
+	})
+}
+
+func TestTouch(t *testing.T) {
+	fake := installFakeBd(t)
+
+	b := New(t.TempDir())
+	for i := 0; i < 2; i++ {
+		if err := b.Touch("gt-agent"); err != nil {
+			t.Fatalf("Touch() call %d error: %v", i+1, err)
+		}
+	}
+	updates := fake.callsMatching(t, "update")
+	if len(updates) != 4 {
+		t.Fatalf("updates = %v, want a remove and an add per touch", updates)
+	}
+	for i, update := range updates {
+		want := "update gt-agent --remove-label=" + TouchLabel
+		if i%2 == 1 {
+			want = "update gt-agent --add-label=" + TouchLabel
+		}
+		if !strings.Contains(update, want) {
+			t.Errorf("update %d = %q, want %q", i, update, want)
+		}
+		if strings.Contains(update, "--priority") {
+			t.Errorf("update = %q, must not rewrite priority", update)
+		}
+	}
+}
+
+func TestTouch_Error(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "update gt-agent", Stderr: "Error: database is locked", Exit: 1})
+
+	if err := New(t.TempDir()).Touch("gt-agent"); err == nil || !strings.Contains(err.Error(), "touching gt-agent") {
+		t.Errorf("Touch() error = %v, want a touching gt-agent error", err)
+	}
+}
+
+func TestCloseWithEvidence(t *testing.T) {
+	fake := installFakeBd(t)
+	b := New(t.TempDir())
+	b.EvidenceLabel = "merged"
+
+	if err := b.CloseWithEvidence("gt-1", "https://github.com/acme/gastown/pull/42", "fixed"); err != nil {
+		t.Fatalf("CloseWithEvidence() error: %v", err)
+	}
+
+	calls := fake.calls(t)
+	if len(calls) != 2 {
+		t.Fatalf("calls = %v, want note then close", calls)
+	}
+	if !strings.Contains(calls[0], "update gt-1 --append-notes=Evidence: https://github.com/acme/gastown/pull/42 --add-label=merged") {
+		t.Errorf("first call = %q, want evidence note and label", calls[0])
+	}
+	if !strings.Contains(calls[1], "close gt-1 --reason=fixed") {
+		t.Errorf("second call = %q, want close with reason", calls[1])
+	}
+}
+
+func TestCloseWithEvidence_InvalidURL(t *testing.T) {
+	fake := installFakeBd(t)
+
+	for _, evidence := range []string{"", "not a url", "github.com/acme/gastown/pull/42", "ftp://example.com/x", "https://"} {
+		if err := New(t.TempDir()).CloseWithEvidence("gt-1", evidence, "fixed"); err == nil {
+			t.Errorf("CloseWithEvidence(%q) should reject the URL", evidence)
+		}
+	}
+	if calls := fake.calls(t); len(calls) != 0 {
+		t.Errorf("invalid URLs should not run bd, got %v", calls)
+	}
+}
+
+func TestReadyCount(t *testing.T) {
+	fake := installFakeBd(t,
+		fakeBdRule{Match: "--label gt:molecule", Outputs: []string{`[{"id":"gt-mol1"}]`}},
+		fakeBdRule{Match: "ready --json", Outputs: []string{`[{"id":"gt-1"},{"id":"gt-2"},{"id":"gt-3"}]`}},
+	)
+	b := New(t.TempDir())
+
+	if n, err := b.ReadyCount(""); err != nil || n != 3 {
+		t.Errorf("ReadyCount(\"\") = %d, %v; want 3", n, err)
+	}
+	if n, err := b.ReadyCount("molecule"); err != nil || n != 1 {
+		t.Errorf("ReadyCount(molecule) = %d, %v; want 1", n, err)
+	}
+	for _, call := range fake.callsMatching(t, "ready") {
+		if !strings.Contains(call, "-n 1000") {
+			t.Errorf("ready call %q should be limited", call)
+		}
+	}
+}
+
+func TestReadyCount_Empty(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "ready", Outputs: []string{""}})
+
+	if n, err := New(t.TempDir()).ReadyCount("task"); err != nil || n != 0 {
+		t.Errorf("ReadyCount() = %d, %v; want 0", n, err)
+	}
+}
+
+func TestShow_ArrayAndObjectForms(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+	}{
+		{"array", `[{"id":"gt-abc","title":"Fix it","status":"open"}]`},
+		{"object", `{"id":"gt-abc","title":"Fix it","status":"open"}`},
+		{"object with whitespace", "\n  {\"id\":\"gt-abc\",\"title\":\"Fix it\",\"status\":\"open\"}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installFakeBd(t, fakeBdRule{Match: "show gt-abc --json", Outputs: []string{tt.output}})
+			b := New(t.TempDir())
+
+			issue, err := b.Show("gt-abc")
+			if err != nil {
+				t.Fatalf("Show() error: %v", err)
+			}
+			if issue.ID != "gt-abc" || issue.Title != "Fix it" || issue.Status != "open" {
+				t.Errorf("Show() = %+v, want gt-abc \"Fix it\" open", issue)
+			}
+
+			raw, err := b.ShowRaw("gt-abc")
+			if err != nil {
+				t.Fatalf("ShowRaw() error: %v", err)
+			}
+			if !strings.HasPrefix(string(raw), "{") {
+				t.Errorf("ShowRaw() = %s, want the issue object", raw)
+			}
+		})
+	}
+}
+
+func TestShow_EmptyOutputsNotFound(t *testing.T) {
+	for _, output := range []string{"", "[]", "{}", "null"} {
+		t.Run(output, func(t *testing.T) {
+			installFakeBd(t, fakeBdRule{Match: "show gt-abc --json", Outputs: []string{output}})
+			if _, err := New(t.TempDir()).Show("gt-abc"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Show() with output %q error = %v, want ErrNotFound", output, err)
+			}
+		})
+	}
+}
+
+func TestShow_MalformedOutput(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "show gt-abc --json", Outputs: []string{`Error: something broke`}})
+	_, err := New(t.TempDir()).Show("gt-abc")
+	if err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "parsing bd show output") {
+		t.Errorf("Show() error = %v, want a parse error", err)
+	}
+}
+
+func TestListDefaultMaxResults(t *testing.T) {
+	orig, origWarnf := DefaultMaxResults, Warnf
+	t.Cleanup(func() { DefaultMaxResults, Warnf = orig, origWarnf })
+	DefaultMaxResults = 2
+	var warnings []string
+	Warnf = func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+
+	fake := installFakeBd(t, fakeBdRule{Match: "list", Outputs: []string{`[{"id":"gt-1"},{"id":"gt-2"}]`}})
+	b := New(t.TempDir())
+
+	issues, err := b.List(ListOptions{Priority: -1})
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("List() = %v, want 2 issues", issues)
+	}
+	if calls := fake.callsMatching(t, "list"); len(calls) != 1 || !strings.HasSuffix(calls[0], "--limit=2") {
+		t.Errorf("list calls = %v, want --limit=2", calls)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "truncated") {
+		t.Errorf("warnings = %v, want one truncation warning", warnings)
+	}
+
+	// An explicit limit is the caller's choice and is not warned about.
+	warnings = nil
+	if _, err := b.List(ListOptions{Priority: -1, Limit: 2}); err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("explicit limit warned: %v", warnings)
+	}
+}
+
+func TestListUnbounded(t *testing.T) {
+	origWarnf := Warnf
+	t.Cleanup(func() { Warnf = origWarnf })
+	Warnf = func(format string, args ...any) { t.Errorf("unexpected warning: "+format, args...) }
+
+	fake := installFakeBd(t, fakeBdRule{Match: "list", Outputs: []string{`[{"id":"gt-1"}]`}})
+
+	if _, err := New(t.TempDir()).List(ListOptions{Priority: -1, Limit: -1}); err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if calls := fake.callsMatching(t, "list"); len(calls) != 1 || !strings.HasSuffix(calls[0], "--limit=0") {
+		t.Errorf("list calls = %v, want --limit=0", calls)
+	}
+}
+
+func TestInProgress(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "--status=in_progress", Outputs: []string{`[{"id":"gt-1","status":"in_progress"}]`}})
+
+	issues, err := New(t.TempDir()).InProgress()
+	if err != nil {
+		t.Fatalf("InProgress() error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "gt-1" {
+		t.Errorf("InProgress() = %v, want [gt-1]", issues)
+	}
+	calls := fake.callsMatching(t, "list")
+	if len(calls) != 1 || !strings.Contains(calls[0], fmt.Sprintf("list --json --status=in_progress --limit=%d", DefaultMaxResults)) || strings.Contains(calls[0], "--priority") {
+		t.Errorf("list calls = %v, want one unfiltered in_progress list", calls)
+	}
+}
+
+func TestInProgressOlderThan(t *testing.T) {
+	old := time.Now().Add(-3 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339)
+	legacy := time.Now().Add(-5 * time.Hour).UTC().Format("2006-01-02T15:04:05")
+	installFakeBd(t, fakeBdRule{Match: "--status=in_progress", Outputs: []string{fmt.Sprintf(`[
+		{"id":"gt-old","updated_at":%q},
+		{"id":"gt-recent","updated_at":%q},
+		{"id":"gt-legacy","updated_at":%q},
+		{"id":"gt-garbled","updated_at":"last tuesday"},
+		{"id":"gt-missing"}
+	]`, old, recent, legacy)}})
+	origWarnf := Warnf
+	t.Cleanup(func() { Warnf = origWarnf })
+	var warnings []string
+	Warnf = func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+
+	issues, err := New(t.TempDir()).InProgressOlderThan(time.Hour)
+	if err != nil {
+		t.Fatalf("InProgressOlderThan() error: %v", err)
+	}
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	if want := []string{"gt-old", "gt-legacy"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("InProgressOlderThan() = %v, want %v", ids, want)
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "gt-garbled") || !strings.Contains(warnings[1], "gt-missing") {
+		t.Errorf("warnings = %v, want the two unparseable issues reported through Warnf", warnings)
+	}
+}
+
+func TestFindByTitle(t *testing.T) {
+	list := `[
+		{"id":"gt-1","title":"Fix auth"},
+		{"id":"gt-2","title":"fix AUTH token refresh"},
+		{"id":"gt-3","title":"Fix Auth","status":"closed"},
+		{"id":"gt-4","title":"Unrelated"}
+	]`
+
+	tests := []struct {
+		name  string
+		title string
+		exact bool
+		want  []string
+	}{
+		{"exact matches case-insensitively", "fix auth", true, []string{"gt-1", "gt-3"}},
+		{"substring", "Fix Auth", false, []string{"gt-1", "gt-2", "gt-3"}},
+		{"no match", "deploy", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := installFakeBd(t, fakeBdRule{Match: "list --json", Outputs: []string{list}})
+
+			issues, err := New(t.TempDir()).FindByTitle(tt.title, tt.exact)
+			if err != nil {
+				t.Fatalf("FindByTitle() error: %v", err)
+			}
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("FindByTitle(%q, %v) = %v, want %v", tt.title, tt.exact, got, tt.want)
+			}
+
+			calls := fake.callsMatching(t, "list")
+			if len(calls) != 1 || !strings.Contains(calls[0], "--status=all") || !strings.Contains(calls[0], "--title-contains="+tt.title) {
+				t.Errorf("list calls = %v, want --status=all --title-contains=%s", calls, tt.title)
+			}
+		})
+	}
+}
+
+func TestIssuesWithLabel(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "list --json", Outputs: []string{`[{"id":"gt-1","labels":["federate"]}]`}})
+
+	issues, err := New(t.TempDir()).IssuesWithLabel("federate")
+	if err != nil {
+		t.Fatalf("IssuesWithLabel() error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "gt-1" {
+		t.Errorf("IssuesWithLabel() = %v, want gt-1", issues)
+	}
+	calls := fake.callsMatching(t, "list")
+	if len(calls) != 1 || !strings.Contains(calls[0], "--label=federate") {
+		t.Errorf("list calls = %v, want --label=federate", calls)
+	}
+}
+
+func TestEnsureDependency(t *testing.T) {
+	show := fakeBdRule{Match: "show gt-1 --json", Outputs: []string{`[{"id":"gt-1","dependencies":[{"id":"gt-2","dependency_type":"blocks"}]}]`}}
+
+	t.Run("present is a no-op", func(t *testing.T) {
+		fake := installFakeBd(t, show)
+		if err := New(t.TempDir()).EnsureDependency("gt-1", "gt-2"); err != nil {
+			t.Fatalf("EnsureDependency() error: %v", err)
+		}
+		if calls := fake.callsMatching(t, "dep "); len(calls) != 0 {
+			t.Errorf("existing dependency should not be re-added, got %v", calls)
+		}
+	})
+
+	t.Run("absent is added", func(t *testing.T) {
+		fake := installFakeBd(t, show)
+		if err := New(t.TempDir()).EnsureDependency("gt-1", "gt-3"); err != nil {
+			t.Fatalf("EnsureDependency() error: %v", err)
+		}
+		calls := fake.callsMatching(t, "dep ")
+		if len(calls) != 1 || !strings.Contains(calls[0], "dep add gt-1 gt-3") {
+			t.Errorf("dep calls = %v, want one dep add gt-1 gt-3", calls)
+		}
+	})
+}
+
+func TestEnsureNoDependency(t *testing.T) {
+	show := fakeBdRule{Match: "show gt-1 --json", Outputs: []string{`[{"id":"gt-1","dependencies":[{"id":"external:bd:bd-9"}]}]`}}
+
+	t.Run("present is removed", func(t *testing.T) {
+		fake := installFakeBd(t, show)
+		if err := New(t.TempDir()).EnsureNoDependency("gt-1", "bd-9"); err != nil {
+			t.Fatalf("EnsureNoDependency() error: %v", err)
+		}
+		calls := fake.callsMatching(t, "dep ")
+		if len(calls) != 1 || !strings.Contains(calls[0], "dep remove gt-1 bd-9") {
+			t.Errorf("dep calls = %v, want one dep remove gt-1 bd-9", calls)
+		}
+	})
+
+	t.Run("absent is a no-op", func(t *testing.T) {
+		fake := installFakeBd(t, show)
+		if err := New(t.TempDir()).EnsureNoDependency("gt-1", "gt-3"); err != nil {
+			t.Fatalf("EnsureNoDependency() error: %v", err)
+		}
+		if calls := fake.callsMatching(t, "dep "); len(calls) != 0 {
+			t.Errorf("missing dependency should not be removed, got %v", calls)
+		}
+	})
+}
+
+func TestEnsureDependencyIgnoresParentChild(t *testing.T) {
+	show := fakeBdRule{Match: "show gt-1 --json", Outputs: []string{`[{"id":"gt-1","dependencies":[{"id":"gt-epic","dependency_type":"parent-child"}]}]`}}
+
+	t.Run("blocker is still added", func(t *testing.T) {
+		fake := installFakeBd(t, show)
+		if err := New(t.TempDir()).EnsureDependency("gt-1", "gt-epic"); err != nil {
+			t.Fatalf("EnsureDependency() error: %v", err)
+		}
+		calls := fake.callsMatching(t, "dep ")
+		if len(calls) != 1 || !strings.Contains(calls[0], "dep add gt-1 gt-epic") {
+			t.Errorf("dep calls = %v, want one dep add gt-1 gt-epic", calls)
+		}
+	})
+
+	t.Run("parent link is kept", func(t *testing.T) {
+		fake := installFakeBd(t, show)
+		if err := New(t.TempDir()).EnsureNoDependency("gt-1", "gt-epic"); err != nil {
+			t.Fatalf("EnsureNoDependency() error: %v", err)
+		}
+		if calls := fake.callsMatching(t, "dep "); len(calls) != 0 {
+			t.Errorf("parent-child link should not be removed, got %v", calls)
+		}
+	})
+}
+
+func TestMarkBlockedExternal(t *testing.T) {
+	fake := installFakeBd(t)
+	b := New(t.TempDir())
+
+	if err := b.MarkBlockedExternal("gt-1", "waiting on vendor patch"); err != nil {
+		t.Fatalf("MarkBlockedExternal() error: %v", err)
+	}
+	if err := b.ClearBlockedExternal("gt-1"); err != nil {
+		t.Fatalf("ClearBlockedExternal() error: %v", err)
+	}
+
+	calls := fake.callsMatching(t, "update")
+	if len(calls) != 2 {
+		t.Fatalf("update calls = %v, want mark then clear", calls)
+	}
+	if !strings.Contains(calls[0], "--add-label=blocked-external") || !strings.Contains(calls[0], "--append-notes=Blocked externally: waiting on vendor patch") {
+		t.Errorf("mark = %q, want the label and a reason note", calls[0])
+	}
+	if !strings.Contains(calls[1], "--remove-label=blocked-external") {
+		t.Errorf("clear = %q, want the label removed", calls[1])
+	}
+
+	if err := b.MarkBlockedExternal("gt-1", "  "); err == nil {
+		t.Error("MarkBlockedExternal() without a reason should fail")
+	}
+}
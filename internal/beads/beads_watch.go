@@ -0,0 +1,116 @@
+// Package beads provides polling watches over assigned work.
+package beads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WatchAssignee polls ListByAssignee every interval and sends the assignee's
+// issues on the returned channel whenever the set changes, compared by ID,
+// status and updated_at. The current set is sent first.
+//
+// The first poll runs before WatchAssignee returns, so a bd failure is
+// reported as an error; later poll failures are skipped and retried on the
+// next tick. The channel is closed when ctx is cancelled.
+func (b *Beads) WatchAssignee(ctx context.Context, assignee string, interval time.Duration) (<-chan []*Issue, error) {
+	if interval <= 0 {
+		return nil, errors.New("watch interval must be positive")
+	}
+	issues, err := b.ListByAssignee(assignee)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan []*Issue)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		last := watchFingerprint(issues)
+		pending := nonNilIssues(issues)
+		for {
+			// Only offer the channel while there is a change to deliver.
+			var out chan []*Issue
+			if pending != nil {
+				out = ch
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- pending:
+				pending = nil
+			case <-ticker.C:
+				issues, err := b.ListByAssignee(assignee)
+				if err != nil {
+					continue
+				}
+				if fp := watchFingerprint(issues); fp != last {
+					last = fp
+					pending = nonNilIssues(issues)
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// WaitForStatus polls Show every interval until issue id has status target.
+// It checks immediately, then on each tick. It stops with ErrNotFound if
+// the issue is deleted; other Show failures are retried on the next tick.
+// If ctx ends first, the error wraps ctx.Err() and names the last status
+// seen.
+func (b *Beads) WaitForStatus(ctx context.Context, id, target string, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("wait interval must be positive")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := "unknown"
+	for {
+		issue, err := b.Show(id)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			return fmt.Errorf("waiting for %s to be %s: %w", id, target, err)
+		case err == nil && issue.Status == target:
+			return nil
+		case err == nil:
+			last = issue.Status
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %s to be %s (last status %s): %w", id, target, last, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchFingerprint summarizes the fields WatchAssignee compares, independent
+// of the order bd lists issues in.
+func watchFingerprint(issues []*Issue) string {
+	keys := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		keys = append(keys, issue.ID+"\x00"+issue.Status+"\x00"+issue.UpdatedAt)
+	}
+	slices.Sort(keys)
+	return strings.Join(keys, "\n")
+}
+
+// nonNilIssues returns issues, or an empty slice if it is nil, so that an
+// empty set can be sent and distinguished from "nothing to send".
+func nonNilIssues(issues []*Issue) []*Issue {
+	if issues == nil {
+		return []*Issue{}
+	}
+	return issues
+}
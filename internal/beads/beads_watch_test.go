@@ -0,0 +1,115 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchAssignee(t *testing.T) {
+	installFakeBd(t,
+		fakeBdRule{Match: "list", Outputs: []string{
+			`[{"id":"gt-1","status":"hooked","updated_at":"2026-01-01T00:00:00Z"}]`,
+			`[{"id":"gt-1","status":"hooked","updated_at":"2026-01-01T00:00:00Z"}]`,
+			`[{"id":"gt-1","status":"in_progress","updated_at":"2026-01-01T00:05:00Z"},{"id":"gt-2","status":"hooked","updated_at":"2026-01-01T00:05:00Z"}]`,
+			`[{"id":"gt-2","status":"hooked","updated_at":"2026-01-01T00:05:00Z"},{"id":"gt-1","status":"in_progress","updated_at":"2026-01-01T00:05:00Z"}]`,
+		}},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := New(t.TempDir()).WatchAssignee(ctx, "gastown/polecats/Toast", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchAssignee() error: %v", err)
+	}
+
+	receive := func() []*Issue {
+		t.Helper()
+		select {
+		case issues, ok := <-ch:
+			if !ok {
+				t.Fatal("watch channel closed early")
+			}
+			return issues
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watch update")
+			return nil
+		}
+	}
+
+	first := receive()
+	if len(first) != 1 || first[0].Status != "hooked" {
+		t.Fatalf("first update = %+v, want gt-1 hooked", first)
+	}
+	second := receive()
+	if len(second) != 2 {
+		t.Fatalf("second update = %+v, want gt-1 and gt-2", second)
+	}
+
+	// The last two polls differ only in order, so nothing more is sent.
+	select {
+	case issues := <-ch:
+		t.Fatalf("unexpected update for unchanged set: %+v", issues)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected channel to be closed after cancel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestWatchAssignee_InitialError(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "list", Stderr: "Error: database is locked", Exit: 1})
+
+	if _, err := New(t.TempDir()).WatchAssignee(context.Background(), "mayor", time.Second); err == nil {
+		t.Error("WatchAssignee() should report a failing first poll")
+	}
+}
+
+func TestWaitForStatus(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "show gt-1 ", Outputs: []string{
+		`[{"id":"gt-1","status":"in_progress"}]`,
+		`[{"id":"gt-1","status":"in_progress"}]`,
+		`[{"id":"gt-1","status":"in_review"}]`,
+	}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := New(t.TempDir()).WaitForStatus(ctx, "gt-1", "in_review", 10*time.Millisecond); err != nil {
+		t.Fatalf("WaitForStatus() error: %v", err)
+	}
+	if calls := fake.callsMatching(t, "show gt-1"); len(calls) != 3 {
+		t.Errorf("show calls = %d, want 3 polls", len(calls))
+	}
+}
+
+func TestWaitForStatusTimeout(t *testing.T) {
+	installFakeBd(t, fakeBdRule{Match: "show gt-1 ", Outputs: []string{`[{"id":"gt-1","status":"in_progress"}]`}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := New(t.TempDir()).WaitForStatus(ctx, "gt-1", "in_review", 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "last status in_progress") {
+		t.Errorf("WaitForStatus() error = %v, want a deadline naming the last status", err)
+	}
+}
+
+func TestWaitForStatusDeleted(t *testing.T) {
+	fake := installFakeBd(t, fakeBdRule{Match: "show gt-1 ", Outputs: []string{`[]`}})
+
+	err := New(t.TempDir()).WaitForStatus(context.Background(), "gt-1", "in_review", 10*time.Millisecond)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("WaitForStatus() error = %v, want ErrNotFound", err)
+	}
+	if calls := fake.callsMatching(t, "show gt-1"); len(calls) != 1 {
+		t.Errorf("show calls = %d, want to stop after the first", len(calls))
+	}
+}
@@ -120,6 +120,41 @@ func TestAgentFieldsModeOmittedWhenEmpty(t *testing.T) {
 	}
 }
 
+// --- AgentFields validation ---
+
+func TestAgentFieldsValidate(t *testing.T) {
+	valid := AgentFields{RoleType: "polecat", AgentState: "working", CleanupStatus: "clean"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() on valid fields: %v", err)
+	}
+	if err := (&AgentFields{}).Validate(); err != nil {
+		t.Errorf("Validate() on empty fields: %v", err)
+	}
+	if err := (*AgentFields)(nil).Validate(); err != nil {
+		t.Errorf("Validate() on nil fields: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		edit  func(*AgentFields)
+		field string
+	}{
+		{"role type", func(f *AgentFields) { f.RoleType = "polcat" }, "role_type"},
+		{"agent state", func(f *AgentFields) { f.AgentState = "workign" }, "agent_state"},
+		{"cleanup status", func(f *AgentFields) { f.CleanupStatus = "dirty" }, "cleanup_status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := valid
+			tt.edit(&fields)
+			err := fields.Validate()
+			if err == nil || !strings.Contains(err.Error(), "invalid "+tt.field) {
+				t.Errorf("Validate() = %v, want invalid %s", err, tt.field)
+			}
+		})
+	}
+}
+
 // --- Convoy fields in AttachmentFields (gt-7b6wf fix) ---
 
 func TestParseAttachmentFieldsConvoy(t *testing.T) {
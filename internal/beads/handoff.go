@@ -18,6 +18,11 @@ const StatusPinned = "pinned"
 // This is distinct from pinned - hooked beads are active work, not permanent records.
 const StatusHooked = "hooked"
 
+// StatusInReview is the status for work that is done but awaiting review.
+// The assignee keeps ownership until the review closes or releases it.
+// bd treats it as a custom status (bd config set status.custom "in_review").
+const StatusInReview = "in_review"
+
 // HandoffBeadTitle returns the well-known title for a role's handoff bead.
 func HandoffBeadTitle(role string) string {
 	return role + " Handoff"
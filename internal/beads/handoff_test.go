@@ -34,6 +34,9 @@ func TestStatusConstants(t *testing.T) {
 	if StatusHooked != "hooked" {
 		t.Errorf("StatusHooked = %q, want %q", StatusHooked, "hooked")
 	}
+	if StatusInReview != "in_review" {
+		t.Errorf("StatusInReview = %q, want %q", StatusInReview, "in_review")
+	}
 }
 
 func TestCurrentTimestamp(t *testing.T) {
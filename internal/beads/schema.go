@@ -0,0 +1,27 @@
+package beads
+
+import "github.com/steveyegge/gastown/internal/jsonschema"
+
+// IssueStatuses are the issue statuses Gas Town uses: bd's built-in
+// statuses plus the in_review custom status.
+var IssueStatuses = []string{
+	"open", "in_progress", "blocked", "deferred", "closed",
+	StatusPinned, StatusHooked, StatusInReview,
+}
+
+// IssueSchema returns a JSON Schema describing an Issue as parsed from bd's
+// JSON output. Properties are generated from the Issue struct's json tags.
+func IssueSchema() *jsonschema.Schema {
+	enum := func(values []string) []any {
+		out := make([]any, len(values))
+		for i, v := range values {
+			out[i] = v
+		}
+		return out
+	}
+	return jsonschema.FromStruct("Issue", Issue{}, map[string][]any{
+		"status":     enum(IssueStatuses),
+		"issue_type": enum(IssueTypes),
+		"priority":   {0, 1, 2, 3, 4},
+	})
+}
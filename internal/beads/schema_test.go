@@ -0,0 +1,41 @@
+package beads
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/jsonschema"
+)
+
+func TestIssueSchema_IncludesAllFields(t *testing.T) {
+	s := IssueSchema()
+
+	typ := reflect.TypeOf(Issue{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := jsonschema.PropertyName(typ.Field(i))
+		if name == "" {
+			continue
+		}
+		if _, ok := s.Properties[name]; !ok {
+			t.Errorf("schema missing property %q for field %s", name, typ.Field(i).Name)
+		}
+	}
+
+	if got := s.Properties["dependencies"].Items.Properties; got["dependency_type"] == nil {
+		t.Error("nested IssueDep properties should be described")
+	}
+}
+
+func TestIssueSchema_Enums(t *testing.T) {
+	s := IssueSchema()
+
+	if got := len(s.Properties["status"].Enum); got != len(IssueStatuses) {
+		t.Errorf("status enum has %d values, want %d", got, len(IssueStatuses))
+	}
+	if got := len(s.Properties["issue_type"].Enum); got != len(IssueTypes) {
+		t.Errorf("issue_type enum has %d values, want %d", got, len(IssueTypes))
+	}
+	if got := len(s.Properties["priority"].Enum); got != 5 {
+		t.Errorf("priority enum has %d values, want 5", got)
+	}
+}
@@ -0,0 +1,109 @@
+package beads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeBdRule maps bd invocations to canned responses. A rule matches when
+// the space-joined argv contains Match (plain substring, no glob characters).
+// When Outputs has several entries, successive matching calls step through
+// them and the last entry repeats.
+type fakeBdRule struct {
+	Match   string
+	Outputs []string
+	Stderr  string
+	Exit    int
+}
+
+// fakeBd is a fake bd binary installed on PATH by installFakeBd.
+type fakeBd struct {
+	dir     string
+	argsLog string
+}
+
+// installFakeBd places a fake bd script first on PATH. Every invocation is
+// appended to an args log, and the first matching rule supplies stdout,
+// stderr and the exit code. Unmatched invocations print "[]" and succeed.
+func installFakeBd(t *testing.T, rules ...fakeBdRule) *fakeBd {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bd script requires a POSIX shell")
+	}
+
+	binDir := t.TempDir()
+	f := &fakeBd{dir: binDir, argsLog: filepath.Join(binDir, "bd_args.log")}
+
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&script, "echo \"$*\" >> %s\n", shellQuote(f.argsLog))
+	script.WriteString("case \"$*\" in\n")
+	for i, rule := range rules {
+		outputs := rule.Outputs
+		if len(outputs) == 0 {
+			outputs = []string{""}
+		}
+		for k, out := range outputs {
+			f.writeFile(t, fmt.Sprintf("out%d_%d", i, k), out)
+		}
+		f.writeFile(t, fmt.Sprintf("err%d", i), rule.Stderr)
+
+		countFile := shellQuote(filepath.Join(binDir, fmt.Sprintf("count%d", i)))
+		fmt.Fprintf(&script, "  *%s*)\n", shellQuote(rule.Match))
+		fmt.Fprintf(&script, "    n=$(cat %s 2>/dev/null || echo 0)\n", countFile)
+		fmt.Fprintf(&script, "    echo $((n+1)) > %s\n", countFile)
+		fmt.Fprintf(&script, "    [ \"$n\" -gt %d ] && n=%d\n", len(outputs)-1, len(outputs)-1)
+		fmt.Fprintf(&script, "    cat %s\"$n\"\n", shellQuote(filepath.Join(binDir, fmt.Sprintf("out%d_", i))))
+		fmt.Fprintf(&script, "    cat %s >&2\n", shellQuote(filepath.Join(binDir, fmt.Sprintf("err%d", i))))
+		fmt.Fprintf(&script, "    exit %d ;;\n", rule.Exit)
+	}
+	script.WriteString("  *) echo '[]' ;;\n")
+	script.WriteString("esac\n")
+
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script.String()), 0o755); err != nil {
+		t.Fatalf("write fake bd: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return f
+}
+
+func (f *fakeBd) writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(f.dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("write fake bd fixture: %v", err)
+	}
+}
+
+// calls returns the logged argv of every bd invocation, one line per call.
+func (f *fakeBd) calls(t *testing.T) []string {
+	t.Helper()
+	data, err := os.ReadFile(f.argsLog)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		t.Fatalf("read bd args log: %v", err)
+	}
+	return strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+}
+
+// callsMatching returns the logged invocations containing substr.
+func (f *fakeBd) callsMatching(t *testing.T, substr string) []string {
+	t.Helper()
+	var matched []string
+	for _, call := range f.calls(t) {
+		if strings.Contains(call, substr) {
+			matched = append(matched, call)
+		}
+	}
+	return matched
+}
+
+// shellQuote wraps s in single quotes for safe use in a POSIX shell script.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tui/convoy"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -1106,8 +1107,7 @@ func findConvoyWorktrees(tracked []trackedIssueInfo) []convoyWorktreeInfo {
 			}
 
 			// Check if this polecat's assignee matches any tracked issue assignee
-			// Assignees have format: rig/polecats/name
-			polecatAssignee := fmt.Sprintf("%s/polecats/%s", rigName, entry.Name())
+			polecatAssignee := session.PolecatAssignee(rigName, entry.Name())
 			if assignees[polecatAssignee] {
 				worktrees = append(worktrees, convoyWorktreeInfo{
 					rigName:     rigName,
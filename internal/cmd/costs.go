@@ -1116,11 +1116,11 @@ func detectCurrentTmuxSession() string {
 		return ""
 	}
 
-	session := strings.TrimSpace(string(output))
+	name := strings.TrimSpace(string(output))
 	// Only return if it looks like a Gas Town session
 	// Accept both gt- (rig sessions) and hq- (town-level sessions like hq-mayor)
-	if strings.HasPrefix(session, constants.SessionPrefix) || strings.HasPrefix(session, constants.HQSessionPrefix) {
-		return session
+	if strings.HasPrefix(name, constants.SessionPrefix) || strings.HasPrefix(name, session.HQPrefix()) {
+		return name
 	}
 	return ""
 }
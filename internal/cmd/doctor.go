@@ -53,6 +53,10 @@ Cleanup checks (fixable):
   - wisp-gc                  Detect and clean abandoned wisps (>1h)
   - stale-beads-redirect     Detect stale files in .beads directories with redirects
 
+Beads redirect checks:
+  - beads-redirect-target    Verify redirect targets exist and have beads (fixable)
+  - beads-redirect-audit     Detect circular, chained, dangling or out-of-town redirects
+
 Clone divergence checks:
   - persistent-role-branches Detect crew/witness/refinery not on main
   - clone-divergence         Detect clones significantly behind origin/main
@@ -167,6 +171,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewCheckMisclassifiedWisps())
 	d.Register(doctor.NewStaleBeadsRedirectCheck())
 	d.Register(doctor.NewBeadsRedirectTargetCheck())
+	d.Register(doctor.NewBeadsRedirectAuditCheck())
 	d.Register(doctor.NewBranchCheck())
 	d.Register(doctor.NewCloneDivergenceCheck())
 	d.Register(doctor.NewDefaultBranchAllRigsCheck())
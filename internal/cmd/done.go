@@ -1398,7 +1398,7 @@ func selfKillSession(townRoot string, roleInfo RoleInfo) error {
 	}
 
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
-	agentID := fmt.Sprintf("%s/polecats/%s", rigName, polecatName)
+	agentID := session.PolecatAssignee(rigName, polecatName)
 
 	// Log to townlog (human-readable audit log)
 	if townRoot != "" {
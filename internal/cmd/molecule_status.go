@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -524,7 +525,7 @@ func buildAgentIdentity(ctx RoleContext) string {
 	case RoleRefinery:
 		return ctx.Rig + "/refinery"
 	case RolePolecat:
-		return ctx.Rig + "/polecats/" + ctx.Polecat
+		return session.PolecatAssignee(ctx.Rig, ctx.Polecat)
 	case RoleCrew:
 		return ctx.Rig + "/crew/" + ctx.Polecat
 	default:
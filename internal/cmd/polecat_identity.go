@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 )
@@ -736,7 +737,7 @@ func buildCVSummary(rigPath, rigName, polecatName, identityBeadID, clonePath str
 	cv.Sessions = countPolecatSessions(rigPath, polecatName)
 
 	// Query completed issues assigned to this polecat
-	assignee := fmt.Sprintf("%s/polecats/%s", rigName, polecatName)
+	assignee := session.PolecatAssignee(rigName, polecatName)
 	completedIssues, err := queryAssignedIssues(beadsQueryPath, assignee, "closed")
 	if err == nil {
 		cv.IssuesCompleted = len(completedIssues)
@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -36,9 +37,10 @@ type SpawnedPolecatInfo struct {
 	agent   string
 }
 
-// AgentID returns the agent identifier (e.g., "gastown/polecats/Toast")
+// AgentID returns the agent identifier (e.g., "gastown/polecats/Toast"),
+// in the town's session.AssigneeFormat since it is hooked as the assignee.
 func (s *SpawnedPolecatInfo) AgentID() string {
-	return fmt.Sprintf("%s/polecats/%s", s.RigName, s.PolecatName)
+	return session.PolecatAssignee(s.RigName, s.PolecatName)
 }
 
 // SessionStarted returns true if the tmux session has been started.
@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/lock"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/state"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -638,7 +639,7 @@ func getAgentIdentity(ctx RoleContext) string {
 	case RoleCrew:
 		return fmt.Sprintf("%s/crew/%s", ctx.Rig, ctx.Polecat)
 	case RolePolecat:
-		return fmt.Sprintf("%s/polecats/%s", ctx.Rig, ctx.Polecat)
+		return session.PolecatAssignee(ctx.Rig, ctx.Polecat)
 	case RoleMayor:
 		return "mayor"
 	case RoleDeacon:
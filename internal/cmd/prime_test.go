@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/checkpoint"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/session"
 )
 
 func writeTestRoutes(t *testing.T, townRoot string, routes []beads.Route) {
@@ -104,6 +105,24 @@ func TestGetAgentBeadID_UsesRigPrefix(t *testing.T) {
 	}
 }
 
+func TestPolecatIdentity_FollowsAssigneeFormat(t *testing.T) {
+	prev := session.AssigneeFormat
+	session.AssigneeFormat = session.AssigneeCanonical
+	t.Cleanup(func() { session.AssigneeFormat = prev })
+
+	ctx := RoleContext{Role: RolePolecat, Rig: "gastown", Polecat: "Toast"}
+	if got := getAgentIdentity(ctx); got != "gastown/Toast" {
+		t.Errorf("getAgentIdentity() = %q, want %q", got, "gastown/Toast")
+	}
+	if got := buildAgentIdentity(ctx); got != "gastown/Toast" {
+		t.Errorf("buildAgentIdentity() = %q, want %q", got, "gastown/Toast")
+	}
+	info := RoleInfo{Role: RolePolecat, Rig: "gastown", Polecat: "Toast"}
+	if got := info.ActorString(); got != "gastown/Toast" {
+		t.Errorf("ActorString() = %q, want %q", got, "gastown/Toast")
+	}
+}
+
 func TestPrimeFlagCombinations(t *testing.T) {
 	// Find the gt binary - we need to test CLI flag validation
 	gtBin, err := exec.LookPath("gt")
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -380,7 +381,8 @@ func parseRoleString(s string) (Role, string, string) {
 //   - Simple roles: "mayor", "deacon"
 //   - Dog roles: "deacon-boot" (hyphenated, matching BD_ACTOR)
 //   - Rig-specific: "gastown/witness", "gastown/refinery"
-//   - Workers: "gastown/crew/max", "gastown/polecats/Toast"
+//   - Workers: "gastown/crew/max", "gastown/polecats/Toast" (polecats
+//     follow session.AssigneeFormat, like BD_ACTOR)
 func (info RoleInfo) ActorString() string {
 	switch info.Role {
 	case RoleMayor:
@@ -399,7 +401,7 @@ func (info RoleInfo) ActorString() string {
 		return "refinery"
 	case RolePolecat:
 		if info.Rig != "" && info.Polecat != "" {
-			return fmt.Sprintf("%s/polecats/%s", info.Rig, info.Polecat)
+			return session.PolecatAssignee(info.Rig, info.Polecat)
 		}
 		return "polecat"
 	case RoleCrew:
@@ -96,10 +96,20 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 	// Initialize CLI theme (dark/light mode support)
 	initCLITheme()
 
-	// Initialize session prefix registry from rigs.json.
-	// Best-effort: if town root not found, the default "gt" prefix is used.
+	// Initialize session naming from town settings (hq prefix, assignee
+	// format) and the prefix registry from rigs.json.
+	// Best-effort: if town root not found, the default "hq" and "gt" prefixes are used.
 	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
-		_ = session.InitRegistry(townRoot)
+		if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+			if err := session.ApplyTownSettings(settings); err != nil {
+				fmt.Fprintf(os.Stderr, "WARNING: town settings: %v\n", err)
+			}
+		}
+		if err := session.InitRegistry(townRoot); err == nil {
+			for _, c := range session.DetectPrefixCollisions() {
+				fmt.Fprintf(os.Stderr, "WARNING: rig prefix collision: %s\n", c)
+			}
+		}
 		if err := config.LoadAgentRegistry(config.DefaultAgentRegistryPath(townRoot)); err != nil {
 			fmt.Fprintf(os.Stderr, "WARNING: failed to load agent registry %s: %v\n",
 				config.DefaultAgentRegistryPath(townRoot), err)
@@ -50,7 +50,7 @@ func sessionToAgentID(sessionName string) string {
 		// Fallback for unparseable sessions
 		return sessionName
 	}
-	return identity.Address()
+	return session.AssigneeString(identity)
 }
 
 // resolveSelfTarget determines agent identity, pane, and hook root for slinging to self.
@@ -74,7 +74,7 @@ func resolveSelfTarget() (agentID string, pane string, hookRoot string, err erro
 	case RoleRefinery:
 		agentID = fmt.Sprintf("%s/refinery", roleInfo.Rig)
 	case RolePolecat:
-		agentID = fmt.Sprintf("%s/polecats/%s", roleInfo.Rig, roleInfo.Polecat)
+		agentID = session.PolecatAssignee(roleInfo.Rig, roleInfo.Polecat)
 	case RoleCrew:
 		agentID = fmt.Sprintf("%s/crew/%s", roleInfo.Rig, roleInfo.Polecat)
 	default:
@@ -824,7 +824,7 @@ func startPolecatsWithWork(townRoot, rigName string) ([]string, map[string]error
 		polecatPath := filepath.Join(polecatsDir, polecatName)
 
 		// Check if this polecat has a pinned bead (work attached)
-		agentID := fmt.Sprintf("%s/polecats/%s", rigName, polecatName)
+		agentID := session.PolecatAssignee(rigName, polecatName)
 		b := beads.New(polecatPath)
 		pinnedBeads, err := b.List(beads.ListOptions{
 			Status:   beads.StatusPinned,
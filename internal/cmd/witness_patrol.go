@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+var (
+	witnessPatrolJSON   bool
+	witnessPatrolFormat string
+	witnessPatrolDryRun bool
+)
+
+var witnessPatrolCmd = &cobra.Command{
+	Use:   "patrol <rig>",
+	Short: "Run a zombie patrol and print receipts",
+	Long: `Run one zombie-detection sweep over a rig's polecats and print a
+receipt for each zombie found.
+
+The sweep is the same one the Witness runs on patrol, and it is
+DESTRUCTIVE: zombies with clean git state are nuked (session killed,
+worktree removed), dirty ones are escalated to the Mayor, and abandoned
+hooked beads are reset for re-dispatch. Use --dry-run to only report
+what the sweep would do.
+
+Exits 1 if any receipt has a stale or confused verdict (work that needs
+attention, or a zombie the sweep failed to handle), so the command can
+gate scripts.
+
+Receipts print as a table by default; --format json prints an array and
+--format jsonl one receipt per line. --json is short for --format json.
+
+Examples:
+  gt witness patrol gastown --dry-run
+  gt witness patrol gastown
+  gt witness patrol gastown --format jsonl`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWitnessPatrol,
+}
+
+func init() {
+	witnessPatrolCmd.Flags().BoolVar(&witnessPatrolJSON, "json", false, "Output receipts as JSON (same as --format json)")
+	witnessPatrolCmd.Flags().StringVar(&witnessPatrolFormat, "format", witness.ReceiptFormatTable, "Receipt format: table, json, or jsonl")
+	witnessPatrolCmd.MarkFlagsMutuallyExclusive("json", "format")
+	witnessPatrolCmd.Flags().BoolVar(&witnessPatrolDryRun, "dry-run", false, "Report zombies without nuking, escalating, or resetting anything")
+
+	witnessCmd.AddCommand(witnessPatrolCmd)
+}
+
+func runWitnessPatrol(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	format := witnessPatrolFormat
+	if witnessPatrolJSON {
+		format = witness.ReceiptFormatJSON
+	}
+	switch format {
+	case witness.ReceiptFormatTable, witness.ReceiptFormatJSON, witness.ReceiptFormatJSONL:
+	default:
+		return fmt.Errorf("invalid --format %q: must be one of %s, %s, %s",
+			format, witness.ReceiptFormatTable, witness.ReceiptFormatJSON, witness.ReceiptFormatJSONL)
+	}
+
+	townRoot, _, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	var result *witness.DetectZombiePolecatsResult
+	if witnessPatrolDryRun {
+		result = witness.ScanZombiePolecats(townRoot, rigName)
+	} else {
+		result = witness.DetectZombiePolecats(townRoot, rigName, mail.NewRouter(townRoot))
+	}
+	receipts := witness.BuildPatrolReceipts(rigName, result)
+
+	if format != witness.ReceiptFormatTable {
+		if err := witness.RenderReceipts(receipts, format, os.Stdout); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("Checked %d polecat(s) in %s\n", result.Checked, style.Bold.Render(rigName))
+		for _, e := range result.Errors {
+			style.PrintWarning("%v", e)
+		}
+		if len(receipts) == 0 {
+			fmt.Printf("%s No zombies found\n", style.Bold.Render("✓"))
+		} else {
+			fmt.Println()
+			if err := witness.RenderReceipts(receipts, witness.ReceiptFormatTable, os.Stdout); err != nil {
+				return err
+			}
+		}
+	}
+
+	if code := witnessPatrolExitCode(receipts); code != 0 {
+		return NewSilentExit(code)
+	}
+	return nil
+}
+
+// witnessPatrolExitCode returns 1 if any receipt's verdict calls for action
+// (stale or confused), 0 otherwise. Orphans are cleaned up by the patrol
+// itself and do not fail the command.
+func witnessPatrolExitCode(receipts []witness.PatrolReceipt) int {
+	for _, r := range receipts {
+		switch r.Verdict {
+		case witness.PatrolVerdictStale, witness.PatrolVerdictConfused:
+			return 1
+		}
+	}
+	return 0
+}
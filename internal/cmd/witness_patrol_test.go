@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+func TestWitnessPatrolExitCode(t *testing.T) {
+	receipt := func(v witness.PatrolVerdict) witness.PatrolReceipt {
+		return witness.PatrolReceipt{Rig: "gastown", Polecat: "Toast", Verdict: v}
+	}
+
+	tests := []struct {
+		name     string
+		receipts []witness.PatrolReceipt
+		want     int
+	}{
+		{"no receipts", nil, 0},
+		{"orphans only", []witness.PatrolReceipt{receipt(witness.PatrolVerdictOrphan), receipt(witness.PatrolVerdictOrphan)}, 0},
+		{"stale", []witness.PatrolReceipt{receipt(witness.PatrolVerdictStale)}, 1},
+		{"confused", []witness.PatrolReceipt{receipt(witness.PatrolVerdictConfused)}, 1},
+		{"orphan then stale", []witness.PatrolReceipt{receipt(witness.PatrolVerdictOrphan), receipt(witness.PatrolVerdictStale)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := witnessPatrolExitCode(tt.receipts); got != tt.want {
+				t.Errorf("witnessPatrolExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWitnessPatrolJSONFlag(t *testing.T) {
+	flag := witnessPatrolCmd.Flags().Lookup("json")
+	if flag == nil {
+		t.Fatal("expected witness patrol to define --json flag")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("expected --json to default to false, got %q", flag.DefValue)
+	}
+}
+
+func TestWitnessPatrolFormatFlag(t *testing.T) {
+	flag := witnessPatrolCmd.Flags().Lookup("format")
+	if flag == nil {
+		t.Fatal("expected witness patrol to define --format flag")
+	}
+	if flag.DefValue != witness.ReceiptFormatTable {
+		t.Errorf("expected --format to default to %q, got %q", witness.ReceiptFormatTable, flag.DefValue)
+	}
+}
+
+func TestWitnessPatrolRejectsUnknownFormat(t *testing.T) {
+	orig := witnessPatrolFormat
+	t.Cleanup(func() { witnessPatrolFormat = orig })
+	witnessPatrolFormat = "yaml"
+
+	err := runWitnessPatrol(witnessPatrolCmd, []string{"gastown"})
+	if err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Errorf("runWitnessPatrol() error = %v, want an invalid --format error", err)
+	}
+}
+
+func TestWitnessPatrolDryRunFlag(t *testing.T) {
+	flag := witnessPatrolCmd.Flags().Lookup("dry-run")
+	if flag == nil {
+		t.Fatal("expected witness patrol to define --dry-run flag")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("expected --dry-run to default to false, got %q", flag.DefValue)
+	}
+}
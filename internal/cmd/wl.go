@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
-	"time"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
@@ -17,8 +20,76 @@ import (
 var (
 	wlJoinHandle      string
 	wlJoinDisplayName string
+	wlQuiet           bool
+
+	// wlCommons overrides the commons read commands clone; see
+	// wasteland.CommonsConfig.CommonsRef for the precedence.
+	wlCommons string
+	// wlCommonsFallbacks are mirrors read commands clone from, in order,
+	// when the upstream commons clone fails.
+	wlCommonsFallbacks []string
 )
 
+// addWLCommonsFlags registers --commons and --commons-fallback on a command
+// that reads the upstream commons. Both default to settings/wasteland.json.
+func addWLCommonsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&wlCommons, "commons", "", "Commons to read (default from settings/wasteland.json, else hop/wl-commons)")
+	cmd.Flags().StringArrayVar(&wlCommonsFallbacks, "commons-fallback", nil, "Mirror to read from if the upstream commons clone fails (repeatable, e.g. myorg/wl-commons)")
+}
+
+// wlProgressOut receives wl progress output. Var so tests can capture it.
+var wlProgressOut io.Writer = os.Stdout
+
+// wlQuietMode reports whether wl progress output is suppressed, via --quiet
+// or a true GASTOWN_QUIET environment variable.
+func wlQuietMode() bool {
+	if wlQuiet {
+		return true
+	}
+	quiet, _ := strconv.ParseBool(os.Getenv("GASTOWN_QUIET"))
+	return quiet
+}
+
+// progressf prints decorative progress output ("Cloning...") for wl
+// commands. It prints nothing in quiet mode; errors and results should be
+// printed directly instead.
+func progressf(format string, args ...any) {
+	if wlQuietMode() {
+		return
+	}
+	fmt.Fprintf(wlProgressOut, format, args...)
+}
+
+// startWLSpinner shows message as a live spinner when wl progress goes to a
+// terminal, and as a plain progress line otherwise. Stop the spinner before
+// printing anything else; it is a no-op in quiet mode.
+func startWLSpinner(message string) *style.Spinner {
+	if wlQuietMode() {
+		return style.NewSpinner(io.Discard, message)
+	}
+	spinner := style.NewSpinner(wlProgressOut, message)
+	if !spinner.Enabled() {
+		progressf("%s\n", message)
+		return spinner
+	}
+	spinner.Start()
+	return spinner
+}
+
+// wlTownRoot finds the town root for a wl command. Every failure wraps
+// workspace.ErrNotInWorkspace, including getcwd errors, so callers can
+// check it with errors.Is.
+func wlTownRoot() (string, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if errors.Is(err, workspace.ErrNotInWorkspace) {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", workspace.ErrNotInWorkspace, err)
+	}
+	return townRoot, nil
+}
+
 var wlCmd = &cobra.Command{
 	Use:     "wl",
 	GroupID: GroupWork,
@@ -65,6 +136,7 @@ Examples:
 func init() {
 	wlJoinCmd.Flags().StringVar(&wlJoinHandle, "handle", "", "Rig handle for registration (default: DoltHub org)")
 	wlJoinCmd.Flags().StringVar(&wlJoinDisplayName, "display-name", "", "Display name for the rig registry")
+	wlCmd.PersistentFlags().BoolVarP(&wlQuiet, "quiet", "q", false, "Suppress progress output (or set GASTOWN_QUIET=1)")
 
 	wlCmd.AddCommand(wlJoinCmd)
 	rootCmd.AddCommand(wlCmd)
@@ -91,9 +163,9 @@ func runWlJoin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Find town root
-	townRoot, err := workspace.FindFromCwdOrError()
+	townRoot, err := wlTownRoot()
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return err
 	}
 
 	// Check if already joined
@@ -114,7 +186,10 @@ func runWlJoin(cmd *cobra.Command, args []string) error {
 	// Determine town handle
 	handle := wlJoinHandle
 	if handle == "" {
-		handle = forkOrg // default to DoltHub org as handle
+		handle, err = wasteland.ResolveTownHandle(townRoot)
+		if err != nil {
+			return err
+		}
 	}
 
 	displayName := wlJoinDisplayName
@@ -134,39 +209,39 @@ func runWlJoin(cmd *cobra.Command, args []string) error {
 	localDir := wasteland.LocalCloneDir(townRoot, upstreamOrg, upstreamDB)
 
 	// Step 1: Fork the commons
-	fmt.Printf("Forking %s to %s/%s...\n", upstream, forkOrg, upstreamDB)
+	progressf("Forking %s to %s/%s...\n", upstream, forkOrg, upstreamDB)
 	if err := wasteland.ForkDoltHubRepo(upstreamOrg, upstreamDB, forkOrg, token); err != nil {
 		return fmt.Errorf("forking commons: %w", err)
 	}
-	fmt.Printf("  %s Fork created (or already exists)\n", style.Bold.Render("✓"))
+	progressf("  %s Fork created (or already exists)\n", style.Bold.Render("✓"))
 
 	// Step 2: Clone the fork locally
-	fmt.Printf("Cloning fork to %s...\n", localDir)
+	progressf("Cloning fork to %s...\n", localDir)
 	if err := wasteland.CloneLocally(forkOrg, upstreamDB, localDir); err != nil {
 		return fmt.Errorf("cloning fork: %w", err)
 	}
-	fmt.Printf("  %s Clone complete\n", style.Bold.Render("✓"))
+	progressf("  %s Clone complete\n", style.Bold.Render("✓"))
 
 	// Step 3: Add upstream remote
-	fmt.Printf("Adding upstream remote...\n")
+	progressf("Adding upstream remote...\n")
 	if err := wasteland.AddUpstreamRemote(localDir, upstreamOrg, upstreamDB); err != nil {
 		return fmt.Errorf("adding upstream remote: %w", err)
 	}
-	fmt.Printf("  %s Upstream remote configured\n", style.Bold.Render("✓"))
+	progressf("  %s Upstream remote configured\n", style.Bold.Render("✓"))
 
 	// Step 4: Register rig in the rigs table
-	fmt.Printf("Registering rig '%s' in the commons...\n", handle)
+	progressf("Registering rig '%s' in the commons...\n", handle)
 	if err := wasteland.RegisterRig(localDir, handle, forkOrg, displayName, ownerEmail, gtVersion); err != nil {
 		return fmt.Errorf("registering rig: %w", err)
 	}
-	fmt.Printf("  %s Rig registered\n", style.Bold.Render("✓"))
+	progressf("  %s Rig registered\n", style.Bold.Render("✓"))
 
 	// Step 5: Push to origin (the fork)
-	fmt.Printf("Pushing registration to fork...\n")
+	progressf("Pushing registration to fork...\n")
 	if err := wasteland.PushToOrigin(localDir); err != nil {
 		return fmt.Errorf("pushing to fork: %w", err)
 	}
-	fmt.Printf("  %s Registration pushed\n", style.Bold.Render("✓"))
+	progressf("  %s Registration pushed\n", style.Bold.Render("✓"))
 
 	// Step 6: Save wasteland config
 	cfg := &wasteland.Config{
@@ -175,7 +250,7 @@ func runWlJoin(cmd *cobra.Command, args []string) error {
 		ForkDB:     upstreamDB,
 		LocalDir:   localDir,
 		RigHandle: handle,
-		JoinedAt:   time.Now(),
+		JoinedAt:   wasteland.Clock(),
 	}
 	if err := wasteland.SaveConfig(townRoot, cfg); err != nil {
 		return fmt.Errorf("saving wasteland config: %w", err)
@@ -185,6 +260,6 @@ func runWlJoin(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Handle: %s\n", handle)
 	fmt.Printf("  Fork: %s/%s\n", forkOrg, upstreamDB)
 	fmt.Printf("  Local: %s\n", localDir)
-	fmt.Printf("\n  %s\n", style.Dim.Render("Next: gt wl browse  — browse the wanted board"))
+	progressf("\n  %s\n", style.Dim.Render("Next: gt wl browse  — browse the wanted board"))
 	return nil
 }
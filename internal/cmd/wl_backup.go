@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wasteland"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	wlBackupOut    string
+	wlBackupFormat string
+)
+
+var wlBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Snapshot the commons to a local dump",
+	Args:  cobra.NoArgs,
+	RunE:  runWLBackup,
+	Long: `Clone the upstream commons and write a timestamped dump of it for
+disaster recovery.
+
+Each backup writes a dump (commons-<timestamp>.sql, or a
+commons-<timestamp>/ directory of per-table CSV files) and a
+commons-<timestamp>.meta.json file recording the commit the dump was
+taken at and the commons schema version.
+
+EXAMPLES:
+  gt wl backup --out ~/backups/commons
+  gt wl backup --out ~/backups/commons --format csv`,
+}
+
+func init() {
+	wlBackupCmd.Flags().StringVar(&wlBackupOut, "out", "", "Directory to write the backup to (required)")
+	wlBackupCmd.Flags().StringVar(&wlBackupFormat, "format", wasteland.DumpFormatSQL, "Dump format: sql or csv")
+	_ = wlBackupCmd.MarkFlagRequired("out")
+
+	addWLCommonsFlags(wlBackupCmd)
+
+	wlCmd.AddCommand(wlBackupCmd)
+}
+
+func runWLBackup(cmd *cobra.Command, args []string) error {
+	if wlBackupFormat != wasteland.DumpFormatSQL && wlBackupFormat != wasteland.DumpFormatCSV {
+		return fmt.Errorf("invalid --format %q: must be sql or csv", wlBackupFormat)
+	}
+
+	// Backups work outside a town too, reading only the flags and defaults.
+	townRoot, _ := workspace.FindFromCwd()
+	dbDir, err := cloneUpstreamCommons(townRoot, false)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(filepath.Dir(dbDir))
+
+	if err := wasteland.DumpCommons(dbDir, wlBackupOut, wlBackupFormat); err != nil {
+		return fmt.Errorf("dumping commons: %w", err)
+	}
+
+	fmt.Printf("%s Backup written to %s\n", style.Bold.Render("✓"), wlBackupOut)
+	return nil
+}
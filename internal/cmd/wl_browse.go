@@ -1,24 +1,36 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/style"
-	"github.com/steveyegge/gastown/internal/workspace"
+	"github.com/steveyegge/gastown/internal/wasteland"
+	"golang.org/x/term"
 )
 
 var (
-	wlBrowseProject  string
+	wlBrowseProject  []string
 	wlBrowseStatus   string
 	wlBrowseType     string
 	wlBrowsePriority int
 	wlBrowseLimit    int
+	wlBrowseOffset   int
+	wlBrowseMaxTier  string
 	wlBrowseJSON     bool
+	wlBrowseSmart    bool
+	wlBrowseEffort   bool
+	wlBrowseWide     bool
+	wlBrowseNoColor  bool
 )
 
 var wlBrowseCmd = &cobra.Command{
@@ -34,94 +46,166 @@ temporary directory, queries it, then deletes the clone.
 EXAMPLES:
   gt wl browse                          # All open wanted items
   gt wl browse --project gastown        # Filter by project
+  gt wl browse --project gastown,beads  # Any of several projects
   gt wl browse --type bug               # Only bugs
   gt wl browse --status claimed         # Claimed items
   gt wl browse --priority 0             # Critical priority only
   gt wl browse --limit 5               # Show 5 items
-  gt wl browse --json                   # JSON output`,
+  gt wl browse --limit 5 --offset 5    # The next 5 items
+  gt wl browse --max-tier restricted    # Only items a restricted sandbox can take
+  gt wl browse --smart                  # Quick wins first within each priority
+  gt wl browse --total-effort           # Sum the effort points of listed items
+  gt wl browse --json                   # JSON output
+  gt wl browse --wide | less -S         # Untruncated titles
+  gt wl browse --commons-fallback myorg/wl-commons  # Read a mirror if upstream is down`,
 }
 
 func init() {
-	wlBrowseCmd.Flags().StringVar(&wlBrowseProject, "project", "", "Filter by project (e.g., gastown, beads, hop)")
+	wlBrowseCmd.Flags().StringSliceVar(&wlBrowseProject, "project", nil, "Filter by project, repeatable or comma-separated (e.g., gastown,beads)")
 	wlBrowseCmd.Flags().StringVar(&wlBrowseStatus, "status", "open", "Filter by status (open, claimed, in_review, completed, withdrawn)")
 	wlBrowseCmd.Flags().StringVar(&wlBrowseType, "type", "", "Filter by type (feature, bug, design, rfc, docs)")
 	wlBrowseCmd.Flags().IntVar(&wlBrowsePriority, "priority", -1, "Filter by priority (0=critical, 2=medium, 4=backlog)")
 	wlBrowseCmd.Flags().IntVar(&wlBrowseLimit, "limit", 50, "Maximum items to display")
+	wlBrowseCmd.Flags().IntVar(&wlBrowseOffset, "offset", 0, "Skip this many items (for paging with --limit)")
+	wlBrowseCmd.Flags().StringVar(&wlBrowseMaxTier, "max-tier", "", "Only show items whose sandbox_min_tier this tier satisfies ("+strings.Join(wasteland.ValidSandboxTiers(), ", ")+")")
 	wlBrowseCmd.Flags().BoolVar(&wlBrowseJSON, "json", false, "Output as JSON")
+	wlBrowseCmd.Flags().BoolVar(&wlBrowseSmart, "smart", false, "Order by priority, then smallest effort first")
+	wlBrowseCmd.Flags().BoolVar(&wlBrowseEffort, "total-effort", false, "Show the total effort points of the listed items (table output)")
+	wlBrowseCmd.Flags().BoolVar(&wlBrowseWide, "wide", false, "Show full titles instead of fitting the terminal width")
+	wlBrowseCmd.Flags().BoolVar(&wlBrowseNoColor, "no-color", false, "Disable styled output (also NO_COLOR)")
+	addWLCommonsFlags(wlBrowseCmd)
 
 	wlCmd.AddCommand(wlBrowseCmd)
 }
 
 func runWLBrowse(cmd *cobra.Command, args []string) error {
-	if _, err := workspace.FindFromCwdOrError(); err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	townRoot, err := wlTownRoot()
+	if err != nil {
+		return err
 	}
 
-	doltPath, err := exec.LookPath("dolt")
-	if err != nil {
-		return fmt.Errorf("dolt not found in PATH — install from https://docs.dolthub.com/introduction/installation")
+	if wlBrowseNoColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
 	}
 
-	tmpDir, err := os.MkdirTemp("", "wl-browse-*")
+	cloneDir, err := cloneUpstreamCommons(townRoot, false)
 	if err != nil {
-		return fmt.Errorf("creating temp directory: %w", err)
+		return err
 	}
-	defer os.RemoveAll(tmpDir)
+	defer os.RemoveAll(filepath.Dir(cloneDir))
+	progressf("%s Cloned successfully\n\n", style.Bold.Render("✓"))
 
-	commonsOrg := "hop"
-	commonsDB := "wl-commons"
-	cloneDir := filepath.Join(tmpDir, commonsDB)
-
-	remote := fmt.Sprintf("%s/%s", commonsOrg, commonsDB)
-	fmt.Printf("Cloning %s...\n", style.Bold.Render(remote))
-
-	cloneCmd := exec.Command(doltPath, "clone", remote, cloneDir)
-	cloneCmd.Stderr = os.Stderr
-	if err := cloneCmd.Run(); err != nil {
-		return fmt.Errorf("cloning %s: %w\nEnsure the database exists on DoltHub: https://www.dolthub.com/%s", remote, err, remote)
+	doltPath, err := exec.LookPath("dolt")
+	if err != nil {
+		return err
 	}
-	fmt.Printf("%s Cloned successfully\n\n", style.Bold.Render("✓"))
 
-	query := buildWLBrowseQuery()
+	query, err := buildWLBrowseQuery()
+	if err != nil {
+		return err
+	}
 
 	if wlBrowseJSON {
 		sqlCmd := exec.Command(doltPath, "sql", "-q", query, "-r", "json")
 		sqlCmd.Dir = cloneDir
-		sqlCmd.Stdout = os.Stdout
 		sqlCmd.Stderr = os.Stderr
-		return sqlCmd.Run()
+		output, err := sqlCmd.Output()
+		if err != nil {
+			return fmt.Errorf("running query: %w", err)
+		}
+		page, err := wlBrowseJSONPage(output, wlBrowseLimit)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(page)
+		return err
 	}
 
 	return renderWLBrowseTable(doltPath, cloneDir, query)
 }
 
-func buildWLBrowseQuery() string {
-	var conditions []string
+func buildWLBrowseQuery() (string, error) {
+	conditions, err := wlWantedConditions(wlBrowseStatus, wlBrowseProject, wlBrowseType, wlBrowsePriority, wlBrowseMaxTier)
+	if err != nil {
+		return "", err
+	}
 
-	if wlBrowseStatus != "" {
-		conditions = append(conditions, fmt.Sprintf("status = '%s'", wlEscapeSQL(wlBrowseStatus)))
+	query := "SELECT id, title, project, type, priority, posted_by, status, effort_level FROM wanted"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
-	if wlBrowseProject != "" {
-		conditions = append(conditions, fmt.Sprintf("project = '%s'", wlEscapeSQL(wlBrowseProject)))
+	query += " ORDER BY " + wlBrowseOrderBy()
+	// One extra row tells us whether there is another page.
+	query += fmt.Sprintf(" LIMIT %d", wlBrowseLimit+1)
+	if wlBrowseOffset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", wlBrowseOffset)
 	}
-	if wlBrowseType != "" {
-		conditions = append(conditions, fmt.Sprintf("type = '%s'", wlEscapeSQL(wlBrowseType)))
+
+	return query, nil
+}
+
+// wlBrowseOrderBy returns the ORDER BY expression for browse.
+func wlBrowseOrderBy() string {
+	return wlWantedOrderBy(wlBrowseSmart)
+}
+
+// wlWantedOrderBy returns the ORDER BY expression for ranking wanted items.
+// The default is priority then newest first; smart puts smaller efforts
+// first within each priority. FIELD is given the known effort levels largest
+// first and sorted descending, so unknown or missing efforts (FIELD = 0)
+// sort last.
+func wlWantedOrderBy(smart bool) string {
+	if !smart {
+		return "priority ASC, created_at DESC"
 	}
-	if wlBrowsePriority >= 0 {
-		conditions = append(conditions, fmt.Sprintf("priority = %d", wlBrowsePriority))
+	levels := make([]string, 0, len(wasteland.EffortLevels))
+	for _, level := range slices.Backward(wasteland.EffortLevels) {
+		levels = append(levels, "'"+wlEscapeSQL(level)+"'")
 	}
+	return fmt.Sprintf("priority ASC, FIELD(effort_level, %s) DESC, created_at DESC", strings.Join(levels, ", "))
+}
 
-	query := "SELECT id, title, project, type, priority, posted_by, status, effort_level FROM wanted"
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+// wlWantedConditions builds the WHERE conditions shared by browse and
+// claim --auto. Empty filters and a negative priority are not applied.
+func wlWantedConditions(status string, projects []string, itemType string, priority int, maxTier string) ([]string, error) {
+	var conditions []string
+
+	if status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = '%s'", wlEscapeSQL(status)))
+	}
+	if len(projects) > 0 {
+		cond, err := wasteland.BuildInClause("project", projects)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, cond)
+	}
+	if itemType != "" {
+		conditions = append(conditions, fmt.Sprintf("type = '%s'", wlEscapeSQL(itemType)))
+	}
+	if priority >= 0 {
+		conditions = append(conditions, fmt.Sprintf("priority = %d", priority))
+	}
+	if maxTier != "" {
+		tier, err := wasteland.ParseSandboxTier(maxTier)
+		if err != nil {
+			return nil, err
+		}
+		cond, err := wasteland.BuildInClause("sandbox_min_tier", tier.TiersUpTo())
+		if err != nil {
+			return nil, err
+		}
+		// Items without a minimum tier can run anywhere.
+		conditions = append(conditions, "(sandbox_min_tier IS NULL OR "+cond+")")
 	}
-	query += " ORDER BY priority ASC, created_at DESC"
-	query += fmt.Sprintf(" LIMIT %d", wlBrowseLimit)
 
-	return query
+	return conditions, nil
 }
 
+// wlEscapeSQL escapes backslashes and single quotes for a single-quoted SQL
+// string literal.
 func wlEscapeSQL(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
 	return strings.ReplaceAll(s, "'", "''")
 }
 
@@ -142,18 +226,14 @@ func renderWLBrowseTable(doltPath, cloneDir, query string) error {
 		return nil
 	}
 
-	tbl := style.NewTable(
-		style.Column{Name: "ID", Width: 12},
-		style.Column{Name: "TITLE", Width: 40},
-		style.Column{Name: "PROJECT", Width: 12},
-		style.Column{Name: "TYPE", Width: 10},
-		style.Column{Name: "PRI", Width: 4, Align: style.AlignRight},
-		style.Column{Name: "POSTED BY", Width: 16},
-		style.Column{Name: "STATUS", Width: 10},
-		style.Column{Name: "EFFORT", Width: 8},
-	)
+	items, hasMore := wlTrimPage(rows[1:], wlBrowseLimit)
 
-	for _, row := range rows[1:] {
+	titleWidth := wlBrowseTitleWidth(wlTerminalWidth())
+	if wlBrowseWide {
+		titleWidth = wlLongestTitle(items)
+	}
+	tbl := style.NewTable(wlBrowseColumns(titleWidth)...)
+	for _, row := range items {
 		if len(row) < 8 {
 			continue
 		}
@@ -161,35 +241,173 @@ func renderWLBrowseTable(doltPath, cloneDir, query string) error {
 		tbl.AddRow(row[0], row[1], row[2], row[3], pri, row[5], row[6], row[7])
 	}
 
-	fmt.Printf("Wanted items (%d):\n\n", len(rows)-1)
+	fmt.Printf("Wanted items (%d):\n\n", len(items))
 	fmt.Print(tbl.Render())
 
+	if hasMore {
+		next := wlBrowseOffset + len(items)
+		fmt.Printf("\n%s\n", style.Dim.Render(fmt.Sprintf("Showing items %d-%d; use --offset %d to see more.", wlBrowseOffset+1, next, next)))
+	}
+
+	if wlBrowseEffort {
+		total, unknown := wlTotalEffort(items)
+		fmt.Printf("\nTotal effort: %d points", total)
+		if unknown > 0 {
+			fmt.Printf(" (%d item(s) without a known effort)", unknown)
+		}
+		fmt.Println()
+	}
+
 	return nil
 }
 
+// wlBrowseColumns returns the browse table columns with the given TITLE
+// width; the other columns are fixed.
+func wlBrowseColumns(titleWidth int) []style.Column {
+	return []style.Column{
+		{Name: "ID", Width: 12},
+		{Name: "TITLE", Width: titleWidth},
+		{Name: "PROJECT", Width: 12},
+		{Name: "TYPE", Width: 10},
+		{Name: "PRI", Width: 4, Align: style.AlignRight},
+		{Name: "POSTED BY", Width: 16},
+		{Name: "STATUS", Width: 10},
+		{Name: "EFFORT", Width: 8},
+	}
+}
+
+// wlBrowseMinTitleWidth keeps titles readable on narrow terminals, where
+// the table overflows rather than shrinking TITLE further.
+const wlBrowseMinTitleWidth = 20
+
+// wlBrowseTitleWidth gives TITLE whatever a termWidth-wide terminal has
+// left after the table indent, the fixed columns and their separators.
+func wlBrowseTitleWidth(termWidth int) int {
+	used := 2 // table indent
+	columns := wlBrowseColumns(0)
+	for _, col := range columns {
+		used += col.Width
+	}
+	used += len(columns) - 1
+	return max(termWidth-used, wlBrowseMinTitleWidth)
+}
+
+// wlLongestTitle returns the width needed to show every title in rows
+// untruncated.
+func wlLongestTitle(rows [][]string) int {
+	width := len("TITLE")
+	for _, row := range rows {
+		if len(row) > 1 {
+			width = max(width, len(row[1]))
+		}
+	}
+	return width
+}
+
+// wlTerminalWidth returns stdout's terminal width, or 80 when stdout is not
+// a terminal.
+func wlTerminalWidth() int {
+	const defaultWidth = 80
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return defaultWidth
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil || width <= 0 {
+		return defaultWidth
+	}
+	return width
+}
+
+// wlTrimPage trims rows fetched with LIMIT limit+1 back to limit and
+// reports whether the extra row, and so another page, was there.
+func wlTrimPage[T any](rows []T, limit int) ([]T, bool) {
+	if limit < 0 || len(rows) <= limit {
+		return rows, false
+	}
+	return rows[:limit], true
+}
+
+// wlBrowsePageJSON is the --json output of gt wl browse: dolt's result
+// rows plus whether more items follow this page.
+type wlBrowsePageJSON struct {
+	Rows    []json.RawMessage `json:"rows"`
+	HasMore bool              `json:"has_more"`
+}
+
+// wlBrowseJSONPage trims dolt's JSON result (fetched with LIMIT limit+1) to
+// limit rows and adds has_more.
+func wlBrowseJSONPage(data []byte, limit int) ([]byte, error) {
+	var page wlBrowsePageJSON
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("parsing query output: %w", err)
+		}
+	}
+	if page.Rows == nil {
+		page.Rows = []json.RawMessage{}
+	}
+	page.Rows, page.HasMore = wlTrimPage(page.Rows, limit)
+
+	out, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// wlTotalEffort sums wasteland.EffortPoints over browse rows (effort_level
+// is the eighth column), returning the total and how many rows had no
+// known effort.
+func wlTotalEffort(rows [][]string) (total, unknown int) {
+	for _, row := range rows {
+		if len(row) < 8 {
+			continue
+		}
+		points, ok := wasteland.EffortPoints(row[7])
+		if !ok {
+			unknown++
+			continue
+		}
+		total += points
+	}
+	return total, unknown
+}
+
+// wlParseCSV parses dolt's CSV output into rows. Quoted fields may contain
+// commas, doubled quotes, and line breaks, so a multi-line description stays
+// in a single record. Blank lines are skipped.
 func wlParseCSV(data string) [][]string {
 	var rows [][]string
-	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
-		if line == "" {
+	for _, record := range wlSplitCSV(strings.TrimSpace(data)) {
+		if len(record) == 1 && record[0] == "" {
 			continue
 		}
-		rows = append(rows, wlParseCSVLine(line))
+		rows = append(rows, record)
 	}
 	return rows
 }
 
+// wlParseCSVLine parses a single CSV record.
 func wlParseCSVLine(line string) []string {
+	return wlSplitCSV(line)[0]
+}
+
+// wlSplitCSV tokenizes CSV data into records, splitting on line breaks
+// outside quoted fields. It always returns at least one record.
+func wlSplitCSV(data string) [][]string {
+	var records [][]string
 	var fields []string
 	var field strings.Builder
 	inQuote := false
 
-	for i := 0; i < len(line); i++ {
-		ch := line[i]
+	for i := 0; i < len(data); i++ {
+		ch := data[i]
 		switch {
 		case ch == '"' && !inQuote:
 			inQuote = true
 		case ch == '"' && inQuote:
-			if i+1 < len(line) && line[i+1] == '"' {
+			if i+1 < len(data) && data[i+1] == '"' {
 				field.WriteByte('"')
 				i++
 			} else {
@@ -198,12 +416,41 @@ func wlParseCSVLine(line string) []string {
 		case ch == ',' && !inQuote:
 			fields = append(fields, field.String())
 			field.Reset()
+		case ch == '\r' && !inQuote && i+1 < len(data) && data[i+1] == '\n':
+			// CRLF record terminator; the \n ends the record.
+		case ch == '\n' && !inQuote:
+			records = append(records, append(fields, field.String()))
+			fields = nil
+			field.Reset()
 		default:
 			field.WriteByte(ch)
 		}
 	}
-	fields = append(fields, field.String())
-	return fields
+	return append(records, append(fields, field.String()))
+}
+
+// wlWriteCSV renders rows as CSV, quoting fields as wlWriteCSVLine does.
+func wlWriteCSV(rows [][]string) string {
+	var sb strings.Builder
+	for _, row := range rows {
+		sb.WriteString(wlWriteCSVLine(row))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// wlWriteCSVLine renders fields as a single CSV record. Fields containing
+// commas, quotes, or line breaks are quoted, with embedded quotes doubled,
+// so that wlParseCSVLine(wlWriteCSVLine(fields)) returns fields unchanged.
+func wlWriteCSVLine(fields []string) string {
+	quoted := make([]string, len(fields))
+	for i, f := range fields {
+		if strings.ContainsAny(f, ",\"\r\n") {
+			f = `"` + strings.ReplaceAll(f, `"`, `""`) + `"`
+		}
+		quoted[i] = f
+	}
+	return strings.Join(quoted, ",")
 }
 
 func wlFormatPriority(pri string) string {
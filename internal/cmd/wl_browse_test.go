@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+func TestWLWriteCSVLine_RoundTrip(t *testing.T) {
+	inputs := [][]string{
+		{"w-abc", "Fix auth", "gastown"},
+		{"a,b", "c"},
+		{`say "hi"`, `""`, `"`},
+		{"", "", ""},
+		{"line one\nline two", "cr\rfield"},
+		{`mixed, "quoted", and` + "\n" + `newline`},
+		{" leading and trailing spaces "},
+		{"ünïcödé", "日本語,text"},
+		{","},
+	}
+
+	for _, fields := range inputs {
+		line := wlWriteCSVLine(fields)
+		got := wlParseCSVLine(line)
+		if !reflect.DeepEqual(got, fields) {
+			t.Errorf("round-trip mismatch:\n  fields: %q\n  line:   %q\n  parsed: %q", fields, line, got)
+		}
+	}
+}
+
+func TestWLWriteCSVLine_QuotesOnlyWhenNeeded(t *testing.T) {
+	tests := []struct {
+		fields []string
+		want   string
+	}{
+		{[]string{"plain", "text"}, "plain,text"},
+		{[]string{"a,b"}, `"a,b"`},
+		{[]string{`say "hi"`}, `"say ""hi"""`},
+		{[]string{"two\nlines"}, "\"two\nlines\""},
+	}
+
+	for _, tt := range tests {
+		if got := wlWriteCSVLine(tt.fields); got != tt.want {
+			t.Errorf("wlWriteCSVLine(%q) = %q, want %q", tt.fields, got, tt.want)
+		}
+	}
+}
+
+func TestWLWriteCSV(t *testing.T) {
+	rows := [][]string{
+		{"id", "title"},
+		{"w-1", "Fix, then ship"},
+	}
+	want := "id,title\nw-1,\"Fix, then ship\"\n"
+	if got := wlWriteCSV(rows); got != want {
+		t.Errorf("wlWriteCSV() = %q, want %q", got, want)
+	}
+	if got := wlParseCSV(wlWriteCSV(rows)); !reflect.DeepEqual(got, rows) {
+		t.Errorf("wlParseCSV(wlWriteCSV(rows)) = %q, want %q", got, rows)
+	}
+}
+
+func TestWLParseCSV_MultiLineQuotedField(t *testing.T) {
+	data := "id,title,description\n" +
+		"w-1,Fix auth,\"First line\nsecond line, with comma\"\n" +
+		"w-2,Docs,plain\n"
+
+	got := wlParseCSV(data)
+	want := [][]string{
+		{"id", "title", "description"},
+		{"w-1", "Fix auth", "First line\nsecond line, with comma"},
+		{"w-2", "Docs", "plain"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wlParseCSV() = %q, want %q", got, want)
+	}
+}
+
+func TestWLParseCSV_CRLFAndBlankLines(t *testing.T) {
+	data := "id,title\r\nw-1,One\r\n\r\nw-2,\"Two\r\nlines\"\r\n"
+
+	got := wlParseCSV(data)
+	want := [][]string{
+		{"id", "title"},
+		{"w-1", "One"},
+		{"w-2", "Two\r\nlines"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wlParseCSV() = %q, want %q", got, want)
+	}
+}
+
+func TestWLEscapeSQL(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{"plain", "plain"},
+		{"it's", "it''s"},
+		{`trailing\`, `trailing\\`},
+		{`\' OR 1=1 -- `, `\\'' OR 1=1 -- `},
+	}
+	for _, tt := range tests {
+		if got := wlEscapeSQL(tt.input); got != tt.want {
+			t.Errorf("wlEscapeSQL(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestBuildWLBrowseQuery_MultipleProjects(t *testing.T) {
+	oldProject, oldStatus, oldType, oldPri, oldLimit := wlBrowseProject, wlBrowseStatus, wlBrowseType, wlBrowsePriority, wlBrowseLimit
+	t.Cleanup(func() {
+		wlBrowseProject, wlBrowseStatus, wlBrowseType, wlBrowsePriority, wlBrowseLimit = oldProject, oldStatus, oldType, oldPri, oldLimit
+	})
+	wlBrowseProject = []string{"gastown", "o'hare"}
+	wlBrowseStatus = "open"
+	wlBrowseType = ""
+	wlBrowsePriority = -1
+	wlBrowseLimit = 10
+
+	query, err := buildWLBrowseQuery()
+	if err != nil {
+		t.Fatalf("buildWLBrowseQuery() error: %v", err)
+	}
+	want := "WHERE status = 'open' AND project IN ('gastown','o''hare')"
+	if !strings.Contains(query, want) {
+		t.Errorf("query = %q, want it to contain %q", query, want)
+	}
+}
+
+func TestBuildWLBrowseQuery_ProjectFilter(t *testing.T) {
+	oldProject, oldStatus, oldType, oldPri, oldTier := wlBrowseProject, wlBrowseStatus, wlBrowseType, wlBrowsePriority, wlBrowseMaxTier
+	t.Cleanup(func() {
+		wlBrowseProject, wlBrowseStatus, wlBrowseType, wlBrowsePriority, wlBrowseMaxTier = oldProject, oldStatus, oldType, oldPri, oldTier
+	})
+	wlBrowseStatus = ""
+	wlBrowseType = ""
+	wlBrowsePriority = -1
+	wlBrowseMaxTier = ""
+
+	tests := []struct {
+		name     string
+		projects []string
+		want     string
+	}{
+		{"single", []string{"gastown"}, "WHERE project IN ('gastown')"},
+		{"multiple", []string{"gastown", "beads"}, "WHERE project IN ('gastown','beads')"},
+		{"empty", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wlBrowseProject = tt.projects
+			query, err := buildWLBrowseQuery()
+			if err != nil {
+				t.Fatalf("buildWLBrowseQuery() error: %v", err)
+			}
+			if tt.want == "" {
+				if strings.Contains(query, "WHERE") {
+					t.Errorf("query = %q, want no WHERE clause", query)
+				}
+			} else if !strings.Contains(query, tt.want) {
+				t.Errorf("query = %q, want it to contain %q", query, tt.want)
+			}
+		})
+	}
+}
+
+func TestWLBrowseProjectFlag_RepeatableAndCommaSeparated(t *testing.T) {
+	oldProject := wlBrowseProject
+	t.Cleanup(func() { wlBrowseProject = oldProject })
+
+	if err := wlBrowseCmd.Flags().Parse([]string{"--project", "gastown,beads", "--project", "hop"}); err != nil {
+		t.Fatalf("parsing flags: %v", err)
+	}
+	want := []string{"gastown", "beads", "hop"}
+	if !reflect.DeepEqual(wlBrowseProject, want) {
+		t.Errorf("--project = %q, want %q", wlBrowseProject, want)
+	}
+}
+
+func TestBuildWLBrowseQuery_MaxTier(t *testing.T) {
+	oldProject, oldStatus, oldType, oldPri, oldTier := wlBrowseProject, wlBrowseStatus, wlBrowseType, wlBrowsePriority, wlBrowseMaxTier
+	t.Cleanup(func() {
+		wlBrowseProject, wlBrowseStatus, wlBrowseType, wlBrowsePriority, wlBrowseMaxTier = oldProject, oldStatus, oldType, oldPri, oldTier
+	})
+	wlBrowseProject = nil
+	wlBrowseStatus = ""
+	wlBrowseType = ""
+	wlBrowsePriority = -1
+
+	wlBrowseMaxTier = "restricted"
+	query, err := buildWLBrowseQuery()
+	if err != nil {
+		t.Fatalf("buildWLBrowseQuery() error: %v", err)
+	}
+	want := "WHERE (sandbox_min_tier IS NULL OR sandbox_min_tier IN ('none','restricted'))"
+	if !strings.Contains(query, want) {
+		t.Errorf("query = %q, want it to contain %q", query, want)
+	}
+
+	wlBrowseMaxTier = "superuser"
+	if _, err := buildWLBrowseQuery(); err == nil {
+		t.Error("buildWLBrowseQuery() should reject an unknown --max-tier")
+	}
+}
+
+func TestBuildWLBrowseQuery_SmartOrder(t *testing.T) {
+	oldSmart := wlBrowseSmart
+	t.Cleanup(func() { wlBrowseSmart = oldSmart })
+
+	wlBrowseSmart = false
+	query, err := buildWLBrowseQuery()
+	if err != nil {
+		t.Fatalf("buildWLBrowseQuery() error: %v", err)
+	}
+	if !strings.Contains(query, " ORDER BY priority ASC, created_at DESC LIMIT") {
+		t.Errorf("default query = %q, want priority then created_at ordering", query)
+	}
+
+	wlBrowseSmart = true
+	query, err = buildWLBrowseQuery()
+	if err != nil {
+		t.Fatalf("buildWLBrowseQuery() error: %v", err)
+	}
+	want := " ORDER BY priority ASC, FIELD(effort_level, 'epic', 'large', 'medium', 'small', 'trivial') DESC, created_at DESC LIMIT"
+	if !strings.Contains(query, want) {
+		t.Errorf("smart query = %q, want it to contain %q", query, want)
+	}
+}
+
+func TestWLTotalEffort(t *testing.T) {
+	rows := [][]string{
+		{"w-1", "Fix typo", "gastown", "docs", "3", "alice", "open", "trivial"},
+		{"w-2", "New parser", "beads", "feature", "1", "bob", "open", "large"},
+		{"w-3", "Rewrite", "gastown", "feature", "2", "carol", "open", "epic"},
+		{"w-4", "Unsized", "gastown", "bug", "2", "dave", "open", ""},
+		{"w-5", "short row"},
+	}
+	total, unknown := wlTotalEffort(rows)
+	if total != 1+8+13 || unknown != 1 {
+		t.Errorf("wlTotalEffort() = %d, %d; want 22, 1", total, unknown)
+	}
+	if total, unknown := wlTotalEffort(nil); total != 0 || unknown != 0 {
+		t.Errorf("wlTotalEffort(nil) = %d, %d; want 0, 0", total, unknown)
+	}
+}
+
+func TestWlTrimPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     int
+		limit    int
+		wantRows int
+		wantMore bool
+	}{
+		{"below limit", 3, 5, 3, false},
+		{"at limit", 5, 5, 5, false},
+		{"extra row fetched", 6, 5, 5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, more := wlTrimPage(make([]int, tt.rows), tt.limit)
+			if len(got) != tt.wantRows || more != tt.wantMore {
+				t.Errorf("wlTrimPage(%d rows, %d) = %d rows, more %v; want %d, %v", tt.rows, tt.limit, len(got), more, tt.wantRows, tt.wantMore)
+			}
+		})
+	}
+}
+
+func TestWlBrowseJSONPage(t *testing.T) {
+	data := []byte(`{"rows": [{"id":"w-1"},{"id":"w-2"},{"id":"w-3"}]}`)
+
+	out, err := wlBrowseJSONPage(data, 2)
+	if err != nil {
+		t.Fatalf("wlBrowseJSONPage() error: %v", err)
+	}
+	var page struct {
+		Rows    []map[string]string `json:"rows"`
+		HasMore bool                `json:"has_more"`
+	}
+	if err := json.Unmarshal(out, &page); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(page.Rows) != 2 || page.Rows[1]["id"] != "w-2" || !page.HasMore {
+		t.Errorf("page = %+v, want w-1, w-2 and has_more", page)
+	}
+
+	out, err = wlBrowseJSONPage(data, 3)
+	if err != nil {
+		t.Fatalf("wlBrowseJSONPage() error: %v", err)
+	}
+	if !strings.Contains(string(out), `"has_more": false`) {
+		t.Errorf("at the limit has_more should be false, got %s", out)
+	}
+}
+
+func TestBuildWLBrowseQuery_FetchesExtraRow(t *testing.T) {
+	oldLimit, oldOffset := wlBrowseLimit, wlBrowseOffset
+	t.Cleanup(func() { wlBrowseLimit, wlBrowseOffset = oldLimit, oldOffset })
+	wlBrowseLimit = 10
+	wlBrowseOffset = 20
+
+	query, err := buildWLBrowseQuery()
+	if err != nil {
+		t.Fatalf("buildWLBrowseQuery() error: %v", err)
+	}
+	if !strings.HasSuffix(query, " LIMIT 11 OFFSET 20") {
+		t.Errorf("query = %q, want LIMIT 11 OFFSET 20", query)
+	}
+}
+
+func TestWLBrowseTitleWidth(t *testing.T) {
+	tests := []struct {
+		termWidth int
+		want      int
+	}{
+		{40, wlBrowseMinTitleWidth},
+		{80, wlBrowseMinTitleWidth},
+		{101, 20},
+		{120, 39},
+		{160, 79},
+		{200, 119},
+	}
+	for _, tt := range tests {
+		got := wlBrowseTitleWidth(tt.termWidth)
+		if got != tt.want {
+			t.Errorf("wlBrowseTitleWidth(%d) = %d, want %d", tt.termWidth, got, tt.want)
+		}
+		// Above the minimum, the table fills the terminal exactly.
+		if got > wlBrowseMinTitleWidth {
+			rendered := style.NewTable(wlBrowseColumns(got)...).Render()
+			header, _, _ := strings.Cut(rendered, "\n")
+			if width := len(strings.TrimRight(header, " ")); width > tt.termWidth {
+				t.Errorf("termWidth %d: header is %d wide", tt.termWidth, width)
+			}
+		}
+	}
+}
+
+func TestWLLongestTitle(t *testing.T) {
+	rows := [][]string{
+		{"w-1", "short"},
+		{"w-2", "a considerably longer title than the default TITLE column"},
+		{"w-3"},
+	}
+	if got, want := wlLongestTitle(rows), len(rows[1][1]); got != want {
+		t.Errorf("wlLongestTitle() = %d, want %d", got, want)
+	}
+	if got := wlLongestTitle(nil); got != len("TITLE") {
+		t.Errorf("wlLongestTitle(nil) = %d, want %d", got, len("TITLE"))
+	}
+}
@@ -1,42 +1,76 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/wasteland"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
+var (
+	wlClaimAuto    bool
+	wlClaimProject []string
+	wlClaimType    string
+	wlClaimMaxTier string
+	wlClaimSmart   bool
+)
+
+// wlClaimAutoAttempts bounds how many ranked candidates claim --auto tries
+// when other rigs keep claiming the top item first.
+const wlClaimAutoAttempts = 3
+
 var wlClaimCmd = &cobra.Command{
-	Use:   "claim <wanted-id>",
+	Use:   "claim [wanted-id]",
 	Short: "Claim a wanted item",
 	Long: `Claim a wanted item on the shared wanted board.
 
 Updates the wanted row: claimed_by=<your rig handle>, status='claimed'.
 The item must exist and have status='open'.
 
+With --auto, claims the highest-ranked open item matching the filters,
+using the same ordering as 'gt wl browse'. If another rig claims it first,
+the next item is tried, up to a few times.
+
 In wild-west mode (Phase 1), this writes directly to the local wl-commons
 database. In PR mode, this will create a DoltHub PR instead.
 
 Examples:
-  gt wl claim w-abc123`,
-	Args: cobra.ExactArgs(1),
+  gt wl claim w-abc123
+  gt wl claim --auto --project gastown
+  gt wl claim --auto --smart --max-tier restricted`,
+	Args: wlClaimArgs,
 	RunE: runWlClaim,
 }
 
+// wlClaimArgs requires a wanted ID unless --auto picks the item.
+func wlClaimArgs(cmd *cobra.Command, args []string) error {
+	if auto, _ := cmd.Flags().GetBool("auto"); auto {
+		if len(args) > 0 {
+			return fmt.Errorf("--auto selects the item itself; do not pass a wanted ID")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
 func init() {
+	wlClaimCmd.Flags().BoolVar(&wlClaimAuto, "auto", false, "Claim the top-ranked open item matching the filters")
+	wlClaimCmd.Flags().StringSliceVar(&wlClaimProject, "project", nil, "With --auto: only consider these projects")
+	wlClaimCmd.Flags().StringVar(&wlClaimType, "type", "", "With --auto: only consider this type")
+	wlClaimCmd.Flags().StringVar(&wlClaimMaxTier, "max-tier", "", "With --auto: only consider items this sandbox tier satisfies")
+	wlClaimCmd.Flags().BoolVar(&wlClaimSmart, "smart", false, "With --auto: rank smallest effort first within each priority")
+
 	wlCmd.AddCommand(wlClaimCmd)
 }
 
 func runWlClaim(cmd *cobra.Command, args []string) error {
-	wantedID := args[0]
-
-	townRoot, err := workspace.FindFromCwdOrError()
+	townRoot, err := wlTownRoot()
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return err
 	}
 
 	wlCfg, err := wasteland.LoadConfig(townRoot)
@@ -45,26 +79,105 @@ func runWlClaim(cmd *cobra.Command, args []string) error {
 	}
 	rigHandle := wlCfg.RigHandle
 
-	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDB) {
-		return fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDB)
+	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDBName()) {
+		return fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDBName())
+	}
+
+	if wlClaimAuto {
+		conditions, err := wlWantedConditions("open", wlClaimProject, wlClaimType, -1, wlClaimMaxTier)
+		if err != nil {
+			return err
+		}
+		item, err := wlClaimTop(townWLClaimStore{townRoot}, conditions, wlWantedOrderBy(wlClaimSmart), rigHandle)
+		if err != nil {
+			return err
+		}
+		recordWLFederation(townRoot, wasteland.FederationClaim, item.ID, rigHandle)
+		printWLClaimed(item, rigHandle)
+		return nil
 	}
 
+	wantedID := args[0]
 	item, err := doltserver.QueryWanted(townRoot, wantedID)
 	if err != nil {
 		return fmt.Errorf("querying wanted item: %w", err)
 	}
 
-	if item.Status != "open" {
+	if !wasteland.CanTransition(wasteland.WantedStatus(item.Status), wasteland.WantedClaimed) {
 		return fmt.Errorf("wanted item %s is not open (status: %s)", wantedID, item.Status)
 	}
 
 	if err := doltserver.ClaimWanted(townRoot, wantedID, rigHandle); err != nil {
 		return fmt.Errorf("claiming wanted item: %w", err)
 	}
+	recordWLFederation(townRoot, wasteland.FederationClaim, wantedID, rigHandle)
 
-	fmt.Printf("%s Claimed %s\n", style.Bold.Render("✓"), wantedID)
+	printWLClaimed(item, rigHandle)
+	return nil
+}
+
+func printWLClaimed(item *doltserver.WantedItem, rigHandle string) {
+	fmt.Printf("%s Claimed %s\n", style.Bold.Render("✓"), item.ID)
 	fmt.Printf("  Claimed by: %s\n", rigHandle)
 	fmt.Printf("  Title: %s\n", item.Title)
+}
 
-	return nil
+// errWLNothingToClaim is returned by wlClaimTop when no open item matches.
+var errWLNothingToClaim = errors.New("no open wanted items match the filters")
+
+// wlClaimStore is the slice of the local commons that claim --auto uses.
+type wlClaimStore interface {
+	QueryWantedIDs(conditions []string, orderBy string, limit int) ([]string, error)
+	ClaimWanted(wantedID, rigHandle string) error
+	QueryWanted(wantedID string) (*doltserver.WantedItem, error)
+}
+
+// townWLClaimStore is the wlClaimStore backed by the town's wl-commons database.
+type townWLClaimStore struct{ townRoot string }
+
+func (s townWLClaimStore) QueryWantedIDs(conditions []string, orderBy string, limit int) ([]string, error) {
+	return doltserver.QueryWantedIDs(s.townRoot, conditions, orderBy, limit)
+}
+
+func (s townWLClaimStore) ClaimWanted(wantedID, rigHandle string) error {
+	return doltserver.ClaimWanted(s.townRoot, wantedID, rigHandle)
+}
+
+func (s townWLClaimStore) QueryWanted(wantedID string) (*doltserver.WantedItem, error) {
+	return doltserver.QueryWanted(s.townRoot, wantedID)
+}
+
+// wlClaimTop claims the first of the top-ranked items matching conditions
+// that rigHandle wins. The claim only applies to rows still open, so after
+// each attempt the row is re-read: if another rig got there first the next
+// candidate is tried, up to wlClaimAutoAttempts candidates.
+func wlClaimTop(store wlClaimStore, conditions []string, orderBy, rigHandle string) (*doltserver.WantedItem, error) {
+	ids, err := store.QueryWantedIDs(conditions, orderBy, wlClaimAutoAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("selecting wanted items: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, errWLNothingToClaim
+	}
+
+	var lost []string
+	for _, id := range ids {
+		claimErr := store.ClaimWanted(id, rigHandle)
+		item, err := store.QueryWanted(id)
+		if err != nil {
+			return nil, fmt.Errorf("checking claim on %s: %w", id, err)
+		}
+		if item.Status == "claimed" && item.ClaimedBy == rigHandle {
+			return item, nil
+		}
+		if item.Status == "open" {
+			// Still open, so nobody beat us to it: the claim itself failed.
+			if claimErr == nil {
+				claimErr = errors.New("row was not updated")
+			}
+			return nil, fmt.Errorf("claiming wanted item %s: %w", id, claimErr)
+		}
+		lost = append(lost, id)
+	}
+	return nil, fmt.Errorf("claimed by other rigs first: %s", strings.Join(lost, ", "))
 }
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// fakeWLClaimStore is an in-memory wlClaimStore. rivals maps an item ID to
+// a rig that claims it between our selection and our claim.
+type fakeWLClaimStore struct {
+	ranked    []string
+	items     map[string]*doltserver.WantedItem
+	rivals    map[string]string
+	claimErr  error
+	gotLimit  int
+	attempted []string
+}
+
+func (s *fakeWLClaimStore) QueryWantedIDs(conditions []string, orderBy string, limit int) ([]string, error) {
+	s.gotLimit = limit
+	var ids []string
+	for _, id := range s.ranked {
+		if s.items[id].Status == "open" && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s *fakeWLClaimStore) ClaimWanted(wantedID, rigHandle string) error {
+	s.attempted = append(s.attempted, wantedID)
+	item := s.items[wantedID]
+	if rival, ok := s.rivals[wantedID]; ok {
+		item.Status, item.ClaimedBy = "claimed", rival
+	}
+	if s.claimErr != nil {
+		return s.claimErr
+	}
+	if item.Status == "open" {
+		item.Status, item.ClaimedBy = "claimed", rigHandle
+	}
+	return nil
+}
+
+func (s *fakeWLClaimStore) QueryWanted(wantedID string) (*doltserver.WantedItem, error) {
+	item := *s.items[wantedID]
+	return &item, nil
+}
+
+func newFakeWLClaimStore(ids ...string) *fakeWLClaimStore {
+	s := &fakeWLClaimStore{ranked: ids, items: map[string]*doltserver.WantedItem{}, rivals: map[string]string{}}
+	for _, id := range ids {
+		s.items[id] = &doltserver.WantedItem{ID: id, Title: "Item " + id, Status: "open"}
+	}
+	return s
+}
+
+func TestWLClaimTop_ClaimsTopItem(t *testing.T) {
+	store := newFakeWLClaimStore("w-1", "w-2")
+
+	item, err := wlClaimTop(store, nil, "", "alice")
+	if err != nil {
+		t.Fatalf("wlClaimTop() error: %v", err)
+	}
+	if item.ID != "w-1" || item.ClaimedBy != "alice" {
+		t.Errorf("claimed %s by %q, want w-1 by alice", item.ID, item.ClaimedBy)
+	}
+	if store.gotLimit != wlClaimAutoAttempts {
+		t.Errorf("candidate limit = %d, want %d", store.gotLimit, wlClaimAutoAttempts)
+	}
+}
+
+func TestWLClaimTop_RetriesAfterLostRace(t *testing.T) {
+	store := newFakeWLClaimStore("w-1", "w-2", "w-3")
+	store.rivals["w-1"] = "bob"
+
+	item, err := wlClaimTop(store, nil, "", "alice")
+	if err != nil {
+		t.Fatalf("wlClaimTop() error: %v", err)
+	}
+	if item.ID != "w-2" || item.ClaimedBy != "alice" {
+		t.Errorf("claimed %s by %q, want w-2 by alice", item.ID, item.ClaimedBy)
+	}
+	if want := []string{"w-1", "w-2"}; !reflect.DeepEqual(store.attempted, want) {
+		t.Errorf("attempted = %v, want %v", store.attempted, want)
+	}
+}
+
+func TestWLClaimTop_LostRaceReportedAsClaimError(t *testing.T) {
+	// dolt fails the commit when the guarded UPDATE matched no rows.
+	store := newFakeWLClaimStore("w-1", "w-2")
+	store.rivals["w-1"] = "bob"
+	store.claimErr = errors.New("nothing to commit")
+	store.rivals["w-2"] = "carol"
+
+	_, err := wlClaimTop(store, nil, "", "alice")
+	if err == nil || !strings.Contains(err.Error(), "w-1, w-2") {
+		t.Errorf("wlClaimTop() error = %v, want both items reported as lost", err)
+	}
+}
+
+func TestWLClaimTop_GivesUpAfterBound(t *testing.T) {
+	store := newFakeWLClaimStore("w-1", "w-2", "w-3", "w-4")
+	for _, id := range store.ranked {
+		store.rivals[id] = "bob"
+	}
+
+	if _, err := wlClaimTop(store, nil, "", "alice"); err == nil {
+		t.Fatal("wlClaimTop() should fail when every candidate is taken")
+	}
+	if len(store.attempted) != wlClaimAutoAttempts {
+		t.Errorf("attempted %d items, want %d", len(store.attempted), wlClaimAutoAttempts)
+	}
+}
+
+func TestWLClaimTop_ClaimFailureNotRetried(t *testing.T) {
+	store := newFakeWLClaimStore("w-1", "w-2")
+	store.claimErr = errors.New("server unavailable")
+
+	_, err := wlClaimTop(store, nil, "", "alice")
+	if err == nil || !strings.Contains(err.Error(), "server unavailable") {
+		t.Errorf("wlClaimTop() error = %v, want the claim failure", err)
+	}
+	if want := []string{"w-1"}; !reflect.DeepEqual(store.attempted, want) {
+		t.Errorf("attempted = %v, want %v", store.attempted, want)
+	}
+}
+
+func TestWLClaimTop_NothingOpen(t *testing.T) {
+	store := newFakeWLClaimStore()
+
+	if _, err := wlClaimTop(store, nil, "", "alice"); !errors.Is(err, errWLNothingToClaim) {
+		t.Errorf("wlClaimTop() error = %v, want errWLNothingToClaim", err)
+	}
+}
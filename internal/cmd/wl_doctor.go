@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wasteland"
+)
+
+var wlDoctorJSON bool
+
+var wlDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the Wasteland setup",
+	Args:  cobra.NoArgs,
+	RunE:  runWLDoctor,
+	Long: `Check everything federation needs, printing a pass/fail line per
+check with a hint for each failure:
+
+  - dolt is installed (and its version)
+  - dolt has DoltHub credentials DoltHub accepts
+  - this town has joined, and its fork clone has origin and upstream remotes
+  - the commons can be fetched
+  - the commons schema version is one this gt understands
+
+Checks that need the fork clone are skipped until it exists.
+
+EXAMPLES:
+  gt wl doctor
+  gt wl doctor --json`,
+}
+
+func init() {
+	wlDoctorCmd.Flags().BoolVar(&wlDoctorJSON, "json", false, "Output results as JSON")
+
+	wlCmd.AddCommand(wlDoctorCmd)
+}
+
+func runWLDoctor(cmd *cobra.Command, args []string) error {
+	townRoot, err := wlTownRoot()
+	if err != nil {
+		return err
+	}
+
+	// A town that hasn't joined is reported by the local fork check.
+	cfg, _ := wasteland.LoadConfig(townRoot)
+	results := wasteland.Doctor(cfg)
+
+	if wlDoctorJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		printWLDoctorResults(os.Stdout, results)
+	}
+
+	for _, r := range results {
+		if !r.OK {
+			return NewSilentExit(1)
+		}
+	}
+	return nil
+}
+
+// printWLDoctorResults prints one line per check, with the hint under each
+// failure.
+func printWLDoctorResults(w io.Writer, results []wasteland.CheckResult) {
+	for _, r := range results {
+		mark := style.Bold.Render("✓")
+		if !r.OK {
+			mark = style.Bold.Render("✗")
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", mark, r.Name, r.Message)
+		if !r.OK && r.Hint != "" {
+			fmt.Fprintf(w, "    %s\n", style.Dim.Render("→ "+r.Hint))
+		}
+	}
+}
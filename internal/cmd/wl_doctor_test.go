@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/wasteland"
+)
+
+func TestPrintWLDoctorResults(t *testing.T) {
+	var buf bytes.Buffer
+	printWLDoctorResults(&buf, []wasteland.CheckResult{
+		{Name: "dolt installed", OK: true, Message: "dolt version 1.43.0", Hint: "unused"},
+		{Name: "local fork", Message: "this town has not joined a wasteland", Hint: "run gt wl join <upstream>"},
+	})
+
+	out := buf.String()
+	if !strings.Contains(out, "✓ dolt installed: dolt version 1.43.0") || strings.Contains(out, "unused") {
+		t.Errorf("passing check should print its message without a hint:\n%s", out)
+	}
+	if !strings.Contains(out, "✗ local fork: this town has not joined a wasteland") || !strings.Contains(out, "→ run gt wl join <upstream>") {
+		t.Errorf("failing check should print its message and hint:\n%s", out)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/wasteland"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var wlDoneEvidence string
@@ -44,9 +43,9 @@ func init() {
 func runWlDone(cmd *cobra.Command, args []string) error {
 	wantedID := args[0]
 
-	townRoot, err := workspace.FindFromCwdOrError()
+	townRoot, err := wlTownRoot()
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return err
 	}
 
 	wlCfg, err := wasteland.LoadConfig(townRoot)
@@ -55,8 +54,8 @@ func runWlDone(cmd *cobra.Command, args []string) error {
 	}
 	rigHandle := wlCfg.RigHandle
 
-	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDB) {
-		return fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDB)
+	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDBName()) {
+		return fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDBName())
 	}
 
 	item, err := doltserver.QueryWanted(townRoot, wantedID)
@@ -64,7 +63,7 @@ func runWlDone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("querying wanted item: %w", err)
 	}
 
-	if item.Status != "claimed" {
+	if !wasteland.CanTransition(wasteland.WantedStatus(item.Status), wasteland.WantedInReview) {
 		return fmt.Errorf("wanted item %s is not claimed (status: %s)", wantedID, item.Status)
 	}
 
@@ -77,6 +76,7 @@ func runWlDone(cmd *cobra.Command, args []string) error {
 	if err := doltserver.SubmitCompletion(townRoot, completionID, wantedID, rigHandle, wlDoneEvidence); err != nil {
 		return fmt.Errorf("submitting completion: %w", err)
 	}
+	recordWLFederation(townRoot, wasteland.FederationComplete, wantedID, rigHandle)
 
 	fmt.Printf("%s Completion submitted for %s\n", style.Bold.Render("✓"), wantedID)
 	fmt.Printf("  Completion ID: %s\n", completionID)
@@ -88,7 +88,7 @@ func runWlDone(cmd *cobra.Command, args []string) error {
 }
 
 func generateCompletionID(wantedID, rigHandle string) string {
-	now := time.Now().UTC().Format(time.RFC3339)
+	now := wasteland.Clock().UTC().Format(time.RFC3339)
 	h := sha256.Sum256([]byte(wantedID + "|" + rigHandle + "|" + now))
 	return fmt.Sprintf("c-%x", h[:8])
 }
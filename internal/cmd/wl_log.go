@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wasteland"
+)
+
+var wlLogJSON bool
+
+var wlLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show what this town has done on the commons",
+	Long: `Show the town's federation log: every wanted item it posted, claimed
+or completed, oldest first, with the wl-commons commit each produced.
+
+The log is append-only and lives in .gastown/federation.log.
+
+Examples:
+  gt wl log
+  gt wl log --json`,
+	Args: cobra.NoArgs,
+	RunE: runWLLog,
+}
+
+func init() {
+	wlLogCmd.Flags().BoolVar(&wlLogJSON, "json", false, "Output as JSON")
+
+	wlCmd.AddCommand(wlLogCmd)
+}
+
+func runWLLog(cmd *cobra.Command, args []string) error {
+	townRoot, err := wlTownRoot()
+	if err != nil {
+		return err
+	}
+
+	events, err := wasteland.ReadFederationLog(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if wlLogJSON {
+		if events == nil {
+			events = []wasteland.FederationEvent{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(events)
+	}
+	printWLLog(os.Stdout, events)
+	return nil
+}
+
+func printWLLog(w io.Writer, events []wasteland.FederationEvent) {
+	if len(events) == 0 {
+		fmt.Fprintln(w, "No federation events recorded yet.")
+		return
+	}
+	for _, event := range events {
+		commit := event.Commit
+		if len(commit) > 12 {
+			commit = commit[:12]
+		}
+		fmt.Fprintf(w, "%s  %-8s  %-12s  %s\n",
+			event.Time.Local().Format("2006-01-02 15:04:05"), event.Action, event.WantedID, style.Dim.Render(commit))
+	}
+}
+
+// wlCommonsHead returns the wl-commons HEAD commit; replaced in tests.
+var wlCommonsHead = doltserver.WLCommonsHead
+
+// recordWLFederation appends a successful wl mutation to the town's
+// federation log. The mutation has already happened, so failures only warn.
+func recordWLFederation(townRoot, action, wantedID, rigHandle string) {
+	commit, err := wlCommonsHead(townRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read wl-commons commit for the federation log: %v\n", err)
+	}
+	event := wasteland.FederationEvent{WantedID: wantedID, Action: action, Commit: commit, Rig: rigHandle}
+	if err := wasteland.AppendFederationLog(townRoot, event); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/wasteland"
+)
+
+func stubWLCommonsHead(t *testing.T, head string, err error) {
+	t.Helper()
+	orig := wlCommonsHead
+	wlCommonsHead = func(string) (string, error) { return head, err }
+	t.Cleanup(func() { wlCommonsHead = orig })
+}
+
+func TestRecordWLFederation(t *testing.T) {
+	townRoot := t.TempDir()
+	stubWLCommonsHead(t, "0123456789abcdef0123", nil)
+	recordWLFederation(townRoot, wasteland.FederationClaim, "w-abc", "alice")
+
+	// A missing commit still records the event.
+	stubWLCommonsHead(t, "", errors.New("server down"))
+	recordWLFederation(townRoot, wasteland.FederationComplete, "w-abc", "alice")
+
+	events, err := wasteland.ReadFederationLog(townRoot)
+	if err != nil {
+		t.Fatalf("ReadFederationLog() error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want 2", events)
+	}
+	if events[0].Action != "claim" || events[0].Commit != "0123456789abcdef0123" || events[0].Rig != "alice" {
+		t.Errorf("first event = %+v", events[0])
+	}
+	if events[1].Action != "complete" || events[1].Commit != "" {
+		t.Errorf("second event = %+v", events[1])
+	}
+}
+
+func TestPrintWLLog(t *testing.T) {
+	var buf bytes.Buffer
+	printWLLog(&buf, nil)
+	if !strings.Contains(buf.String(), "No federation events") {
+		t.Errorf("empty log output = %q", buf.String())
+	}
+
+	buf.Reset()
+	printWLLog(&buf, []wasteland.FederationEvent{
+		{Time: time.Now(), WantedID: "w-abc", Action: "post", Commit: "0123456789abcdef0123"},
+	})
+	out := buf.String()
+	if !strings.Contains(out, "post") || !strings.Contains(out, "w-abc") || !strings.Contains(out, "0123456789ab") || strings.Contains(out, "0123456789abc") {
+		t.Errorf("log output should show the action, ID and short commit:\n%s", out)
+	}
+}
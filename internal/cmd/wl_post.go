@@ -1,14 +1,23 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/wasteland"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var (
@@ -16,9 +25,18 @@ var (
 	wlPostDescription string
 	wlPostProject     string
 	wlPostType        string
-	wlPostPriority    int
+	wlPostPriority    string
 	wlPostEffort      string
 	wlPostTags        string
+	wlPostMinTier     string
+	wlPostStrict      bool
+	wlPostAllowExt    bool
+	wlPostInteractive bool
+	wlPostDryRun      bool
+	wlPostJSON        bool
+	wlPostEdit        bool
+	wlPostServer      bool
+	wlPostCheckRemote bool
 )
 
 var wlPostCmd = &cobra.Command{
@@ -32,10 +50,36 @@ wl-commons database. Phase 1 (wild-west): direct write to main branch.
 The posted_by field is set to the rig's DoltHub org (DOLTHUB_ORG) or
 falls back to the directory name.
 
+The --project value is checked against the rigs registered in this town.
+An unknown project prints a warning (or fails with --strict). Use
+--allow-external to post for a federation project that is not a local rig.
+
+With --interactive, prompts for each field (suggesting known rigs for the
+project) and asks for confirmation before posting. Requires a terminal.
+
+Posting only writes the town's local wl-commons database, so it needs no
+network access. With --check-remote, first fetches from your commons fork
+on DoltHub so that authentication problems surface before you build the
+item; this proves read access only. --dry-run validates and previews the
+item without any database writes and skips --check-remote.
+
+With --edit, opens $VISUAL or $EDITOR (default vi) to write the
+description. Saving an empty or unchanged description aborts the post.
+
+With --json, prints the posted item as JSON instead of a summary; with
+--dry-run it also includes the SQL that would be run.
+
 Examples:
   gt wl post --title "Fix auth bug" --project gastown --type bug
   gt wl post --title "Add federation sync" --type feature --priority 1 --effort large
-  gt wl post --title "Update docs" --tags "docs,federation" --effort small`,
+  gt wl post --title "Triage flaky test" --type bug --priority high
+  gt wl post --title "Update docs" --tags "docs,federation" --effort small
+  gt wl post --title "Fix schema" --project hop --allow-external
+  gt wl post --title "Rotate keys" --sandbox-min-tier trusted
+  gt wl post --interactive
+  gt wl post --title "Design federation auth" --type design --edit
+  gt wl post --title "Fix auth bug" --dry-run
+  gt wl post --title "Fix auth bug" --json`,
 	RunE: runWlPost,
 }
 
@@ -44,19 +88,75 @@ func init() {
 	wlPostCmd.Flags().StringVarP(&wlPostDescription, "description", "d", "", "Detailed description")
 	wlPostCmd.Flags().StringVar(&wlPostProject, "project", "", "Project name (e.g., gastown, beads)")
 	wlPostCmd.Flags().StringVar(&wlPostType, "type", "", "Item type: feature, bug, design, rfc, docs")
-	wlPostCmd.Flags().IntVar(&wlPostPriority, "priority", 2, "Priority: 0=critical, 1=high, 2=medium, 3=low, 4=backlog")
+	wlPostCmd.Flags().StringVar(&wlPostPriority, "priority", "2", "Priority: 0=critical, 1=high, 2=medium, 3=low, 4=backlog (number or label)")
 	wlPostCmd.Flags().StringVar(&wlPostEffort, "effort", "medium", "Effort level: trivial, small, medium, large, epic")
 	wlPostCmd.Flags().StringVar(&wlPostTags, "tags", "", "Comma-separated tags (e.g., 'go,auth,federation')")
-
-	_ = wlPostCmd.MarkFlagRequired("title")
+	wlPostCmd.Flags().StringVar(&wlPostMinTier, "sandbox-min-tier", "", "Minimum sandbox tier: "+strings.Join(wasteland.ValidSandboxTiers(), ", "))
+	wlPostCmd.Flags().BoolVar(&wlPostStrict, "strict", false, "Fail instead of warning when --project is not a known rig")
+	wlPostCmd.Flags().BoolVar(&wlPostAllowExt, "allow-external", false, "Allow a --project that is not a rig in this town")
+	wlPostCmd.Flags().BoolVarP(&wlPostInteractive, "interactive", "i", false, "Prompt for each field and confirm before posting")
+	wlPostCmd.Flags().BoolVar(&wlPostDryRun, "dry-run", false, "Validate and preview the item without posting")
+	wlPostCmd.Flags().BoolVar(&wlPostJSON, "json", false, "Output the posted item as JSON")
+	wlPostCmd.Flags().BoolVarP(&wlPostEdit, "edit", "e", false, "Write the description in $VISUAL/$EDITOR")
+	wlPostCmd.Flags().BoolVar(&wlPostServer, "server", false, "Post through the town's Dolt SQL server, starting it (and leaving it running) if needed")
+	wlPostCmd.Flags().BoolVar(&wlPostCheckRemote, "check-remote", false, "Check that your DoltHub commons fork is reachable before posting")
 
 	wlCmd.AddCommand(wlPostCmd)
 }
 
 func runWlPost(cmd *cobra.Command, args []string) error {
-	townRoot, err := workspace.FindFromCwdOrError()
+	townRoot, err := wlTownRoot()
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return err
+	}
+	if wlPostJSON && wlPostInteractive {
+		return fmt.Errorf("--json cannot be combined with --interactive")
+	}
+	if wlPostEdit && wlPostDescription != "" {
+		return fmt.Errorf("--edit cannot be combined with --description")
+	}
+
+	var wlCfg *wasteland.Config
+	if !wlPostDryRun {
+		wlCfg, err = wasteland.LoadConfig(townRoot)
+		if err != nil {
+			return fmt.Errorf("loading wasteland config: %w", err)
+		}
+		// Check the fork before the user invests in building an item.
+		if wlPostCheckRemote {
+			if err := wasteland.PreflightCommons(wlCfg.LocalDir); err != nil {
+				return fmt.Errorf("commons preflight failed: %w", err)
+			}
+		}
+	}
+
+	knownRigs := loadKnownRigNames(townRoot)
+
+	var reader *bufio.Reader
+	if wlPostInteractive {
+		if !isStdinTerminal() {
+			return fmt.Errorf("--interactive needs a terminal on stdin; pass --title and the other flags instead")
+		}
+		reader = bufio.NewReader(os.Stdin)
+		answers, err := promptWLPostItem(reader, os.Stdout, knownRigs)
+		if err != nil {
+			return err
+		}
+		wlPostTitle = answers.Title
+		wlPostProject = answers.Project
+		wlPostType = answers.Type
+		wlPostPriority = strconv.Itoa(answers.Priority)
+		wlPostEffort = answers.EffortLevel
+		wlPostTags = strings.Join(answers.Tags, ",")
+	} else if wlPostTitle == "" {
+		return fmt.Errorf(`required flag "title" not set (or use --interactive)`)
+	}
+
+	if wlPostEdit {
+		wlPostDescription, err = editWLPostDescription(wlPostTitle)
+		if err != nil {
+			return err
+		}
 	}
 
 	var tags []string
@@ -69,66 +169,213 @@ func runWlPost(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	validTypes := map[string]bool{
-		"feature": true, "bug": true, "design": true, "rfc": true, "docs": true,
+	if wlPostType != "" && !slices.Contains(wasteland.WantedTypes, wlPostType) {
+		return fmt.Errorf("invalid type %q: must be one of %s", wlPostType, strings.Join(wasteland.WantedTypes, ", "))
 	}
-	if wlPostType != "" && !validTypes[wlPostType] {
-		return fmt.Errorf("invalid type %q: must be one of feature, bug, design, rfc, docs", wlPostType)
+
+	if !slices.Contains(wasteland.EffortLevels, wlPostEffort) {
+		return fmt.Errorf("invalid effort %q: must be one of %s", wlPostEffort, strings.Join(wasteland.EffortLevels, ", "))
 	}
 
-	validEfforts := map[string]bool{
-		"trivial": true, "small": true, "medium": true, "large": true, "epic": true,
+	if wlPostMinTier != "" && !wasteland.IsValidTier(wlPostMinTier) {
+		return fmt.Errorf("invalid sandbox tier %q: must be one of %s", wlPostMinTier, strings.Join(wasteland.ValidSandboxTiers(), ", "))
 	}
-	if !validEfforts[wlPostEffort] {
-		return fmt.Errorf("invalid effort %q: must be one of trivial, small, medium, large, epic", wlPostEffort)
+
+	priority, err := wasteland.PriorityForLabel(wlPostPriority)
+	if err != nil {
+		return err
 	}
 
-	if wlPostPriority < 0 || wlPostPriority > 4 {
-		return fmt.Errorf("invalid priority %d: must be 0-4", wlPostPriority)
+	warning, err := checkWLPostProject(wlPostProject, knownRigs, wlPostStrict, wlPostAllowExt)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		// Keep stdout clean for --json.
+		out := os.Stdout
+		if wlPostJSON {
+			out = os.Stderr
+		}
+		fmt.Fprintf(out, "%s %s\n", style.Dim.Render("⚠"), warning)
+	}
+
+	item := &doltserver.WantedItem{
+		Title:          wlPostTitle,
+		Description:    wlPostDescription,
+		Project:        wlPostProject,
+		Type:           wlPostType,
+		Priority:       priority,
+		Tags:           tags,
+		EffortLevel:    wlPostEffort,
+		SandboxMinTier: wlPostMinTier,
+	}
+
+	if wlPostDryRun {
+		if wlPostJSON {
+			// Preview the row and SQL with a sample ID and, when joined, the real poster.
+			item.ID = doltserver.GenerateWantedID(item.Title)
+			if cfg, err := wasteland.LoadConfig(townRoot); err == nil {
+				item.PostedBy = cfg.RigHandle
+			}
+			script, err := doltserver.InsertWantedSQL(item)
+			if err != nil {
+				return err
+			}
+			return writeWLPostJSON(os.Stdout, item, script)
+		}
+		fmt.Printf("%s\n", style.Bold.Render("Would post (dry run):"))
+		printWLPostSummary(os.Stdout, item)
+		return nil
+	}
+
+	if wlPostInteractive {
+		fmt.Printf("\n%s\n", style.Bold.Render("About to post:"))
+		printWLPostSummary(os.Stdout, item)
+		ok, err := promptWLConfirm(reader, os.Stdout, "Post this item?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted; nothing posted.")
+			return nil
+		}
+	}
+
+	if wlPostServer {
+		session, err := doltserver.StartSession(townRoot)
+		if err != nil {
+			return fmt.Errorf("starting Dolt session: %w", err)
+		}
+		if session.Started {
+			fmt.Fprintln(os.Stderr, "Started the town's Dolt server; it keeps running for other agents (gt dolt stop to stop it).")
+		}
 	}
 
 	if err := doltserver.EnsureWLCommons(townRoot); err != nil {
 		return fmt.Errorf("ensuring wl-commons database: %w", err)
 	}
 
-	wlCfg, err := wasteland.LoadConfig(townRoot)
-	if err != nil {
-		return fmt.Errorf("loading wasteland config: %w", err)
+	item.ID = doltserver.GenerateWantedID(item.Title)
+	item.PostedBy = wlCfg.RigHandle
+
+	if err := insertWLWanted(townRoot, item); err != nil {
+		return fmt.Errorf("posting wanted item: %w", err)
 	}
+	recordWLFederation(townRoot, wasteland.FederationPost, item.ID, item.PostedBy)
 
-	id := doltserver.GenerateWantedID(wlPostTitle)
-	handle := wlCfg.RigHandle
+	if wlPostJSON {
+		return writeWLPostJSON(os.Stdout, item, "")
+	}
 
-	item := &doltserver.WantedItem{
-		ID:          id,
-		Title:       wlPostTitle,
-		Description: wlPostDescription,
-		Project:     wlPostProject,
-		Type:        wlPostType,
-		Priority:    wlPostPriority,
-		Tags:        tags,
-		PostedBy:    handle,
-		EffortLevel: wlPostEffort,
-	}
-
-	if err := doltserver.InsertWanted(townRoot, item); err != nil {
-		return fmt.Errorf("posting wanted item: %w", err)
+	fmt.Printf("%s Posted wanted item: %s\n", style.Bold.Render("✓"), style.Bold.Render(item.ID))
+	printWLPostSummary(os.Stdout, item)
+
+	return nil
+}
+
+// insertWLWanted inserts item into the local commons. A commons created
+// before the dedup_key column is migrated and the insert retried once.
+func insertWLWanted(townRoot string, item *doltserver.WantedItem) error {
+	err := doltserver.InsertWanted(townRoot, item)
+	if !errors.Is(err, doltserver.ErrWLCommonsSchemaOutdated) {
+		return err
 	}
+	from, to, migrateErr := wasteland.MigrateLocalCommons(townRoot)
+	if migrateErr != nil {
+		return fmt.Errorf("%w; migrating it failed: %v", err, migrateErr)
+	}
+	progressf("%s Migrated local wl-commons schema %s -> %s\n", style.Dim.Render("⚠"), from, to)
+	return doltserver.InsertWanted(townRoot, item)
+}
+
+// wlPostResult is the --json output of gt wl post. SQL is set only for a
+// dry run.
+type wlPostResult struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Project string `json:"project"`
+	Status  string `json:"status"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+	SQL     string `json:"sql,omitempty"`
+}
 
-	fmt.Printf("%s Posted wanted item: %s\n", style.Bold.Render("✓"), style.Bold.Render(id))
-	fmt.Printf("  Title:    %s\n", wlPostTitle)
-	if wlPostProject != "" {
-		fmt.Printf("  Project:  %s\n", wlPostProject)
+// writeWLPostJSON writes item as a wlPostResult. A non-empty script marks
+// the output as a dry run.
+func writeWLPostJSON(w io.Writer, item *doltserver.WantedItem, script string) error {
+	status := item.Status
+	if status == "" {
+		status = "open"
 	}
-	if wlPostType != "" {
-		fmt.Printf("  Type:     %s\n", wlPostType)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(wlPostResult{
+		ID:      item.ID,
+		Title:   item.Title,
+		Project: item.Project,
+		Status:  status,
+		DryRun:  script != "",
+		SQL:     script,
+	})
+}
+
+// printWLPostSummary prints a wanted item's fields, one per line.
+func printWLPostSummary(w io.Writer, item *doltserver.WantedItem) {
+	fmt.Fprintf(w, "  Title:    %s\n", item.Title)
+	if item.Project != "" {
+		fmt.Fprintf(w, "  Project:  %s\n", item.Project)
+	}
+	if item.Type != "" {
+		fmt.Fprintf(w, "  Type:     %s\n", item.Type)
+	}
+	fmt.Fprintf(w, "  Priority: %d (%s)\n", item.Priority, wasteland.LabelForPriority(item.Priority))
+	fmt.Fprintf(w, "  Effort:   %s\n", item.EffortLevel)
+	if len(item.Tags) > 0 {
+		fmt.Fprintf(w, "  Tags:     %s\n", strings.Join(item.Tags, ", "))
+	}
+	if item.SandboxMinTier != "" {
+		fmt.Fprintf(w, "  Sandbox:  %s\n", item.SandboxMinTier)
 	}
-	fmt.Printf("  Priority: %d\n", wlPostPriority)
-	fmt.Printf("  Effort:   %s\n", wlPostEffort)
-	if len(tags) > 0 {
-		fmt.Printf("  Tags:     %s\n", strings.Join(tags, ", "))
+	if item.PostedBy != "" {
+		fmt.Fprintf(w, "  Posted by: %s\n", item.PostedBy)
 	}
-	fmt.Printf("  Posted by: %s\n", handle)
+}
 
-	return nil
+// loadKnownRigNames returns the names of the rigs registered in the town.
+// Returns nil if the rigs registry cannot be read.
+func loadKnownRigNames(townRoot string) []string {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(rigsConfig.Rigs))
+	for name := range rigsConfig.Rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkWLPostProject validates a wanted item's project against the town's rigs.
+// An empty project or a known rig passes. An unknown project returns a warning,
+// or an error in strict mode, unless allowExternal permits federation projects
+// that are not local rigs.
+func checkWLPostProject(project string, knownRigs []string, strict, allowExternal bool) (string, error) {
+	if project == "" || allowExternal {
+		return "", nil
+	}
+	for _, rig := range knownRigs {
+		if rig == project {
+			return "", nil
+		}
+	}
+
+	known := "none registered"
+	if len(knownRigs) > 0 {
+		known = strings.Join(knownRigs, ", ")
+	}
+	msg := fmt.Sprintf("project %q is not a known rig (known: %s); use --allow-external for federation projects", project, known)
+	if strict {
+		return "", errors.New(msg)
+	}
+	return msg, nil
 }
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// wlPostEditScissors separates the description from the editor help, like
+// git's commit scissors. Everything from it down is dropped, so the
+// description itself may use Markdown headings.
+const wlPostEditScissors = "# ------------------------ >8 ------------------------"
+
+// wlPostEditTemplate is the editor's starting content for wl post --edit,
+// formatted with the item title.
+const wlPostEditTemplate = `
+` + wlPostEditScissors + `
+# Do not modify or remove the line above.
+# Describe the wanted item %q above it; everything below it is ignored.
+# Leave the description empty, or quit without saving, to abort the post.
+`
+
+var (
+	errWLPostEditEmpty     = errors.New("description is empty, aborting post")
+	errWLPostEditUnchanged = errors.New("description left unchanged, aborting post")
+)
+
+// runWLEditor opens the user's editor on a temporary file holding initial
+// and returns the file's content once the editor exits. Var so tests can
+// stub it.
+var runWLEditor = func(initial string) (string, error) {
+	f, err := os.CreateTemp("", "wl-post-*.md")
+	if err != nil {
+		return "", fmt.Errorf("creating description file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", fmt.Errorf("writing description file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("writing description file: %w", err)
+	}
+
+	// The editor may carry arguments, e.g. EDITOR="code --wait".
+	editor := strings.Fields(wlEditor())
+	editorCmd := exec.Command(editor[0], append(editor[1:], path)...) //nolint:gosec // G204: the user's own editor
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return "", fmt.Errorf("running editor %s: %w", editor[0], err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading description file: %w", err)
+	}
+	return string(data), nil
+}
+
+// wlEditor returns the editor to run: $VISUAL, then $EDITOR, then vi.
+func wlEditor() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// editWLPostDescription opens the editor on the description template for
+// title and returns the description written.
+func editWLPostDescription(title string) (string, error) {
+	template := fmt.Sprintf(wlPostEditTemplate, title)
+	edited, err := runWLEditor(template)
+	if err != nil {
+		return "", err
+	}
+	return wlPostDescriptionFromEdit(template, edited)
+}
+
+// wlPostDescriptionFromEdit turns the saved editor content into a
+// description, dropping everything from the scissors line down and
+// surrounding blank lines. It fails with errWLPostEditUnchanged if the
+// template was saved as is and errWLPostEditEmpty if nothing is left.
+func wlPostDescriptionFromEdit(template, edited string) (string, error) {
+	if edited == template {
+		return "", errWLPostEditUnchanged
+	}
+
+	var lines []string
+	for _, line := range strings.Split(edited, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == wlPostEditScissors {
+			break
+		}
+		lines = append(lines, line)
+	}
+	description := strings.TrimSpace(strings.Join(lines, "\n"))
+	if description == "" {
+		return "", errWLPostEditEmpty
+	}
+	return description, nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWLPostDescriptionFromEdit(t *testing.T) {
+	template := fmt.Sprintf(wlPostEditTemplate, "Fix auth")
+	tests := []struct {
+		name    string
+		edited  string
+		want    string
+		wantErr error
+	}{
+		{"unchanged", template, "", errWLPostEditUnchanged},
+		{"emptied", "", "", errWLPostEditEmpty},
+		{"only help", "\n\n" + template, "", errWLPostEditEmpty},
+		{"written above template", "Tokens expire early.\n\nSee #123 for logs.\n" + template,
+			"Tokens expire early.\n\nSee #123 for logs.", nil},
+		{"markdown headings kept", "# Problem\nTokens expire.\n\n## Fix\nRefresh them.  \n" + template,
+			"# Problem\nTokens expire.\n\n## Fix\nRefresh them.", nil},
+		{"text below scissors dropped", "Real text\n" + wlPostEditScissors + "\nscratch notes\n", "Real text", nil},
+		{"scissors removed", "Real text\n", "Real text", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wlPostDescriptionFromEdit(template, tt.edited)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("description = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditWLPostDescription_UsesEditor(t *testing.T) {
+	orig := runWLEditor
+	t.Cleanup(func() { runWLEditor = orig })
+
+	var shown string
+	runWLEditor = func(initial string) (string, error) {
+		shown = initial
+		return "Rotate the keys.\n" + initial, nil
+	}
+
+	got, err := editWLPostDescription("Rotate keys")
+	if err != nil {
+		t.Fatalf("editWLPostDescription() error: %v", err)
+	}
+	if got != "Rotate the keys." {
+		t.Errorf("description = %q, want %q", got, "Rotate the keys.")
+	}
+	if want := fmt.Sprintf(wlPostEditTemplate, "Rotate keys"); shown != want {
+		t.Errorf("editor opened with %q, want %q", shown, want)
+	}
+}
+
+func TestWLEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := wlEditor(); got != "vi" {
+		t.Errorf("wlEditor() = %q, want vi fallback", got)
+	}
+	t.Setenv("EDITOR", "nano")
+	if got := wlEditor(); got != "nano" {
+		t.Errorf("wlEditor() = %q, want $EDITOR", got)
+	}
+	t.Setenv("VISUAL", "code --wait")
+	if got := wlEditor(); got != "code --wait" {
+		t.Errorf("wlEditor() = %q, want $VISUAL to win", got)
+	}
+}
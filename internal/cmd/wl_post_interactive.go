@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/wasteland"
+)
+
+// promptWLPostItem asks for the fields of a wanted item on r, writing prompts
+// to w. Invalid answers are re-prompted; running out of input is an error.
+// The returned item has no ID or PostedBy, which are filled in at post time.
+func promptWLPostItem(r *bufio.Reader, w io.Writer, knownRigs []string) (*doltserver.WantedItem, error) {
+	item := &doltserver.WantedItem{}
+	var err error
+
+	item.Title, err = promptWLField(r, w, "Title", "", func(s string) error {
+		if s == "" {
+			return errors.New("title is required")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(knownRigs) > 0 {
+		fmt.Fprintf(w, "Known rigs: %s\n", strings.Join(knownRigs, ", "))
+	}
+	item.Project, err = promptWLField(r, w, "Project (optional)", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Type, err = promptWLField(r, w, "Type ("+strings.Join(wasteland.WantedTypes, ", ")+")", "", func(s string) error {
+		if s != "" && !slices.Contains(wasteland.WantedTypes, s) {
+			return fmt.Errorf("invalid type %q", s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := promptWLField(r, w, "Priority ("+strings.Join(wasteland.PriorityLabels, ", ")+" or 0-4)", "medium", func(s string) error {
+		_, err := wasteland.PriorityForLabel(s)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	item.Priority, _ = wasteland.PriorityForLabel(priority)
+
+	item.EffortLevel, err = promptWLField(r, w, "Effort ("+strings.Join(wasteland.EffortLevels, ", ")+")", "medium", func(s string) error {
+		if !slices.Contains(wasteland.EffortLevels, s) {
+			return fmt.Errorf("invalid effort %q", s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := promptWLField(r, w, "Tags (comma-separated, optional)", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range strings.Split(tags, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			item.Tags = append(item.Tags, t)
+		}
+	}
+
+	return item, nil
+}
+
+// promptWLField prompts for one value. An empty answer selects def. If
+// validate rejects the answer, the error is shown and the prompt repeats.
+func promptWLField(r *bufio.Reader, w io.Writer, label, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(w, "%s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(w, "%s: ", label)
+		}
+
+		line, err := r.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return "", fmt.Errorf("input ended while prompting for %s", strings.ToLower(label))
+			}
+			return "", fmt.Errorf("reading %s: %w", strings.ToLower(label), err)
+		}
+
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+		if validate != nil {
+			if verr := validate(answer); verr != nil {
+				fmt.Fprintf(w, "  %v\n", verr)
+				if err == io.EOF {
+					return "", fmt.Errorf("input ended while prompting for %s", strings.ToLower(label))
+				}
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// promptWLConfirm asks a yes/no question on r, defaulting to no.
+func promptWLConfirm(r *bufio.Reader, w io.Writer, question string) (bool, error) {
+	answer, err := promptWLField(r, w, question+" [y/N]", "", nil)
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
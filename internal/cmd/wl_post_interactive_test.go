@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+func TestPromptWLPostItem(t *testing.T) {
+	input := strings.Join([]string{
+		"Fix auth bug",
+		"gastown",
+		"bug",
+		"high",
+		"",
+		"go, auth,,",
+	}, "\n") + "\n"
+	var out bytes.Buffer
+
+	item, err := promptWLPostItem(bufio.NewReader(strings.NewReader(input)), &out, []string{"beads", "gastown"})
+	if err != nil {
+		t.Fatalf("promptWLPostItem() error: %v", err)
+	}
+
+	want := &doltserver.WantedItem{
+		Title:       "Fix auth bug",
+		Project:     "gastown",
+		Type:        "bug",
+		Priority:    1,
+		EffortLevel: "medium",
+		Tags:        []string{"go", "auth"},
+	}
+	if !reflect.DeepEqual(item, want) {
+		t.Errorf("promptWLPostItem() = %+v, want %+v", item, want)
+	}
+	if !strings.Contains(out.String(), "Known rigs: beads, gastown") {
+		t.Errorf("prompts should suggest known rigs, got:\n%s", out.String())
+	}
+}
+
+func TestPromptWLPostItem_Reprompts(t *testing.T) {
+	input := strings.Join([]string{
+		"",         // title required
+		"Add docs", // title
+		"",         // project
+		"chore",    // invalid type
+		"docs",     // type
+		"urgent",   // invalid priority
+		"4",        // priority
+		"enormous", // invalid effort
+		"small",    // effort
+		"",         // tags
+	}, "\n") + "\n"
+	var out bytes.Buffer
+
+	item, err := promptWLPostItem(bufio.NewReader(strings.NewReader(input)), &out, nil)
+	if err != nil {
+		t.Fatalf("promptWLPostItem() error: %v", err)
+	}
+	if item.Title != "Add docs" || item.Type != "docs" || item.Priority != 4 || item.EffortLevel != "small" {
+		t.Errorf("promptWLPostItem() = %+v", item)
+	}
+	if item.Project != "" || item.Tags != nil {
+		t.Errorf("optional fields should stay empty, got project=%q tags=%v", item.Project, item.Tags)
+	}
+	for _, msg := range []string{"title is required", `invalid type "chore"`, `invalid priority "urgent"`, `invalid effort "enormous"`} {
+		if !strings.Contains(out.String(), msg) {
+			t.Errorf("output missing %q:\n%s", msg, out.String())
+		}
+	}
+}
+
+func TestPromptWLPostItem_InputEnds(t *testing.T) {
+	for _, input := range []string{"", "Title only\n", "\n\n"} {
+		_, err := promptWLPostItem(bufio.NewReader(strings.NewReader(input)), &bytes.Buffer{}, nil)
+		if err == nil || !strings.Contains(err.Error(), "input ended") {
+			t.Errorf("input %q: error = %v, want input ended", input, err)
+		}
+	}
+}
+
+func TestPromptWLConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"y", true},
+	}
+	for _, tt := range tests {
+		got, err := promptWLConfirm(bufio.NewReader(strings.NewReader(tt.input)), &bytes.Buffer{}, "Post?")
+		if err != nil {
+			t.Errorf("promptWLConfirm(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("promptWLConfirm(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+func TestCheckWLPostProject(t *testing.T) {
+	known := []string{"beads", "gastown"}
+
+	tests := []struct {
+		name          string
+		project       string
+		strict        bool
+		allowExternal bool
+		wantWarning   bool
+		wantErr       bool
+	}{
+		{name: "empty project", project: ""},
+		{name: "known rig", project: "gastown"},
+		{name: "known rig strict", project: "beads", strict: true},
+		{name: "unknown warns", project: "gastwon", wantWarning: true},
+		{name: "unknown strict errors", project: "gastwon", strict: true, wantErr: true},
+		{name: "unknown allow external", project: "hop", allowExternal: true},
+		{name: "unknown allow external strict", project: "hop", strict: true, allowExternal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning, err := checkWLPostProject(tt.project, known, tt.strict, tt.allowExternal)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("warning = %q, wantWarning %v", warning, tt.wantWarning)
+			}
+			if tt.wantWarning && !strings.Contains(warning, "gastown") {
+				t.Errorf("warning %q should list known rigs", warning)
+			}
+		})
+	}
+}
+
+func TestCheckWLPostProject_NoKnownRigs(t *testing.T) {
+	warning, err := checkWLPostProject("gastown", nil, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(warning, "none registered") {
+		t.Errorf("warning = %q, want mention of no registered rigs", warning)
+	}
+}
+
+func TestWriteWLPostJSON(t *testing.T) {
+	item := &doltserver.WantedItem{ID: "w-abc123", Title: "Fix auth", Project: "gastown", Priority: 1, EffortLevel: "small"}
+
+	decode := func(t *testing.T, script string) map[string]any {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := writeWLPostJSON(&buf, item, script); err != nil {
+			t.Fatalf("writeWLPostJSON() error: %v", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+		}
+		return got
+	}
+
+	t.Run("posted", func(t *testing.T) {
+		got := decode(t, "")
+		want := map[string]any{"id": "w-abc123", "title": "Fix auth", "project": "gastown", "status": "open"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("JSON = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		script, err := doltserver.InsertWantedSQL(item)
+		if err != nil {
+			t.Fatalf("InsertWantedSQL() error: %v", err)
+		}
+		got := decode(t, script)
+		if got["id"] != "w-abc123" || got["status"] != "open" || got["dry_run"] != true {
+			t.Errorf("JSON = %v, want the would-be row marked as a dry run", got)
+		}
+		sql, _ := got["sql"].(string)
+		if !strings.Contains(sql, "INSERT INTO wanted") || !strings.Contains(sql, "'w-abc123'") {
+			t.Errorf("sql = %q, want the insert for w-abc123", sql)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wasteland"
+)
+
+var wlShowCmd = &cobra.Command{
+	Use:   "show <wanted-id>",
+	Short: "Show a wanted item",
+	Long: `Show every field of a wanted item in the local wl-commons database.
+
+Examples:
+  gt wl show w-abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWlShow,
+}
+
+func init() {
+	wlCmd.AddCommand(wlShowCmd)
+}
+
+func runWlShow(cmd *cobra.Command, args []string) error {
+	wantedID := args[0]
+
+	townRoot, err := wlTownRoot()
+	if err != nil {
+		return err
+	}
+
+	if !doltserver.DatabaseExists(townRoot, doltserver.WLCommonsDBName()) {
+		return fmt.Errorf("database %q not found\nJoin a wasteland first with: gt wl join <org/db>", doltserver.WLCommonsDBName())
+	}
+
+	output, err := doltserver.QueryWantedCSV(townRoot, wantedID, wasteland.WantedColumns)
+	if err != nil {
+		return fmt.Errorf("querying wanted item: %w", err)
+	}
+	item, err := parseWLShowCSV(output)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("wanted item %q not found", wantedID)
+	}
+
+	printWLShowItem(item)
+	return nil
+}
+
+// parseWLShowCSV decodes the CSV output of a single-item wanted query,
+// returning nil if it has no row.
+func parseWLShowCSV(output string) (*doltserver.WantedItem, error) {
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing wanted item: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, nil
+	}
+	return wasteland.ScanWantedRow(records[0], records[1])
+}
+
+func printWLShowItem(item *doltserver.WantedItem) {
+	fmt.Printf("%s %s\n", style.Bold.Render(item.ID), item.Title)
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Printf("  %-14s %s\n", name+":", value)
+		}
+	}
+	field("Status", item.Status)
+	field("Project", item.Project)
+	field("Type", item.Type)
+	field("Priority", wlFormatPriority(fmt.Sprint(item.Priority)))
+	field("Effort", item.EffortLevel)
+	field("Posted by", item.PostedBy)
+	field("Claimed by", item.ClaimedBy)
+	field("Tags", strings.Join(item.Tags, ", "))
+	if item.SandboxRequired {
+		field("Sandbox", "required")
+	}
+	field("Min tier", item.SandboxMinTier)
+	if item.Description != "" {
+		fmt.Printf("\n%s\n", item.Description)
+	}
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWLShowCSV(t *testing.T) {
+	output := "id,title,description,tags,sandbox_required\n" +
+		"w-abc,Fix auth,\"Tokens expire, then\nrefresh fails\",\"[\"\"go\"\",\"\"auth\"\"]\",1\n"
+
+	item, err := parseWLShowCSV(output)
+	if err != nil {
+		t.Fatalf("parseWLShowCSV() error: %v", err)
+	}
+	if item.ID != "w-abc" || item.Description != "Tokens expire, then\nrefresh fails" {
+		t.Errorf("item = %+v, want multi-line description intact", item)
+	}
+	if !reflect.DeepEqual(item.Tags, []string{"go", "auth"}) || !item.SandboxRequired {
+		t.Errorf("Tags = %v, SandboxRequired = %v; want [go auth], true", item.Tags, item.SandboxRequired)
+	}
+
+	if item, err := parseWLShowCSV("id,title\n"); err != nil || item != nil {
+		t.Errorf("parseWLShowCSV(header only) = %v, %v; want nil, nil", item, err)
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/wasteland"
+)
+
+var wlStatsJSON bool
+
+var wlStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize the wanted board of the commons",
+	Args:  cobra.NoArgs,
+	RunE:  runWLStats,
+	Long: `Summarize the Wasteland wanted board: counts by status, project, and
+type, plus the most active posters.
+
+Uses your local wl-commons fork when one exists (created by gt wl join);
+otherwise clones the upstream commons to a temporary directory. Passing
+--commons or --commons-fallback always clones, skipping the local fork.
+
+EXAMPLES:
+  gt wl stats                             # Summary tables
+  gt wl stats --json                      # JSON output
+  gt wl stats --commons myorg/wl-commons  # Stats for another commons`,
+}
+
+func init() {
+	wlStatsCmd.Flags().BoolVar(&wlStatsJSON, "json", false, "Output as JSON")
+	addWLCommonsFlags(wlStatsCmd)
+
+	wlCmd.AddCommand(wlStatsCmd)
+}
+
+func runWLStats(cmd *cobra.Command, args []string) error {
+	townRoot, err := wlTownRoot()
+	if err != nil {
+		return err
+	}
+
+	dbDir := wlStatsLocalFork(townRoot)
+
+	if dbDir == "" {
+		dbDir, err = cloneUpstreamCommons(townRoot, wlStatsJSON)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(filepath.Dir(dbDir))
+	}
+
+	stats, err := wasteland.CommonsStats(dbDir)
+	if err != nil {
+		return fmt.Errorf("computing commons stats: %w", err)
+	}
+
+	if wlStatsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+
+	fmt.Printf("%s Wanted items: %d\n", style.Bold.Render("Commons"), stats.Total)
+	renderWLStatsCounts("By status", "STATUS", stats.ByStatus)
+	renderWLStatsCounts("By project", "PROJECT", stats.ByProject)
+	renderWLStatsCounts("By type", "TYPE", stats.ByType)
+	renderWLStatsCounts("Top posters", "POSTED BY", stats.TopPosters)
+	return nil
+}
+
+// wlStatsLocalFork returns the town's local wl-commons fork, or "" when
+// there is none or --commons or --commons-fallback names a remote to read
+// instead.
+func wlStatsLocalFork(townRoot string) string {
+	if wlCommons != "" || len(wlCommonsFallbacks) > 0 {
+		return ""
+	}
+	if cfg, err := wasteland.LoadConfig(townRoot); err == nil && cfg.LocalDir != "" {
+		return cfg.LocalDir
+	}
+	return findWLCommonsFork(townRoot)
+}
+
+// cloneUpstreamCommons clones the commons named by --commons or the town's
+// commons config, falling back to each mirror from --commons-fallback or
+// the config in order, and returns the clone's directory. The config's
+// branch, if set, is cloned instead of the default branch. Remove
+// filepath.Dir of it when done. Progress is printed unless quiet. An empty
+// townRoot skips the town's config.
+func cloneUpstreamCommons(townRoot string, quiet bool) (string, error) {
+	if _, err := exec.LookPath("dolt"); err != nil {
+		return "", fmt.Errorf("dolt not found in PATH — install from https://docs.dolthub.com/introduction/installation")
+	}
+
+	cfg, err := wasteland.LoadCommonsConfig(townRoot)
+	if err != nil {
+		return "", err
+	}
+	remote := cfg.CommonsRef(wlCommons)
+	refs := append([]string{remote}, cfg.FallbackRefs(wlCommonsFallbacks)...)
+
+	spinner := style.NewSpinner(io.Discard, "")
+	if !quiet {
+		spinner = startWLSpinner(fmt.Sprintf("Cloning %s...", style.Bold.Render(remote)))
+	}
+	defer spinner.Stop()
+
+	dbDir, used, err := wasteland.CloneCommonsWithFallbacks(refs, cfg.Branch)
+	if err != nil {
+		err = fmt.Errorf("cloning %s: %w\nEnsure the database exists on DoltHub: https://www.dolthub.com/%s", remote, err, remote)
+		if cfg.AuthHint != "" {
+			err = fmt.Errorf("%w\n%s", err, cfg.AuthHint)
+		}
+		return "", err
+	}
+	spinner.Stop()
+	if used != remote && !quiet {
+		progressf("%s %s unavailable; reading from fallback %s\n", style.Dim.Render("⚠"), remote, style.Bold.Render(used))
+	}
+	return dbDir, nil
+}
+
+func renderWLStatsCounts(title, column string, counts []wasteland.Count) {
+	fmt.Printf("\n%s\n", style.Bold.Render(title))
+	if len(counts) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(none)"))
+		return
+	}
+
+	tbl := style.NewTable(
+		style.Column{Name: column, Width: 24},
+		style.Column{Name: "COUNT", Width: 6, Align: style.AlignRight},
+	)
+	for _, c := range counts {
+		tbl.AddRow(c.Name, strconv.Itoa(c.Count))
+	}
+	fmt.Print(tbl.Render())
+}
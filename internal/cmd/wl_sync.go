@@ -9,10 +9,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/wasteland"
-	"github.com/steveyegge/gastown/internal/workspace"
 )
 
-var wlSyncDryRun bool
+var (
+	wlSyncDryRun bool
+	wlSyncPush   bool
+)
 
 var wlSyncCmd = &cobra.Command{
 	Use:   "sync",
@@ -24,21 +26,28 @@ var wlSyncCmd = &cobra.Command{
 If you have a local fork of wl-commons (created by gt wl join), this pulls
 the latest changes from upstream.
 
+With --push, local commits (such as posts made while offline) are then
+pushed to your fork on DoltHub, along with the upstream commits just
+pulled. Local commits are counted before the pull, so the summary tells
+the two apart. If the fork has moved on, it is pulled and the push retried.
+
 EXAMPLES:
   gt wl sync                # Pull upstream changes
+  gt wl sync --push         # Pull upstream, then push local commits
   gt wl sync --dry-run      # Show what would change`,
 }
 
 func init() {
 	wlSyncCmd.Flags().BoolVar(&wlSyncDryRun, "dry-run", false, "Show what would change without pulling")
+	wlSyncCmd.Flags().BoolVar(&wlSyncPush, "push", false, "Push local commits to your fork after pulling")
 
 	wlCmd.AddCommand(wlSyncCmd)
 }
 
 func runWLSync(cmd *cobra.Command, args []string) error {
-	townRoot, err := workspace.FindFromCwdOrError()
+	townRoot, err := wlTownRoot()
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return err
 	}
 
 	doltPath, err := exec.LookPath("dolt")
@@ -61,10 +70,10 @@ func runWLSync(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no local wl-commons fork found\n\nJoin a wasteland first: gt wl join <org/db>")
 	}
 
-	fmt.Printf("Local fork: %s\n", style.Dim.Render(forkDir))
+	progressf("Local fork: %s\n", style.Dim.Render(forkDir))
 
 	if wlSyncDryRun {
-		fmt.Printf("\n%s Dry run — checking upstream for changes...\n", style.Bold.Render("~"))
+		progressf("\n%s Dry run — checking upstream for changes...\n", style.Bold.Render("~"))
 
 		fetchCmd := exec.Command(doltPath, "fetch", "upstream")
 		fetchCmd.Dir = forkDir
@@ -80,10 +89,27 @@ func runWLSync(cmd *cobra.Command, args []string) error {
 		if err := diffCmd.Run(); err != nil {
 			fmt.Printf("%s Already up to date.\n", style.Bold.Render("✓"))
 		}
+
+		if wlSyncPush {
+			ahead, err := wasteland.LocalCommitsAhead(forkDir)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Would push %d local commit(s) to origin\n", ahead)
+		}
 		return nil
 	}
 
-	fmt.Printf("\nPulling from upstream...\n")
+	// Count local commits before the upstream pull, which would otherwise
+	// add upstream's new commits to the count.
+	localAhead := 0
+	if wlSyncPush {
+		if localAhead, err = wasteland.LocalCommitsAhead(forkDir); err != nil {
+			return err
+		}
+	}
+
+	progressf("\nPulling from upstream...\n")
 
 	pullCmd := exec.Command(doltPath, "pull", "upstream", "main")
 	pullCmd.Dir = forkDir
@@ -95,6 +121,22 @@ func runWLSync(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("\n%s Synced with upstream\n", style.Bold.Render("✓"))
 
+	if wlSyncPush {
+		progressf("\nPushing local commits to origin...\n")
+		pushed, err := wasteland.PushLocalCommits(forkDir)
+		if err != nil {
+			return err
+		}
+		switch {
+		case pushed == 0:
+			fmt.Printf("%s No local commits to push\n", style.Bold.Render("✓"))
+		case pushed > localAhead:
+			fmt.Printf("%s Pushed %d local commit(s) to origin, plus %d pulled from upstream\n", style.Bold.Render("✓"), localAhead, pushed-localAhead)
+		default:
+			fmt.Printf("%s Pushed %d local commit(s) to origin\n", style.Bold.Render("✓"), pushed)
+		}
+	}
+
 	// Show summary
 	summaryQuery := `SELECT
 		(SELECT COUNT(*) FROM wanted WHERE status = 'open') AS open_wanted,
@@ -1,7 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/wasteland"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 func TestWlCommandRegistered(t *testing.T) {
@@ -46,7 +56,7 @@ func TestWlCommandGroup(t *testing.T) {
 }
 
 func TestWlSubcommands(t *testing.T) {
-	expected := []string{"join", "post", "claim", "done", "browse", "sync"}
+	expected := []string{"join", "post", "claim", "done", "browse", "show", "sync", "stats", "doctor", "log"}
 	for _, name := range expected {
 		found := false
 		for _, c := range wlCmd.Commands() {
@@ -90,3 +100,195 @@ func TestWlSyncNoArgs(t *testing.T) {
 		t.Errorf("sync should accept 0 arguments: %v", err)
 	}
 }
+
+func TestWlStatsNoArgs(t *testing.T) {
+	if err := wlStatsCmd.Args(wlStatsCmd, []string{}); err != nil {
+		t.Errorf("stats should accept 0 arguments: %v", err)
+	}
+	if err := wlStatsCmd.Args(wlStatsCmd, []string{"extra"}); err == nil {
+		t.Error("stats should reject arguments")
+	}
+}
+
+// captureWLProgress sets the quiet flag and redirects progress output to a
+// buffer for the duration of a test.
+func captureWLProgress(t *testing.T, quiet bool) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	origOut, origQuiet := wlProgressOut, wlQuiet
+	wlProgressOut, wlQuiet = &buf, quiet
+	t.Cleanup(func() { wlProgressOut, wlQuiet = origOut, origQuiet })
+	return &buf
+}
+
+func TestWlQuietSuppressesProgress(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt script requires a POSIX shell")
+	}
+	// Fake dolt whose clone creates the target directory.
+	binDir := t.TempDir()
+	script := "#!/bin/sh\n[ \"$1\" = clone ] && mkdir -p \"$3\"\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("GASTOWN_WL_UPSTREAM", "hop/wl-commons")
+
+	tests := []struct {
+		name     string
+		quiet    bool
+		env      string
+		wantText bool
+	}{
+		{"default", false, "", true},
+		{"flag", true, "", false},
+		{"env", false, "1", false},
+		{"env false", false, "0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GASTOWN_QUIET", tt.env)
+			out := captureWLProgress(t, tt.quiet)
+
+			dbDir, err := cloneUpstreamCommons("", false)
+			if err != nil {
+				t.Fatalf("cloneUpstreamCommons() error: %v", err)
+			}
+			defer os.RemoveAll(filepath.Dir(dbDir))
+			if _, err := os.Stat(dbDir); err != nil {
+				t.Errorf("clone result %q missing: %v", dbDir, err)
+			}
+			if got := strings.Contains(out.String(), "Cloning"); got != tt.wantText {
+				t.Errorf("progress output = %q, want progress=%v", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestWlCommandsOutsideWorkspace(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("DOLTHUB_TOKEN", "test-token")
+	t.Setenv("DOLTHUB_ORG", "alice-dev")
+
+	tests := []struct {
+		name string
+		run  func(*cobra.Command, []string) error
+		cmd  *cobra.Command
+		args []string
+	}{
+		{"join", runWlJoin, wlJoinCmd, []string{"steveyegge/wl-commons"}},
+		{"post", runWlPost, wlPostCmd, nil},
+		{"browse", runWLBrowse, wlBrowseCmd, nil},
+		{"claim", runWlClaim, wlClaimCmd, []string{"w-abc"}},
+		{"done", runWlDone, wlDoneCmd, []string{"w-abc"}},
+		{"show", runWlShow, wlShowCmd, []string{"w-abc"}},
+		{"stats", runWLStats, wlStatsCmd, nil},
+		{"sync", runWLSync, wlSyncCmd, nil},
+		{"doctor", runWLDoctor, wlDoctorCmd, nil},
+		{"log", runWLLog, wlLogCmd, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(tt.cmd, tt.args)
+			if !errors.Is(err, workspace.ErrNotInWorkspace) {
+				t.Errorf("wl %s outside a workspace: error = %v, want ErrNotInWorkspace", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestWLStatsLocalForkYieldsToCommonsFlags(t *testing.T) {
+	townRoot := t.TempDir()
+	fork := filepath.Join(townRoot, "wl-commons")
+	if err := os.MkdirAll(filepath.Join(fork, ".dolt"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	origCommons, origFallbacks := wlCommons, wlCommonsFallbacks
+	t.Cleanup(func() { wlCommons, wlCommonsFallbacks = origCommons, origFallbacks })
+
+	wlCommons, wlCommonsFallbacks = "", nil
+	if got := wlStatsLocalFork(townRoot); got != fork {
+		t.Errorf("wlStatsLocalFork() without flags = %q, want %q", got, fork)
+	}
+	wlCommons = "acme/wl-commons"
+	if got := wlStatsLocalFork(townRoot); got != "" {
+		t.Errorf("wlStatsLocalFork() with --commons = %q, want \"\"", got)
+	}
+	wlCommons, wlCommonsFallbacks = "", []string{"mirror/wl-commons"}
+	if got := wlStatsLocalFork(townRoot); got != "" {
+		t.Errorf("wlStatsLocalFork() with --commons-fallback = %q, want \"\"", got)
+	}
+}
+
+func TestCloneUpstreamCommonsNotesFallback(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt script requires a POSIX shell")
+	}
+	// Fake dolt where only the mirror clones.
+	binDir := t.TempDir()
+	script := "#!/bin/sh\n[ \"$2\" = hop/wl-commons ] && exit 1\nmkdir -p \"$3\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("GASTOWN_WL_UPSTREAM", "hop/wl-commons")
+	t.Setenv("GASTOWN_QUIET", "")
+	out := captureWLProgress(t, false)
+
+	orig := wlCommonsFallbacks
+	wlCommonsFallbacks = []string{"mirror/wl-commons"}
+	t.Cleanup(func() { wlCommonsFallbacks = orig })
+
+	dbDir, err := cloneUpstreamCommons("", false)
+	if err != nil {
+		t.Fatalf("cloneUpstreamCommons() error: %v", err)
+	}
+	defer os.RemoveAll(filepath.Dir(dbDir))
+	if !strings.Contains(out.String(), "reading from fallback") || !strings.Contains(out.String(), "mirror/wl-commons") {
+		t.Errorf("progress = %q, want a note naming the fallback", out.String())
+	}
+}
+
+func TestCloneUpstreamCommonsUsesTownConfig(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt script requires a POSIX shell")
+	}
+	// Fake dolt that logs what it clones.
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "clones.log")
+	script := "#!/bin/sh\necho \"$2\" >> '" + logPath + "'\nmkdir -p \"$3\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("GASTOWN_WL_UPSTREAM", "")
+	captureWLProgress(t, true)
+
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "settings"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(wasteland.CommonsConfigPath(townRoot), []byte(`{"commons": "acme/wl-commons"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	origCommons := wlCommons
+	t.Cleanup(func() { wlCommons = origCommons })
+
+	for _, tt := range []struct{ flag, want string }{
+		{"", "acme/wl-commons"},
+		{"flag/wl-commons", "flag/wl-commons"},
+	} {
+		wlCommons = tt.flag
+		os.Remove(logPath)
+		dbDir, err := cloneUpstreamCommons(townRoot, true)
+		if err != nil {
+			t.Fatalf("cloneUpstreamCommons() error: %v", err)
+		}
+		os.RemoveAll(filepath.Dir(dbDir))
+		cloned, _ := os.ReadFile(logPath)
+		if got := strings.TrimSpace(string(cloned)); got != tt.want {
+			t.Errorf("--commons=%q cloned %q, want %q", tt.flag, got, tt.want)
+		}
+	}
+}
@@ -40,6 +40,13 @@ type AgentEnvConfig struct {
 	Agent string
 }
 
+// PolecatActor returns the BD_ACTOR for polecat name in rig.
+// session.ApplyTownSettings replaces it so BD_ACTOR follows the town's
+// assignee format; the default is the explicit "rig/polecats/name" form.
+var PolecatActor = func(rig, name string) string {
+	return fmt.Sprintf("%s/polecats/%s", rig, name)
+}
+
 // AgentEnv returns all environment variables for an agent based on the config.
 // This is the single source of truth for agent environment variables.
 func AgentEnv(cfg AgentEnvConfig) map[string]string {
@@ -80,7 +87,7 @@ func AgentEnv(cfg AgentEnvConfig) map[string]string {
 		env["GT_ROLE"] = fmt.Sprintf("%s/polecats/%s", cfg.Rig, cfg.AgentName)
 		env["GT_RIG"] = cfg.Rig
 		env["GT_POLECAT"] = cfg.AgentName
-		env["BD_ACTOR"] = fmt.Sprintf("%s/polecats/%s", cfg.Rig, cfg.AgentName)
+		env["BD_ACTOR"] = PolecatActor(cfg.Rig, cfg.AgentName)
 		env["GIT_AUTHOR_NAME"] = cfg.AgentName
 		// Disable Dolt auto-commit for polecats. With branch-per-polecat,
 		// individual commits are pointless — all changes merge at gt done time
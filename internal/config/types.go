@@ -65,6 +65,16 @@ type TownSettings struct {
 	// Example: {"mayor": "claude-opus", "witness": "claude-haiku", "polecat": "claude-sonnet"}
 	RoleAgents map[string]string `json:"role_agents,omitempty"`
 
+	// AssigneeFormat is how polecats are written as bead assignees and BD_ACTOR.
+	// Values: "explicit" (default, "rig/polecats/name") or "canonical" ("rig/name").
+	// Pick one per town: assignee lookups match the stored string exactly.
+	AssigneeFormat string `json:"assignee_format,omitempty"`
+
+	// HQPrefix is the tmux session prefix for town-level agents (mayor,
+	// deacon, boot, dogs), so two towns on one machine can use distinct names.
+	// Must not contain '-' or be a rig prefix. Default: "hq".
+	HQPrefix string `json:"hq_prefix,omitempty"`
+
 	// AgentEmailDomain is the domain used for agent git identity emails.
 	// Agent addresses like "gastown/crew/jack" become "gastown.crew.jack@{domain}".
 	// Default: "gastown.local"
@@ -0,0 +1,63 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// BeadsRedirectAuditCheck resolves every agent working directory's beads
+// redirect and reports circular, chained, dangling or town-escaping ones.
+type BeadsRedirectAuditCheck struct {
+	BaseCheck
+}
+
+// NewBeadsRedirectAuditCheck creates a new beads redirect audit check.
+func NewBeadsRedirectAuditCheck() *BeadsRedirectAuditCheck {
+	return &BeadsRedirectAuditCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "beads-redirect-audit",
+			CheckDescription: "Check beads redirects resolve directly to a target inside the town",
+			CheckCategory:    CategoryRig,
+		},
+	}
+}
+
+// Run audits all beads redirects in the town.
+func (c *BeadsRedirectAuditCheck) Run(ctx *CheckContext) *CheckResult {
+	audits, err := beads.AuditRedirects(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Could not audit redirects: %v", err),
+		}
+	}
+
+	if len(audits) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "All beads redirects resolve cleanly",
+		}
+	}
+
+	var details []string
+	for _, a := range audits {
+		rel, err := filepath.Rel(ctx.TownRoot, a.WorkDir)
+		if err != nil {
+			rel = a.WorkDir
+		}
+		details = append(details, fmt.Sprintf("%s: %s", rel, strings.Join(a.Details, "; ")))
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d problem redirect(s)", len(audits)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to rewrite worktree redirects, or correct .beads/redirect by hand",
+	}
+}
@@ -0,0 +1,41 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBeadsRedirectAuditCheck(t *testing.T) {
+	townRoot := t.TempDir()
+	rigDir := filepath.Join(townRoot, "myrig")
+	if err := os.MkdirAll(filepath.Join(rigDir, "mayor", "rig", ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	crewBeads := filepath.Join(rigDir, "crew", "worker1", ".beads")
+	if err := os.MkdirAll(crewBeads, 0755); err != nil {
+		t.Fatal(err)
+	}
+	redirect := filepath.Join(crewBeads, "redirect")
+	if err := os.WriteFile(redirect, []byte("../../mayor/rig/.beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewBeadsRedirectAuditCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("Expected StatusOK for a direct redirect, got %v: %s", result.Status, result.Message)
+	}
+
+	if err := os.WriteFile(redirect, []byte("../../missing/.beads\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning {
+		t.Fatalf("Expected StatusWarning for a dangling redirect, got %v", result.Status)
+	}
+	if len(result.Details) != 1 || !strings.HasPrefix(result.Details[0], filepath.Join("myrig", "crew", "worker1")+":") {
+		t.Errorf("Details = %v, want one entry for myrig/crew/worker1", result.Details)
+	}
+}
@@ -179,7 +179,7 @@ func (c *ClaudeSettingsCheck) findSettingsFiles(townRoot string) []staleSettings
 		files = append(files, staleSettingsInfo{
 			path:          staleTownRootSettings,
 			agentType:     "mayor",
-			sessionName:   "hq-mayor",
+			sessionName:   session.MayorSessionName(),
 			wrongLocation: true,
 			gitStatus:     c.getGitFileStatus(staleTownRootSettings),
 			missing:       []string{"stale settings.json at town root (should not exist)"},
@@ -191,7 +191,7 @@ func (c *ClaudeSettingsCheck) findSettingsFiles(townRoot string) []staleSettings
 		files = append(files, staleSettingsInfo{
 			path:          staleTownRootLocal,
 			agentType:     "mayor",
-			sessionName:   "hq-mayor",
+			sessionName:   session.MayorSessionName(),
 			wrongLocation: true,
 			gitStatus:     c.getGitFileStatus(staleTownRootLocal),
 			missing:       []string{"stale settings.local.json at town root (should not exist)"},
@@ -207,7 +207,7 @@ func (c *ClaudeSettingsCheck) findSettingsFiles(townRoot string) []staleSettings
 		files = append(files, staleSettingsInfo{
 			path:          staleTownRootCLAUDEmd,
 			agentType:     "mayor",
-			sessionName:   "hq-mayor",
+			sessionName:   session.MayorSessionName(),
 			wrongLocation: true,
 			gitStatus:     c.getGitFileStatus(staleTownRootCLAUDEmd),
 			missing:       []string{"should be at mayor/CLAUDE.md, not town root"},
@@ -220,7 +220,7 @@ func (c *ClaudeSettingsCheck) findSettingsFiles(townRoot string) []staleSettings
 		files = append(files, staleSettingsInfo{
 			path:          mayorStaleLocal,
 			agentType:     "mayor",
-			sessionName:   "hq-mayor",
+			sessionName:   session.MayorSessionName(),
 			wrongLocation: true,
 			missing:       []string{"stale settings.local.json (should be settings.json)"},
 		})
@@ -232,13 +232,13 @@ func (c *ClaudeSettingsCheck) findSettingsFiles(townRoot string) []staleSettings
 		files = append(files, staleSettingsInfo{
 			path:        mayorSettings,
 			agentType:   "mayor",
-			sessionName: "hq-mayor",
+			sessionName: session.MayorSessionName(),
 		})
 	} else if dirExists(mayorWorkDir) {
 		files = append(files, staleSettingsInfo{
 			path:        mayorSettings,
 			agentType:   "mayor",
-			sessionName: "hq-mayor",
+			sessionName: session.MayorSessionName(),
 			missingFile: true,
 		})
 	}
@@ -249,7 +249,7 @@ func (c *ClaudeSettingsCheck) findSettingsFiles(townRoot string) []staleSettings
 		files = append(files, staleSettingsInfo{
 			path:          deaconStaleLocal,
 			agentType:     "deacon",
-			sessionName:   "hq-deacon",
+			sessionName:   session.DeaconSessionName(),
 			wrongLocation: true,
 			missing:       []string{"stale settings.local.json (should be settings.json)"},
 		})
@@ -261,13 +261,13 @@ func (c *ClaudeSettingsCheck) findSettingsFiles(townRoot string) []staleSettings
 		files = append(files, staleSettingsInfo{
 			path:        deaconSettings,
 			agentType:   "deacon",
-			sessionName: "hq-deacon",
+			sessionName: session.DeaconSessionName(),
 		})
 	} else if dirExists(deaconWorkDir) {
 		files = append(files, staleSettingsInfo{
 			path:        deaconSettings,
 			agentType:   "deacon",
-			sessionName: "hq-deacon",
+			sessionName: session.DeaconSessionName(),
 			missingFile: true,
 		})
 	}
@@ -65,13 +65,10 @@ type SessionInfo struct {
 	Created time.Time `json:"created,omitempty"`
 }
 
-// SessionName generates the tmux session name for a dog.
-// Pattern: hq-dog-{name}
-// Dogs are town-level (managed by deacon), so they use the hq- prefix.
-// We use "hq-dog-" instead of "hq-deacon-" to avoid tmux prefix-matching
-// collisions with the "hq-deacon" session.
+// SessionName generates the tmux session name for a dog (e.g., "hq-dog-alpha").
+// See session.DogSessionName.
 func (m *SessionManager) SessionName(dogName string) string {
-	return fmt.Sprintf("hq-dog-%s", dogName)
+	return session.DogSessionName(dogName)
 }
 
 // kennelPath returns the path to the dog's kennel directory.
@@ -46,6 +46,7 @@ import (
 
 	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/retry"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/util"
 )
@@ -128,6 +129,11 @@ const (
 	DefaultMaxConnections = 50     // Conservative default to prevent connection storms
 )
 
+// Clock returns the current time for timestamps this package records
+// (wanted items, server state, branch names). Tests may replace it to get
+// deterministic values; elapsed-time measurements still use time.Now.
+var Clock = time.Now
+
 // metadataMu provides per-path mutexes for EnsureMetadata goroutine synchronization.
 // flock is inter-process only and cannot reliably synchronize goroutines within the
 // same process (the same process may acquire the same flock twice without blocking).
@@ -625,7 +631,7 @@ func Start(townRoot string) error {
 		Running:   true,
 		PID:       cmd.Process.Pid,
 		Port:      config.Port,
-		StartedAt: time.Now(),
+		StartedAt: Clock(),
 		DataDir:   config.DataDir,
 		Databases: databases,
 	}
@@ -2148,33 +2154,15 @@ func doltSQL(townRoot, rigDB, query string) error {
 
 // doltSQLWithRetry executes a SQL statement with exponential backoff on transient errors.
 func doltSQLWithRetry(townRoot, rigDB, query string) error {
-	const maxRetries = 5
-	const baseBackoff = 500 * time.Millisecond
-	const maxBackoff = 15 * time.Second
-
-	var lastErr error
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if err := doltSQL(townRoot, rigDB, query); err != nil {
-			lastErr = err
-			if !isDoltRetryableError(err) {
-				return err
-			}
-			if attempt < maxRetries {
-				backoff := baseBackoff
-				for i := 1; i < attempt; i++ {
-					backoff *= 2
-					if backoff > maxBackoff {
-						backoff = maxBackoff
-						break
-					}
-				}
-				time.Sleep(backoff)
-			}
-			continue
-		}
-		return nil
-	}
-	return fmt.Errorf("after %d retries: %w", maxRetries, lastErr)
+	policy := retry.Policy{
+		MaxAttempts: 5,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  15 * time.Second,
+		Retryable:   isDoltRetryableError,
+	}
+	return retry.Do(context.Background(), policy, func() error {
+		return doltSQL(townRoot, rigDB, query)
+	})
 }
 
 // isDoltRetryableError returns true if the error is a transient Dolt failure worth retrying.
@@ -2215,7 +2203,7 @@ func validateBranchName(branchName string) error {
 // PolecatBranchName returns the Dolt branch name for a polecat.
 // Format: polecat-<name>-<unix-timestamp>
 func PolecatBranchName(polecatName string) string {
-	return fmt.Sprintf("polecat-%s-%d", strings.ToLower(polecatName), time.Now().Unix())
+	return fmt.Sprintf("polecat-%s-%d", strings.ToLower(polecatName), Clock().Unix())
 }
 
 // CreatePolecatBranch creates a Dolt branch for a polecat's isolated writes.
@@ -2360,33 +2348,15 @@ func doltSQLScript(townRoot, script string) error {
 // before the retry. Uses the same retry classification as doltSQLWithRetry but with
 // fewer retries and shorter backoff since multi-statement scripts are more expensive.
 func doltSQLScriptWithRetry(townRoot, script string) error {
-	const maxRetries = 3
-	const baseBackoff = 500 * time.Millisecond
-	const maxBackoff = 8 * time.Second
-
-	var lastErr error
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if err := doltSQLScript(townRoot, script); err != nil {
-			lastErr = err
-			if !isDoltRetryableError(err) {
-				return err
-			}
-			if attempt < maxRetries {
-				backoff := baseBackoff
-				for i := 1; i < attempt; i++ {
-					backoff *= 2
-					if backoff > maxBackoff {
-						backoff = maxBackoff
-						break
-					}
-				}
-				time.Sleep(backoff)
-			}
-			continue
-		}
-		return nil
-	}
-	return fmt.Errorf("after %d retries: %w", maxRetries, lastErr)
+	policy := retry.Policy{
+		MaxAttempts: 3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  8 * time.Second,
+		Retryable:   isDoltRetryableError,
+	}
+	return retry.Do(context.Background(), policy, func() error {
+		return doltSQLScript(townRoot, script)
+	})
 }
 
 // DeletePolecatBranch deletes a polecat's Dolt branch (cleanup/nuke).
@@ -2532,9 +2532,9 @@ func TestDoltSQLScriptWithRetry_ImmediateSuccess(t *testing.T) {
 		// the point is it doesn't retry/hang.
 		t.Skip("dolt binary available and accepted invalid SQL somehow")
 	}
-	// Verify the error is not wrapped with "after N retries" since exec failures
+	// Verify the error is not wrapped with "after N attempts" since exec failures
 	// (dolt not found / not a dolt data dir) are not retryable.
-	if strings.Contains(err.Error(), "after 3 retries") {
+	if strings.Contains(err.Error(), "after 3 attempts") {
 		t.Errorf("non-retryable error was retried: %v", err)
 	}
 }
@@ -0,0 +1,53 @@
+package doltserver
+
+import (
+	"fmt"
+	"os"
+)
+
+// Hooks for the session lifecycle, replaced in tests.
+var (
+	sessionIsRunning = IsRunning
+	sessionStart     = Start
+)
+
+// Session records a Dolt SQL server kept up across a run of operations,
+// such as creating wl-commons and then inserting into it, so each `dolt sql`
+// call connects to the warm server instead of paying the embedded engine's
+// cold start. Local `dolt sql` auto-detects a server running in the data
+// directory, so scripts route through it without extra flags.
+//
+// The server is the town's shared one: other agents may connect to it as
+// soon as it is up, so a session never stops it, even one it started.
+type Session struct {
+	// Active reports whether scripts run against a live server. It is false
+	// when the server could not be started; scripts then run as one-shot
+	// dolt processes.
+	Active bool
+	// Started reports whether StartSession started the server rather than
+	// finding it already running.
+	Started bool
+}
+
+// StartSession starts (or adopts) the town's Dolt SQL server. It only fails
+// for a remote server that can't be reached; a local server that fails to
+// start degrades to one-shot execution with a warning.
+func StartSession(townRoot string) (*Session, error) {
+	if DefaultConfig(townRoot).IsRemote() {
+		if err := CheckServerReachable(townRoot); err != nil {
+			return nil, err
+		}
+		return &Session{Active: true}, nil
+	}
+
+	running, _, err := sessionIsRunning(townRoot)
+	if err == nil && running {
+		return &Session{Active: true}, nil
+	}
+
+	if err := sessionStart(townRoot); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not start Dolt server, falling back to one-shot dolt sql: %v\n", err)
+		return &Session{}, nil
+	}
+	return &Session{Active: true, Started: true}, nil
+}
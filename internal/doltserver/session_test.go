@@ -0,0 +1,66 @@
+package doltserver
+
+import (
+	"errors"
+	"testing"
+)
+
+// stubSession replaces the session lifecycle hooks and records the calls.
+func stubSession(t *testing.T, running bool, startErr error) *[]string {
+	t.Helper()
+	var calls []string
+	origRunning, origStart := sessionIsRunning, sessionStart
+	t.Cleanup(func() { sessionIsRunning, sessionStart = origRunning, origStart })
+
+	sessionIsRunning = func(string) (bool, int, error) { return running, 0, nil }
+	sessionStart = func(string) error {
+		calls = append(calls, "start")
+		return startErr
+	}
+	return &calls
+}
+
+func TestSessionStartsServer(t *testing.T) {
+	calls := stubSession(t, false, nil)
+	t.Setenv("GT_DOLT_HOST", "")
+
+	s, err := StartSession(t.TempDir())
+	if err != nil {
+		t.Fatalf("StartSession() error: %v", err)
+	}
+	if *s != (Session{Active: true, Started: true}) {
+		t.Errorf("session = %+v, want active and started", *s)
+	}
+	if len(*calls) != 1 || (*calls)[0] != "start" {
+		t.Errorf("calls = %v, want one start", *calls)
+	}
+}
+
+func TestSessionReusesRunningServer(t *testing.T) {
+	calls := stubSession(t, true, nil)
+	t.Setenv("GT_DOLT_HOST", "")
+
+	s, err := StartSession(t.TempDir())
+	if err != nil {
+		t.Fatalf("StartSession() error: %v", err)
+	}
+	if *s != (Session{Active: true}) {
+		t.Errorf("session = %+v, want active but not started", *s)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("a running server should not be started again, got %v", *calls)
+	}
+}
+
+func TestSessionFallsBackToOneShot(t *testing.T) {
+	stubSession(t, false, errors.New("port in use"))
+	t.Setenv("GT_DOLT_HOST", "")
+
+	s, err := StartSession(t.TempDir())
+	if err != nil {
+		t.Fatalf("StartSession() error: %v", err)
+	}
+	if s.Active || s.Started {
+		t.Errorf("session = %+v, want inactive when the server fails to start", *s)
+	}
+}
@@ -4,14 +4,28 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 )
 
+// Push errors returned by PushDatabase, classified from dolt's output.
+var (
+	// ErrDoltAuth means the remote rejected the push for lack of
+	// credentials or permission.
+	ErrDoltAuth = errors.New("not authenticated to DoltHub (run 'dolt login')")
+	// ErrDoltNonFastForward means the remote has commits the local branch
+	// lacks, usually because someone else pushed first.
+	ErrDoltNonFastForward = errors.New("remote has newer commits (pull and retry, or push with --force)")
+	// ErrDoltPush is any other push failure.
+	ErrDoltPush = errors.New("dolt push failed")
+)
+
 // SyncOptions controls the behavior of SyncDatabases.
 type SyncOptions struct {
 	// Force enables --force on dolt push.
@@ -100,7 +114,8 @@ func CommitWorkingSet(dbDir string) error {
 }
 
 // PushDatabase pushes a Dolt database directory to origin main.
-// If force is true, uses --force.
+// If force is true, uses --force. Failures wrap ErrDoltAuth,
+// ErrDoltNonFastForward or ErrDoltPush; see ClassifyPushError.
 func PushDatabase(dbDir string, force bool) error {
 	args := []string{"push", "origin", "main"}
 	if force {
@@ -111,12 +126,50 @@ func PushDatabase(dbDir string, force bool) error {
 	cmd.Dir = dbDir
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("dolt push: %w (%s)", err, strings.TrimSpace(string(output)))
+		return ClassifyPushError(string(output), err)
 	}
 
 	return nil
 }
 
+// ClassifyPushError turns a failed dolt push, given its combined output and
+// exit error, into an error wrapping ErrDoltAuth, ErrDoltNonFastForward or,
+// when the output is not recognized, ErrDoltPush. The dolt output is kept
+// in the message.
+func ClassifyPushError(output string, err error) error {
+	detail := strings.TrimSpace(output)
+	lower := strings.ToLower(detail)
+
+	for _, pattern := range []string{"non-fast-forward", "updates were rejected", "tip of your current branch is behind"} {
+		if strings.Contains(lower, pattern) {
+			return fmt.Errorf("dolt push: %w: %s", ErrDoltNonFastForward, detail)
+		}
+	}
+	if IsAuthFailure(detail) {
+		return fmt.Errorf("dolt push: %w: %s", ErrDoltAuth, detail)
+	}
+	return fmt.Errorf("%w: %w (%s)", ErrDoltPush, err, detail)
+}
+
+// authFailureRe matches dolt remote output that reports rejected
+// credentials or permission: gRPC status codes, HTTP 401/403 statuses and
+// the phrases dolt and DoltHub print. Phrases and status codes must stand
+// alone, so a commit hash or byte count containing "401" does not match.
+var authFailureRe = regexp.MustCompile(`(?i)` +
+	`code\s*=\s*(unauthenticated|permissiondenied)\b` +
+	`|\b(http|status)(\s+code)?[\s:/]*(1\.1\s+)?40[13]\b` +
+	`|\b40[13]\s+(unauthorized|forbidden)\b` +
+	`|\b(permission denied|unauthenticated|unauthorized|not authorized|forbidden)\b` +
+	`|\bauthentication (failed|required)\b` +
+	`|\b(could not find|no|invalid|missing) credentials\b`)
+
+// IsAuthFailure reports whether the output of a failed dolt remote
+// operation (push, fetch, clone) says the remote rejected the request for
+// lack of credentials or permission.
+func IsAuthFailure(output string) bool {
+	return authFailureRe.MatchString(output)
+}
+
 // SyncDatabases iterates all databases (or a filtered subset), checks for remotes,
 // commits working changes, and pushes to origin. Never fails fast — collects all results.
 func SyncDatabases(townRoot string, opts SyncOptions) []SyncResult {
@@ -0,0 +1,66 @@
+package doltserver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIsAuthFailure(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"rpc error: code = Unauthenticated desc = invalid token", true},
+		{"rpc error: code = PermissionDenied desc = no write access", true},
+		{"error: could not find credentials for dolthub.com; run dolt login", true},
+		{"unexpected HTTP 401 from doltremoteapi.dolthub.com", true},
+		{"server returned status code: 403", true},
+		{"403 Forbidden", true},
+		{"authentication required", true},
+		{"error: failed to push chunk 4019a2: 401 bytes written before reset", false},
+		{"commit 8c401f3 is not an ancestor of main", false},
+		{"wrote 1403 chunks", false},
+		{"dial tcp: lookup doltremoteapi.dolthub.com: no such host", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsAuthFailure(tt.output); got != tt.want {
+			t.Errorf("IsAuthFailure(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyPushError(t *testing.T) {
+	exit := errors.New("exit status 1")
+
+	tests := []struct {
+		name   string
+		output string
+		want   error
+	}{
+		{"behind", "error: failed to push some refs to 'https://doltremoteapi.dolthub.com/acme/gastown'\nhint: Updates were rejected because the tip of your current branch is behind\nhint: its remote counterpart.", ErrDoltNonFastForward},
+		{"non-fast-forward", "! [rejected] main -> main (non-fast-forward)", ErrDoltNonFastForward},
+		{"no credentials", "error: could not find credentials for dolthub.com; run dolt login", ErrDoltAuth},
+		{"permission denied", "rpc error: code = PermissionDenied desc = permission denied", ErrDoltAuth},
+		{"unauthenticated", "rpc error: code = Unauthenticated desc = invalid token", ErrDoltAuth},
+		{"network", "dial tcp: lookup doltremoteapi.dolthub.com: no such host", ErrDoltPush},
+		{"empty", "", ErrDoltPush},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ClassifyPushError(tt.output, exit)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ClassifyPushError() = %v, want %v", err, tt.want)
+			}
+			for _, other := range []error{ErrDoltAuth, ErrDoltNonFastForward, ErrDoltPush} {
+				if other != tt.want && errors.Is(err, other) {
+					t.Errorf("ClassifyPushError() = %v, should not also be %v", err, other)
+				}
+			}
+			if tt.output != "" && !strings.Contains(err.Error(), strings.TrimSpace(tt.output)) {
+				t.Errorf("ClassifyPushError() = %q, want dolt output preserved", err)
+			}
+		})
+	}
+}
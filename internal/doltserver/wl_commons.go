@@ -9,6 +9,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,9 +17,19 @@ import (
 	"time"
 )
 
-// WLCommonsDB is the database name for the wl-commons shared wanted board.
+// WLCommonsDB is the default database name for the wl-commons shared wanted board.
 const WLCommonsDB = "wl_commons"
 
+// WLCommonsDBName returns the wl-commons database name, honoring the
+// GASTOWN_WL_DB environment override (e.g., for testing against a private
+// mirror). Falls back to WLCommonsDB.
+func WLCommonsDBName() string {
+	if name := os.Getenv("GASTOWN_WL_DB"); name != "" {
+		return name
+	}
+	return WLCommonsDB
+}
+
 // WantedItem represents a row in the wanted table.
 type WantedItem struct {
 	ID              string
@@ -33,6 +44,47 @@ type WantedItem struct {
 	Status          string
 	EffortLevel     string
 	SandboxRequired bool
+	SandboxMinTier  string
+}
+
+// WantedDedupKey returns the dedup_key stored with a wanted item: the
+// SHA-256 of its title and project, each lowercased with runs of whitespace
+// collapsed. The unique index on dedup_key rejects a second item with the
+// same normalized title in the same project.
+func WantedDedupKey(title, project string) string {
+	normalize := func(s string) string {
+		return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	}
+	hash := sha256.Sum256([]byte(normalize(title) + "\x00" + normalize(project)))
+	return hex.EncodeToString(hash[:])
+}
+
+// ErrWLCommonsSchemaOutdated is returned by InsertWanted when the local
+// wl-commons predates the dedup_key column. Migrate it (see
+// wasteland.MigrateLocalCommons) and retry.
+var ErrWLCommonsSchemaOutdated = errors.New("wl-commons schema is outdated")
+
+// isMissingDedupKeyError reports whether a failed insert hit a wanted table
+// without the dedup_key column. Dolt reports it as column "dedup_key" could
+// not be found, MySQL as Unknown column 'dedup_key'.
+func isMissingDedupKeyError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "dedup_key") &&
+		(strings.Contains(msg, "could not be found") || strings.Contains(msg, "unknown column"))
+}
+
+// ErrDuplicateWanted is returned by InsertWanted when the commons already
+// has a wanted item with the same normalized title and project.
+var ErrDuplicateWanted = errors.New("duplicate wanted item")
+
+// isDuplicateDedupKeyError reports whether a failed insert hit the unique
+// index on wanted.dedup_key. Dolt reports unique index violations as
+// "duplicate unique key given" (primary keys say "duplicate primary key"),
+// MySQL as "Duplicate entry ... for key 'wanted_dedup_key'".
+func isDuplicateDedupKeyError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "duplicate unique key given") ||
+		(strings.Contains(msg, "Duplicate entry") && strings.Contains(msg, "wanted_dedup_key"))
 }
 
 // GenerateWantedID generates a unique wanted item ID in the format w-<10-char-hash>.
@@ -40,23 +92,72 @@ func GenerateWantedID(title string) string {
 	randomBytes := make([]byte, 8)
 	_, _ = rand.Read(randomBytes)
 
-	input := fmt.Sprintf("%s:%d:%x", title, time.Now().UnixNano(), randomBytes)
+	input := fmt.Sprintf("%s:%d:%x", title, Clock().UnixNano(), randomBytes)
 	hash := sha256.Sum256([]byte(input))
 	hashStr := hex.EncodeToString(hash[:])[:10]
 
 	return fmt.Sprintf("w-%s", hashStr)
 }
 
-// EnsureWLCommons ensures the wl-commons database exists and has the correct schema.
-func EnsureWLCommons(townRoot string) error {
+// WLCommonsState describes how far the wl-commons database has been set up.
+type WLCommonsState struct {
+	// DBExists is true when the database directory exists on disk.
+	DBExists bool
+
+	// SchemaInitialized is true when the _meta table records a schema version.
+	SchemaInitialized bool
+
+	// SchemaVersion is the recorded schema version (empty if not initialized).
+	SchemaVersion string
+}
+
+// WLCommonsStatus reports whether the wl-commons database and schema exist,
+// without creating anything. The schema check queries the running Dolt
+// server, so it is only attempted when the database exists on disk.
+func WLCommonsStatus(townRoot string) (WLCommonsState, error) {
+	var state WLCommonsState
+	if !wlCommonsDBExists(townRoot) {
+		return state, nil
+	}
+	state.DBExists = true
+
+	query := fmt.Sprintf("USE %s; SELECT value FROM _meta WHERE %s = 'schema_version';",
+		WLCommonsDBName(), backtickKey())
+	output, err := doltSQLQuery(townRoot, query)
+	if err != nil {
+		// A database created without its schema has no _meta table yet.
+		lower := strings.ToLower(err.Error())
+		if strings.Contains(lower, "table not found") || strings.Contains(lower, "doesn't exist") {
+			return state, nil
+		}
+		return state, fmt.Errorf("checking wl-commons schema: %w", err)
+	}
+
+	rows := parseSimpleCSV(output)
+	if len(rows) > 0 && rows[0]["value"] != "" {
+		state.SchemaInitialized = true
+		state.SchemaVersion = rows[0]["value"]
+	}
+	return state, nil
+}
+
+// wlCommonsDBExists reports whether the wl-commons database directory exists.
+func wlCommonsDBExists(townRoot string) bool {
 	config := DefaultConfig(townRoot)
-	dbDir := filepath.Join(config.DataDir, WLCommonsDB)
+	dbDir := filepath.Join(config.DataDir, WLCommonsDBName())
+	_, err := os.Stat(filepath.Join(dbDir, ".dolt"))
+	return err == nil
+}
 
-	if _, err := os.Stat(filepath.Join(dbDir, ".dolt")); err == nil {
+// EnsureWLCommons ensures the wl-commons database exists and has the correct schema.
+// An existing database is left as is; InsertWanted reports one that predates
+// the current schema with ErrWLCommonsSchemaOutdated.
+func EnsureWLCommons(townRoot string) error {
+	if wlCommonsDBExists(townRoot) {
 		return nil
 	}
 
-	_, created, err := InitRig(townRoot, WLCommonsDB)
+	_, created, err := InitRig(townRoot, WLCommonsDBName())
 	if err != nil {
 		return fmt.Errorf("creating wl-commons database: %w", err)
 	}
@@ -72,6 +173,33 @@ func EnsureWLCommons(townRoot string) error {
 	return nil
 }
 
+// WLCommonsSchemaVersion is the schema version initWLCommonsSchema creates.
+const WLCommonsSchemaVersion = "1.1"
+
+// WantedDedupKeyMigration is the SQL that takes a schema 1.0 commons to
+// 1.1 by adding wanted.dedup_key and its unique index. Rows posted before
+// the migration keep a NULL key, which the index does not constrain.
+// Each statement only runs when information_schema shows its column or
+// index is missing, so rerunning an interrupted migration is a no-op.
+const WantedDedupKeyMigration = `SET @gt_stmt = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'wanted' AND column_name = 'dedup_key') = 0,
+  'ALTER TABLE wanted ADD COLUMN dedup_key VARCHAR(64)', 'DO 0');
+PREPARE gt_migrate FROM @gt_stmt;
+EXECUTE gt_migrate;
+DEALLOCATE PREPARE gt_migrate;
+SET @gt_stmt = IF((SELECT COUNT(*) FROM information_schema.statistics
+    WHERE table_schema = DATABASE() AND table_name = 'wanted' AND index_name = 'wanted_dedup_key') = 0,
+  'CREATE UNIQUE INDEX wanted_dedup_key ON wanted (dedup_key)', 'DO 0');
+PREPARE gt_migrate FROM @gt_stmt;
+EXECUTE gt_migrate;
+DEALLOCATE PREPARE gt_migrate;`
+
+// ExecWLCommonsScript runs a multi-statement SQL script against the local
+// wl-commons database on the town's Dolt server.
+func ExecWLCommonsScript(townRoot, script string) error {
+	return doltSQLScript(townRoot, fmt.Sprintf("USE %s;\n%s", WLCommonsDBName(), script))
+}
+
 func initWLCommonsSchema(townRoot string) error {
 	schema := fmt.Sprintf(`USE %s;
 
@@ -80,7 +208,7 @@ CREATE TABLE IF NOT EXISTS _meta (
     value TEXT
 );
 
-INSERT IGNORE INTO _meta (%s, value) VALUES ('schema_version', '1.0');
+INSERT IGNORE INTO _meta (%s, value) VALUES ('schema_version', '%s');
 INSERT IGNORE INTO _meta (%s, value) VALUES ('wasteland_name', 'Gas Town Wasteland');
 
 CREATE TABLE IF NOT EXISTS rigs (
@@ -113,8 +241,10 @@ CREATE TABLE IF NOT EXISTS wanted (
     sandbox_required TINYINT(1) DEFAULT 0,
     sandbox_scope JSON,
     sandbox_min_tier VARCHAR(32),
+    dedup_key VARCHAR(64),
     created_at TIMESTAMP,
-    updated_at TIMESTAMP
+    updated_at TIMESTAMP,
+    UNIQUE KEY wanted_dedup_key (dedup_key)
 );
 
 CREATE TABLE IF NOT EXISTS completions (
@@ -167,9 +297,10 @@ CREATE TABLE IF NOT EXISTS chain_meta (
 );
 
 CALL DOLT_ADD('-A');
-CALL DOLT_COMMIT('--allow-empty', '-m', 'Initialize wl-commons schema v1.0');
-`, WLCommonsDB,
-		backtickKey(), backtickKey(), backtickKey())
+CALL DOLT_COMMIT('--allow-empty', '-m', 'Initialize wl-commons schema v%s');
+`, WLCommonsDBName(),
+		backtickKey(), backtickKey(), WLCommonsSchemaVersion, backtickKey(),
+		WLCommonsSchemaVersion)
 
 	return doltSQLScriptWithRetry(townRoot, schema)
 }
@@ -179,15 +310,37 @@ func backtickKey() string {
 }
 
 // InsertWanted inserts a new wanted item into the wl-commons database.
+// It returns an error wrapping ErrDuplicateWanted if an item with the same
+// normalized title and project is already posted, and one wrapping
+// ErrWLCommonsSchemaOutdated if the database has no dedup_key column yet.
 func InsertWanted(townRoot string, item *WantedItem) error {
+	script, err := InsertWantedSQL(item)
+	if err != nil {
+		return err
+	}
+	if err := doltSQLScriptWithRetry(townRoot, script); err != nil {
+		if isDuplicateDedupKeyError(err) {
+			return fmt.Errorf("%w: %q is already posted for project %q", ErrDuplicateWanted, item.Title, item.Project)
+		}
+		if isMissingDedupKeyError(err) {
+			return fmt.Errorf("%w: %v", ErrWLCommonsSchemaOutdated, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// InsertWantedSQL returns the SQL script InsertWanted runs to insert and
+// commit item.
+func InsertWantedSQL(item *WantedItem) (string, error) {
 	if item.ID == "" {
-		return fmt.Errorf("wanted item ID cannot be empty")
+		return "", fmt.Errorf("wanted item ID cannot be empty")
 	}
 	if item.Title == "" {
-		return fmt.Errorf("wanted item title cannot be empty")
+		return "", fmt.Errorf("wanted item title cannot be empty")
 	}
 
-	now := time.Now().UTC().Format("2006-01-02 15:04:05")
+	now := Clock().UTC().Format("2006-01-02 15:04:05")
 
 	tagsJSON := "NULL"
 	if len(item.Tags) > 0 {
@@ -225,6 +378,10 @@ func InsertWanted(townRoot string, item *WantedItem) error {
 	if item.EffortLevel != "" {
 		effortField = fmt.Sprintf("'%s'", esc(item.EffortLevel))
 	}
+	tierField := "NULL"
+	if item.SandboxMinTier != "" {
+		tierField = fmt.Sprintf("'%s'", esc(item.SandboxMinTier))
+	}
 	status := "'open'"
 	if item.Status != "" {
 		status = fmt.Sprintf("'%s'", esc(item.Status))
@@ -232,19 +389,19 @@ func InsertWanted(townRoot string, item *WantedItem) error {
 
 	script := fmt.Sprintf(`USE %s;
 
-INSERT INTO wanted (id, title, description, project, type, priority, tags, posted_by, status, effort_level, created_at, updated_at)
-VALUES ('%s', '%s', %s, %s, %s, %d, %s, %s, %s, %s, '%s', '%s');
+INSERT INTO wanted (id, title, description, project, type, priority, tags, posted_by, status, effort_level, sandbox_min_tier, dedup_key, created_at, updated_at)
+VALUES ('%s', '%s', %s, %s, %s, %d, %s, %s, %s, %s, %s, '%s', '%s', '%s');
 
 CALL DOLT_ADD('-A');
 CALL DOLT_COMMIT('-m', 'wl post: %s');
 `,
-		WLCommonsDB,
+		WLCommonsDBName(),
 		esc(item.ID), esc(item.Title), descField, projectField, typeField,
-		item.Priority, tagsJSON, postedByField, status, effortField,
-		now, now,
+		item.Priority, tagsJSON, postedByField, status, effortField, tierField,
+		WantedDedupKey(item.Title, item.Project), now, now,
 		esc(item.Title))
 
-	return doltSQLScriptWithRetry(townRoot, script)
+	return script, nil
 }
 
 // ClaimWanted updates a wanted item's status to claimed.
@@ -261,7 +418,7 @@ WHERE id='%s' AND status='open';
 CALL DOLT_ADD('-A');
 CALL DOLT_COMMIT('-m', 'wl claim: %s');
 `,
-		WLCommonsDB,
+		WLCommonsDBName(),
 		esc(rigHandle),
 		esc(wantedID),
 		esc(wantedID))
@@ -286,7 +443,7 @@ WHERE id='%s';
 CALL DOLT_ADD('-A');
 CALL DOLT_COMMIT('-m', 'wl done: %s');
 `,
-		WLCommonsDB,
+		WLCommonsDBName(),
 		esc(completionID),
 		esc(wantedID),
 		esc(rigHandle),
@@ -305,7 +462,7 @@ func QueryWanted(townRoot, wantedID string) (*WantedItem, error) {
 	}
 
 	query := fmt.Sprintf(`USE %s; SELECT id, title, status, COALESCE(claimed_by, '') as claimed_by FROM wanted WHERE id='%s';`,
-		WLCommonsDB, esc(wantedID))
+		WLCommonsDBName(), esc(wantedID))
 
 	output, err := doltSQLQuery(townRoot, query)
 	if err != nil {
@@ -327,6 +484,56 @@ func QueryWanted(townRoot, wantedID string) (*WantedItem, error) {
 	return item, nil
 }
 
+// QueryWantedCSV selects columns of the wanted item wantedID and returns
+// dolt's CSV output (a header line, then the row if it exists). Unlike
+// QueryWanted it returns every requested column, quoted as dolt emits it,
+// for callers that parse the CSV properly.
+func QueryWantedCSV(townRoot, wantedID string, columns []string) (string, error) {
+	query := fmt.Sprintf("USE %s; SELECT %s FROM wanted WHERE id='%s';",
+		WLCommonsDBName(), strings.Join(columns, ", "), strings.ReplaceAll(wantedID, "'", "''"))
+	return doltSQLQuery(townRoot, query)
+}
+
+// QueryWantedIDs returns the IDs of wanted items matching all conditions
+// (SQL boolean expressions over the wanted table), ordered by orderBy and
+// capped at limit. An empty orderBy leaves the order unspecified.
+func QueryWantedIDs(townRoot string, conditions []string, orderBy string, limit int) ([]string, error) {
+	query := fmt.Sprintf("USE %s; SELECT id FROM wanted", WLCommonsDBName())
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if orderBy != "" {
+		query += " ORDER BY " + orderBy
+	}
+	query += fmt.Sprintf(" LIMIT %d;", limit)
+
+	output, err := doltSQLQuery(townRoot, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, row := range parseSimpleCSV(output) {
+		if id := row["id"]; id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// WLCommonsHead returns the commit hash at HEAD of the wl-commons database.
+func WLCommonsHead(townRoot string) (string, error) {
+	output, err := doltSQLQuery(townRoot, fmt.Sprintf("USE %s; SELECT DOLT_HASHOF('HEAD') AS head;", WLCommonsDBName()))
+	if err != nil {
+		return "", err
+	}
+	rows := parseSimpleCSV(output)
+	if len(rows) == 0 || rows[0]["head"] == "" {
+		return "", fmt.Errorf("no HEAD commit in %s", WLCommonsDBName())
+	}
+	return rows[0]["head"], nil
+}
+
 // doltSQLQuery executes a SQL query and returns the raw CSV output.
 func doltSQLQuery(townRoot, query string) (string, error) {
 	config := DefaultConfig(townRoot)
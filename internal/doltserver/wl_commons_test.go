@@ -0,0 +1,257 @@
+package doltserver
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWLCommonsDBName(t *testing.T) {
+	t.Setenv("GASTOWN_WL_DB", "")
+	if got := WLCommonsDBName(); got != WLCommonsDB {
+		t.Errorf("WLCommonsDBName() = %q, want default %q", got, WLCommonsDB)
+	}
+
+	t.Setenv("GASTOWN_WL_DB", "wl_mirror")
+	if got := WLCommonsDBName(); got != "wl_mirror" {
+		t.Errorf("WLCommonsDBName() = %q, want env override %q", got, "wl_mirror")
+	}
+}
+
+// installFakeDoltSQL puts a fake dolt on PATH whose "sql" subcommand prints
+// output and exits with exitCode.
+func installFakeDoltSQL(t *testing.T, output string, exitCode int) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt script requires a POSIX shell")
+	}
+	binDir := t.TempDir()
+	outFile := filepath.Join(binDir, "out")
+	if err := os.WriteFile(outFile, []byte(output), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	script := fmt.Sprintf("#!/bin/sh\ncat %q\nexit %d\n", outFile, exitCode)
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// makeWLCommonsDir creates an (empty) wl-commons database directory.
+func makeWLCommonsDir(t *testing.T, townRoot string) {
+	t.Helper()
+	dir := filepath.Join(DefaultConfig(townRoot).DataDir, WLCommonsDBName(), ".dolt")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWLCommonsStatus_Absent(t *testing.T) {
+	installFakeDoltSQL(t, "should not be called", 1)
+	townRoot := t.TempDir()
+
+	state, err := WLCommonsStatus(townRoot)
+	if err != nil {
+		t.Fatalf("WLCommonsStatus() error: %v", err)
+	}
+	if state != (WLCommonsState{}) {
+		t.Errorf("state = %+v, want zero state", state)
+	}
+	if _, err := os.Stat(DefaultConfig(townRoot).DataDir); !os.IsNotExist(err) {
+		t.Error("WLCommonsStatus must not create the data directory")
+	}
+}
+
+func TestWLCommonsStatus_Present(t *testing.T) {
+	installFakeDoltSQL(t, "value\n1.0\n", 0)
+	townRoot := t.TempDir()
+	makeWLCommonsDir(t, townRoot)
+
+	state, err := WLCommonsStatus(townRoot)
+	if err != nil {
+		t.Fatalf("WLCommonsStatus() error: %v", err)
+	}
+	want := WLCommonsState{DBExists: true, SchemaInitialized: true, SchemaVersion: "1.0"}
+	if state != want {
+		t.Errorf("state = %+v, want %+v", state, want)
+	}
+}
+
+func TestWLCommonsStatus_PartiallyInitialized(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		exit   int
+	}{
+		{"no meta table", "error: table not found: _meta", 1},
+		{"no version row", "value\n", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installFakeDoltSQL(t, tt.output, tt.exit)
+			townRoot := t.TempDir()
+			makeWLCommonsDir(t, townRoot)
+
+			state, err := WLCommonsStatus(townRoot)
+			if err != nil {
+				t.Fatalf("WLCommonsStatus() error: %v", err)
+			}
+			want := WLCommonsState{DBExists: true}
+			if state != want {
+				t.Errorf("state = %+v, want %+v", state, want)
+			}
+		})
+	}
+}
+
+func TestWLCommonsStatus_QueryFailure(t *testing.T) {
+	installFakeDoltSQL(t, "error: connection refused", 1)
+	townRoot := t.TempDir()
+	makeWLCommonsDir(t, townRoot)
+
+	state, err := WLCommonsStatus(townRoot)
+	if err == nil {
+		t.Fatal("WLCommonsStatus() should surface unexpected query errors")
+	}
+	if !state.DBExists {
+		t.Error("DBExists should still be reported on query failure")
+	}
+}
+
+// pinClock replaces Clock with a fixed time for the duration of a test.
+func pinClock(t *testing.T, at time.Time) {
+	t.Helper()
+	orig := Clock
+	Clock = func() time.Time { return at }
+	t.Cleanup(func() { Clock = orig })
+}
+
+func TestInsertWanted_UsesClock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt script requires a POSIX shell")
+	}
+	pinClock(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600)))
+
+	// Fake dolt that saves the script passed via --file.
+	binDir := t.TempDir()
+	captured := filepath.Join(binDir, "script.sql")
+	script := fmt.Sprintf(`#!/bin/sh
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--file" ]; then cp "$2" %q; fi
+  shift
+done
+`, captured)
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	townRoot := t.TempDir()
+	makeWLCommonsDir(t, townRoot)
+	if err := InsertWanted(townRoot, &WantedItem{ID: "w-abc", Title: "Fix it"}); err != nil {
+		t.Fatalf("InsertWanted() error: %v", err)
+	}
+
+	data, err := os.ReadFile(captured)
+	if err != nil {
+		t.Fatalf("reading captured script: %v", err)
+	}
+	// created_at and updated_at are the clock's time in UTC.
+	if want := "'2026-01-02 02:04:05', '2026-01-02 02:04:05')"; !strings.Contains(string(data), want) {
+		t.Errorf("script missing timestamps %s:\n%s", want, data)
+	}
+	if want := "'" + WantedDedupKey("Fix it", "") + "'"; !strings.Contains(string(data), want) {
+		t.Errorf("script missing dedup_key %s:\n%s", want, data)
+	}
+}
+
+func TestInsertWanted_OutdatedSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		outdated bool
+	}{
+		{"dolt", `error on line 3: column "dedup_key" could not be found in any table in scope`, true},
+		{"mysql", "Error 1054: Unknown column 'dedup_key' in 'field list'", true},
+		{"other column", `error on line 3: column "project" could not be found in any table in scope`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installFakeDoltSQL(t, tt.output, 1)
+			townRoot := t.TempDir()
+			makeWLCommonsDir(t, townRoot)
+
+			err := InsertWanted(townRoot, &WantedItem{ID: "w-abc", Title: "Fix it"})
+			if err == nil {
+				t.Fatal("InsertWanted() should fail")
+			}
+			if got := errors.Is(err, ErrWLCommonsSchemaOutdated); got != tt.outdated {
+				t.Errorf("errors.Is(%v, ErrWLCommonsSchemaOutdated) = %v, want %v", err, got, tt.outdated)
+			}
+		})
+	}
+}
+
+func TestWantedDedupKey(t *testing.T) {
+	base := WantedDedupKey("Fix the build", "gastown")
+	if len(base) != 64 {
+		t.Errorf("WantedDedupKey() = %q, want a 64-char hex digest", base)
+	}
+	if got := WantedDedupKey("  fix THE\tbuild ", "GasTown"); got != base {
+		t.Errorf("case and whitespace changed the key: %q != %q", got, base)
+	}
+	if got := WantedDedupKey("Fix the build", "beads"); got == base {
+		t.Error("a different project should give a different key")
+	}
+	if WantedDedupKey("ab", "c") == WantedDedupKey("a", "bc") {
+		t.Error("title and project must not run together")
+	}
+}
+
+func TestWantedDedupKeyMigration_Guarded(t *testing.T) {
+	// Every DDL statement must sit behind an information_schema check so a
+	// rerun after a partial migration does not fail on "duplicate column".
+	for _, ddl := range []string{"ALTER TABLE wanted ADD COLUMN dedup_key", "CREATE UNIQUE INDEX wanted_dedup_key"} {
+		if !strings.Contains(WantedDedupKeyMigration, "'"+ddl) {
+			t.Errorf("migration runs %q unguarded:\n%s", ddl, WantedDedupKeyMigration)
+		}
+	}
+	for _, check := range []string{"information_schema.columns", "information_schema.statistics"} {
+		if !strings.Contains(WantedDedupKeyMigration, check) {
+			t.Errorf("migration does not check %s:\n%s", check, WantedDedupKeyMigration)
+		}
+	}
+}
+
+func TestInsertWanted_Duplicate(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		dup    bool
+	}{
+		{"dolt unique index", "error on line 3: duplicate unique key given: [3f2a]", true},
+		{"mysql unique index", "Error 1062: Duplicate entry '3f2a' for key 'wanted_dedup_key'", true},
+		{"primary key", "error on line 3: duplicate primary key given: [w-abc]", false},
+		{"other failure", "error: table not found: wanted", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installFakeDoltSQL(t, tt.output, 1)
+			townRoot := t.TempDir()
+			makeWLCommonsDir(t, townRoot)
+
+			err := InsertWanted(townRoot, &WantedItem{ID: "w-abc", Title: "Fix it", Project: "gastown"})
+			if err == nil {
+				t.Fatal("InsertWanted() should fail")
+			}
+			if got := errors.Is(err, ErrDuplicateWanted); got != tt.dup {
+				t.Errorf("errors.Is(%v, ErrDuplicateWanted) = %v, want %v", err, got, tt.dup)
+			}
+		})
+	}
+}
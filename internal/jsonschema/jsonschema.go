@@ -0,0 +1,116 @@
+// Package jsonschema generates JSON Schema documents from Go structs, so
+// published field shapes stay in sync with the types that produce them.
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Draft is the JSON Schema dialect emitted by FromStruct.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a (subset of a) JSON Schema document.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []any              `json:"enum,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// FromStruct returns an object schema for v, which must be a struct or a
+// pointer to one. Property names come from json tags, or the snake_cased
+// field name for untagged fields; fields tagged "-" and unexported fields
+// are skipped. enums restricts the named top-level properties to the given
+// values.
+func FromStruct(title string, v any, enums map[string][]any) *Schema {
+	s := forType(reflect.TypeOf(v))
+	s.Schema = Draft
+	s.Title = title
+	for name, values := range enums {
+		if prop, ok := s.Properties[name]; ok {
+			prop.Enum = values
+		}
+	}
+	return s
+}
+
+// PropertyName returns the JSON property name FromStruct uses for a field,
+// or "" if the field is skipped.
+func PropertyName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return snakeCase(f.Name)
+}
+
+func forType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: forType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if name := PropertyName(f); name != "" {
+				s.Properties[name] = forType(f.Type)
+			}
+		}
+		return s
+	default:
+		return &Schema{}
+	}
+}
+
+// snakeCase converts a Go field name to snake_case, keeping initialisms
+// together (ID → id, PostedBy → posted_by, HTTPPort → http_port).
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,81 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type sample struct {
+	ID        string            `json:"id"`
+	Count     int               `json:"count,omitempty"`
+	Tags      []string          `json:"tags"`
+	Ready     bool              `json:"ready"`
+	When      time.Time         `json:"when"`
+	Meta      map[string]string `json:"meta"`
+	Hidden    string            `json:"-"`
+	PostedBy  string
+	HTTPPort  int
+	unexposed string
+}
+
+func TestFromStruct(t *testing.T) {
+	s := FromStruct("Sample", &sample{}, map[string][]any{"id": {"a", "b"}})
+
+	if s.Schema != Draft || s.Title != "Sample" || s.Type != "object" {
+		t.Errorf("header = %q %q %q", s.Schema, s.Title, s.Type)
+	}
+
+	wantTypes := map[string]string{
+		"id":        "string",
+		"count":     "integer",
+		"tags":      "array",
+		"ready":     "boolean",
+		"when":      "string",
+		"meta":      "object",
+		"posted_by": "string",
+		"http_port": "integer",
+	}
+	if len(s.Properties) != len(wantTypes) {
+		t.Errorf("properties = %v, want %d entries", s.Properties, len(wantTypes))
+	}
+	for name, typ := range wantTypes {
+		prop, ok := s.Properties[name]
+		if !ok {
+			t.Errorf("missing property %q", name)
+			continue
+		}
+		if prop.Type != typ {
+			t.Errorf("property %q type = %q, want %q", name, prop.Type, typ)
+		}
+	}
+	if s.Properties["when"].Format != "date-time" {
+		t.Error("time.Time should be a date-time string")
+	}
+	if s.Properties["tags"].Items.Type != "string" {
+		t.Error("[]string items should be strings")
+	}
+	if got := s.Properties["id"].Enum; len(got) != 2 {
+		t.Errorf("id enum = %v, want [a b]", got)
+	}
+
+	if _, err := json.Marshal(s); err != nil {
+		t.Fatalf("schema should marshal: %v", err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":             "id",
+		"Title":          "title",
+		"PostedBy":       "posted_by",
+		"SandboxMinTier": "sandbox_min_tier",
+		"HTTPPort":       "http_port",
+		"HookBead":       "hook_bead",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -331,10 +331,10 @@ func (m *Manager) SetAgentStateWithRetry(name string, state string) error {
 	return fmt.Errorf("setting agent state after %d attempts: %w", doltMaxRetries, lastErr)
 }
 
-// assigneeID returns the beads assignee identifier for a polecat.
-// Format: "rig/polecats/polecatName" (e.g., "gastown/polecats/Toast")
+// assigneeID returns the beads assignee identifier for a polecat, in the
+// town's session.AssigneeFormat (e.g., "gastown/polecats/Toast").
 func (m *Manager) assigneeID(name string) string {
-	return fmt.Sprintf("%s/polecats/%s", m.rig.Name, name)
+	return session.PolecatAssignee(m.rig.Name, name)
 }
 
 // agentBeadID returns the agent bead ID for a polecat.
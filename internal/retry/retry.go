@@ -0,0 +1,100 @@
+// Package retry runs operations with capped exponential backoff.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Policy describes how Do retries an operation.
+type Policy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 1 mean a single attempt.
+	MaxAttempts int
+	// BaseBackoff is the delay after the first failure. It doubles after
+	// each further failure.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the delay. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter randomizes each delay downward by up to this fraction (0 to 1),
+	// so concurrent retriers spread out: a delay d becomes a value in
+	// [d*(1-Jitter), d].
+	Jitter float64
+	// Retryable reports whether an error is worth retrying. Nil retries
+	// every error.
+	Retryable func(error) bool
+}
+
+// Backoff returns the delay after the given failed attempt (1-based),
+// before jitter.
+func (p Policy) Backoff(attempt int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < attempt && d > 0; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// jittered applies p.Jitter to d.
+func (p Policy) jittered(d time.Duration) time.Duration {
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter == 0 || d <= 0 {
+		return d
+	}
+	return d - time.Duration(randFloat()*jitter*float64(d))
+}
+
+// randFloat returns a value in [0, 1). Var so tests can pin jitter.
+var randFloat = rand.Float64
+
+// sleep waits for d or until ctx is done. Var so tests can record delays
+// instead of waiting.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Do calls fn until it succeeds, returns an error p.Retryable rejects, or
+// p.MaxAttempts attempts have failed, sleeping p's backoff between
+// attempts. A non-retryable error is returned as is; running out of
+// attempts returns the last error wrapped with the attempt count. If ctx
+// ends while waiting, Do returns an error wrapping both ctx.Err() and the
+// last error.
+func Do(ctx context.Context, p Policy, fn func() error) error {
+	attempts := max(p.MaxAttempts, 1)
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+		if p.Retryable != nil && !p.Retryable(lastErr) {
+			return lastErr
+		}
+		if attempt == attempts {
+			break
+		}
+		if err := sleep(ctx, p.jittered(p.Backoff(attempt))); err != nil {
+			return fmt.Errorf("retry interrupted after %d attempt(s): %w", attempt, errors.Join(err, lastErr))
+		}
+	}
+	return fmt.Errorf("after %d attempts: %w", attempts, lastErr)
+}
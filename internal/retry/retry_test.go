@@ -0,0 +1,181 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordSleeps replaces sleep with one that records each delay.
+func recordSleeps(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var delays []time.Duration
+	orig := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = orig })
+	return &delays
+}
+
+func pinRand(t *testing.T, v float64) {
+	t.Helper()
+	orig := randFloat
+	randFloat = func() float64 { return v }
+	t.Cleanup(func() { randFloat = orig })
+}
+
+// failing returns an fn that fails n times with err, then succeeds, and
+// a pointer to its call count.
+func failing(n int, err error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= n {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+var errTransient = errors.New("transient")
+
+func TestBackoffSchedule(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []time.Duration
+	}{
+		{
+			name:   "doubling",
+			policy: Policy{BaseBackoff: 100 * time.Millisecond},
+			want:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			name:   "capped",
+			policy: Policy{BaseBackoff: time.Second, MaxBackoff: 3 * time.Second},
+			want:   []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:   "base above cap",
+			policy: Policy{BaseBackoff: 10 * time.Second, MaxBackoff: time.Second},
+			want:   []time.Duration{time.Second, time.Second, time.Second, time.Second},
+		},
+		{
+			name:   "zero base",
+			policy: Policy{},
+			want:   []time.Duration{0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []time.Duration
+			for attempt := 1; attempt <= len(tt.want); attempt++ {
+				got = append(got, tt.policy.Backoff(attempt))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Backoff schedule = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDo_SleepsScheduleBetweenAttempts(t *testing.T) {
+	delays := recordSleeps(t)
+	fn, calls := failing(3, errTransient)
+
+	p := Policy{MaxAttempts: 5, BaseBackoff: 10 * time.Millisecond, MaxBackoff: 25 * time.Millisecond}
+	if err := Do(context.Background(), p, fn); err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	if *calls != 4 {
+		t.Errorf("calls = %d, want 4", *calls)
+	}
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond}
+	if !reflect.DeepEqual(*delays, want) {
+		t.Errorf("delays = %v, want %v", *delays, want)
+	}
+}
+
+func TestDo_JitterBounds(t *testing.T) {
+	p := Policy{BaseBackoff: time.Second, Jitter: 0.5}
+	for _, r := range []float64{0, 0.25, 0.999999} {
+		pinRand(t, r)
+		got := p.jittered(p.Backoff(1))
+		if got < 500*time.Millisecond || got > time.Second {
+			t.Errorf("jittered delay with rand %v = %v, want within [500ms, 1s]", r, got)
+		}
+	}
+
+	pinRand(t, 0.5)
+	if got := p.jittered(time.Second); got != 750*time.Millisecond {
+		t.Errorf("jittered(1s) with rand 0.5 = %v, want 750ms", got)
+	}
+
+	// Out-of-range jitter is clamped: never negative, never above the delay.
+	pinRand(t, 0.999999)
+	if got := (Policy{Jitter: 3}).jittered(time.Second); got < 0 || got > time.Second {
+		t.Errorf("jittered with Jitter 3 = %v, want within [0, 1s]", got)
+	}
+	if got := (Policy{Jitter: -1}).jittered(time.Second); got != time.Second {
+		t.Errorf("jittered with Jitter -1 = %v, want 1s", got)
+	}
+}
+
+func TestDo_NonRetryableShortCircuits(t *testing.T) {
+	delays := recordSleeps(t)
+	fatal := errors.New("syntax error")
+	fn, calls := failing(10, fatal)
+
+	p := Policy{MaxAttempts: 5, BaseBackoff: time.Second, Retryable: func(err error) bool { return errors.Is(err, errTransient) }}
+	err := Do(context.Background(), p, fn)
+	if err != fatal {
+		t.Errorf("Do() error = %v, want the non-retryable error unwrapped", err)
+	}
+	if *calls != 1 || len(*delays) != 0 {
+		t.Errorf("calls = %d, delays = %v; want one call and no sleep", *calls, *delays)
+	}
+}
+
+func TestDo_ExhaustsAttempts(t *testing.T) {
+	delays := recordSleeps(t)
+	fn, calls := failing(10, errTransient)
+
+	err := Do(context.Background(), Policy{MaxAttempts: 3, BaseBackoff: time.Millisecond}, fn)
+	if !errors.Is(err, errTransient) || !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("Do() error = %v, want errTransient after 3 attempts", err)
+	}
+	if *calls != 3 || len(*delays) != 2 {
+		t.Errorf("calls = %d, delays = %d; want 3 calls and 2 sleeps", *calls, len(*delays))
+	}
+}
+
+func TestDo_SingleAttemptDefault(t *testing.T) {
+	delays := recordSleeps(t)
+	fn, calls := failing(10, errTransient)
+
+	if err := Do(context.Background(), Policy{}, fn); !errors.Is(err, errTransient) {
+		t.Errorf("Do() error = %v, want errTransient", err)
+	}
+	if *calls != 1 || len(*delays) != 0 {
+		t.Errorf("calls = %d, delays = %v; want a single attempt", *calls, *delays)
+	}
+}
+
+func TestDo_ContextCancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fn, calls := failing(10, errTransient)
+
+	err := Do(ctx, Policy{MaxAttempts: 5, BaseBackoff: time.Hour}, fn)
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errTransient) {
+		t.Errorf("Do() error = %v, want both context.Canceled and the last error", err)
+	}
+	if *calls != 1 {
+		t.Errorf("calls = %d, want 1", *calls)
+	}
+}
@@ -0,0 +1,45 @@
+package session
+
+import "fmt"
+
+// AssigneeForm is how polecat addresses are written as bead assignees.
+type AssigneeForm string
+
+const (
+	// AssigneeExplicit writes polecats as "rig/polecats/name".
+	AssigneeExplicit AssigneeForm = "explicit"
+	// AssigneeCanonical writes polecats as the "rig/name" shorthand.
+	AssigneeCanonical AssigneeForm = "canonical"
+)
+
+// AssigneeFormat is the form AssigneeString writes polecat assignees in.
+// A town should pick one and keep it, since assignee lookups match the
+// stored string exactly. ApplyTownSettings sets it from the town's
+// assignee_format setting.
+var AssigneeFormat = AssigneeExplicit
+
+// ParseAssigneeForm parses an assignee_format setting. Empty means
+// AssigneeExplicit.
+func ParseAssigneeForm(s string) (AssigneeForm, error) {
+	switch AssigneeForm(s) {
+	case "", AssigneeExplicit:
+		return AssigneeExplicit, nil
+	case AssigneeCanonical:
+		return AssigneeCanonical, nil
+	}
+	return "", fmt.Errorf("unknown assignee format %q (want %q or %q)", s, AssigneeExplicit, AssigneeCanonical)
+}
+
+// AssigneeString returns the bead assignee for id: its Address, except
+// that polecats follow AssigneeFormat. Other roles have a single form.
+func AssigneeString(id *AgentIdentity) string {
+	if id.Role == RolePolecat && AssigneeFormat == AssigneeCanonical {
+		return fmt.Sprintf("%s/%s", id.Rig, id.Name)
+	}
+	return id.Address()
+}
+
+// PolecatAssignee returns the bead assignee for polecat name in rig.
+func PolecatAssignee(rig, name string) string {
+	return AssigneeString(&AgentIdentity{Role: RolePolecat, Rig: rig, Name: name})
+}
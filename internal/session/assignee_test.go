@@ -0,0 +1,79 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestAssigneeString(t *testing.T) {
+	orig := AssigneeFormat
+	t.Cleanup(func() { AssigneeFormat = orig })
+
+	ids := []struct {
+		id        AgentIdentity
+		explicit  string
+		canonical string
+	}{
+		{AgentIdentity{Role: RolePolecat, Rig: "gastown", Name: "Toast"}, "gastown/polecats/Toast", "gastown/Toast"},
+		{AgentIdentity{Role: RoleCrew, Rig: "gastown", Name: "max"}, "gastown/crew/max", "gastown/crew/max"},
+		{AgentIdentity{Role: RoleWitness, Rig: "gastown"}, "gastown/witness", "gastown/witness"},
+		{AgentIdentity{Role: RoleRefinery, Rig: "gastown"}, "gastown/refinery", "gastown/refinery"},
+		{AgentIdentity{Role: RoleMayor}, "mayor", "mayor"},
+		{AgentIdentity{Role: RoleDeacon}, "deacon", "deacon"},
+	}
+	for _, format := range []AssigneeForm{AssigneeExplicit, AssigneeCanonical} {
+		AssigneeFormat = format
+		for _, tt := range ids {
+			want := tt.explicit
+			if format == AssigneeCanonical {
+				want = tt.canonical
+			}
+			if got := AssigneeString(&tt.id); got != want {
+				t.Errorf("%s format: AssigneeString(%s) = %q, want %q", format, tt.id.Address(), got, want)
+			}
+		}
+	}
+}
+
+func TestAssigneeStringCanonicalParsesBack(t *testing.T) {
+	orig := AssigneeFormat
+	t.Cleanup(func() { AssigneeFormat = orig })
+	AssigneeFormat = AssigneeCanonical
+
+	id := &AgentIdentity{Role: RolePolecat, Rig: "gastown", Name: "Toast"}
+	back, err := ParseAddress(AssigneeString(id))
+	if err != nil {
+		t.Fatalf("ParseAddress() error: %v", err)
+	}
+	if back.Role != RolePolecat || back.Rig != "gastown" || back.Name != "Toast" {
+		t.Errorf("canonical assignee parses back as %+v", back)
+	}
+}
+
+func TestApplyTownSettingsAssigneeFormat(t *testing.T) {
+	orig := AssigneeFormat
+	t.Cleanup(func() { AssigneeFormat = orig })
+
+	if err := ApplyTownSettings(&config.TownSettings{AssigneeFormat: "canonical"}); err != nil {
+		t.Fatalf("ApplyTownSettings() error: %v", err)
+	}
+	if got := PolecatAssignee("gastown", "Toast"); got != "gastown/Toast" {
+		t.Errorf("PolecatAssignee() = %q, want gastown/Toast", got)
+	}
+	// BD_ACTOR must match the assignee polecats are looked up by.
+	env := config.AgentEnvSimple("polecat", "gastown", "Toast")
+	if env["BD_ACTOR"] != "gastown/Toast" {
+		t.Errorf("BD_ACTOR = %q, want gastown/Toast", env["BD_ACTOR"])
+	}
+
+	if err := ApplyTownSettings(&config.TownSettings{}); err != nil {
+		t.Fatalf("ApplyTownSettings() error: %v", err)
+	}
+	if AssigneeFormat != AssigneeExplicit {
+		t.Errorf("empty setting: AssigneeFormat = %q, want explicit", AssigneeFormat)
+	}
+	if err := ApplyTownSettings(&config.TownSettings{AssigneeFormat: "short"}); err == nil {
+		t.Error("ApplyTownSettings() should reject an unknown format")
+	}
+}
@@ -0,0 +1,91 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PrefixCollision is a pair of registered prefixes, or a prefix and the
+// town prefix, that can make session names ambiguous.
+type PrefixCollision struct {
+	Prefix string   // the shorter (or shared) prefix
+	Other  string   // the prefix it collides with
+	Rigs   []string // rigs using either prefix, sorted
+	Reason string
+}
+
+func (c PrefixCollision) String() string {
+	return fmt.Sprintf("prefixes %q and %q (%s): %s", c.Prefix, c.Other, strings.Join(c.Rigs, ", "), c.Reason)
+}
+
+// DetectPrefixCollisions checks the default registry; see
+// PrefixRegistry.DetectPrefixCollisions.
+func DetectPrefixCollisions() []PrefixCollision {
+	return defaultRegistry.DetectPrefixCollisions()
+}
+
+// DetectPrefixCollisions reports registered prefixes that can be confused
+// with each other or with the town-level HQ prefix:
+//   - two rigs sharing one prefix;
+//   - a prefix that starts with another plus a hyphen ("g" and "g-t"), so
+//     "g-t-witness" parses as either rig;
+//   - a prefix that starts with another ("g" and "gt"), which the hyphen
+//     keeps apart for ParseSessionName but not for plain string matching
+//     of session names and bead IDs;
+//   - a prefix whose sessions would be taken for town-level sessions.
+//
+// Collisions are sorted by prefix, then the other prefix.
+func (r *PrefixRegistry) DetectPrefixCollisions() []PrefixCollision {
+	rigsByPrefix := make(map[string][]string)
+	for rig, prefix := range r.AllRigs() {
+		rigsByPrefix[prefix] = append(rigsByPrefix[prefix], rig)
+	}
+	prefixes := make([]string, 0, len(rigsByPrefix))
+	for prefix, rigs := range rigsByPrefix {
+		sort.Strings(rigs)
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	rigsFor := func(prefixes ...string) []string {
+		var rigs []string
+		for _, p := range prefixes {
+			rigs = append(rigs, rigsByPrefix[p]...)
+		}
+		sort.Strings(rigs)
+		return rigs
+	}
+
+	var collisions []PrefixCollision
+	hq := HQPrefix()
+	for i, prefix := range prefixes {
+		if len(rigsByPrefix[prefix]) > 1 {
+			collisions = append(collisions, PrefixCollision{
+				Prefix: prefix, Other: prefix, Rigs: rigsFor(prefix),
+				Reason: "rigs share a prefix, so their sessions resolve to only one of them",
+			})
+		}
+		if strings.HasPrefix(prefix+"-", hq) {
+			collisions = append(collisions, PrefixCollision{
+				Prefix: prefix, Other: strings.TrimSuffix(hq, "-"), Rigs: rigsFor(prefix),
+				Reason: "sessions would be parsed as town-level sessions",
+			})
+		}
+		for _, other := range prefixes[i+1:] {
+			switch {
+			case strings.HasPrefix(other, prefix+"-"):
+				collisions = append(collisions, PrefixCollision{
+					Prefix: prefix, Other: other, Rigs: rigsFor(prefix, other),
+					Reason: "generated session names can collide",
+				})
+			case strings.HasPrefix(other, prefix):
+				collisions = append(collisions, PrefixCollision{
+					Prefix: prefix, Other: other, Rigs: rigsFor(prefix, other),
+					Reason: "one prefix starts with the other",
+				})
+			}
+		}
+	}
+	return collisions
+}
@@ -0,0 +1,65 @@
+package session
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectPrefixCollisions_Overlap(t *testing.T) {
+	r := NewPrefixRegistry()
+	r.Register("g", "garden")
+	r.Register("gt", "gastown")
+	r.Register("bd", "beads")
+
+	got := r.DetectPrefixCollisions()
+	if len(got) != 1 {
+		t.Fatalf("collisions = %v, want just g/gt", got)
+	}
+	c := got[0]
+	if c.Prefix != "g" || c.Other != "gt" || !reflect.DeepEqual(c.Rigs, []string{"garden", "gastown"}) {
+		t.Errorf("collision = %+v, want g/gt for garden and gastown", c)
+	}
+	if !strings.Contains(c.String(), `"g" and "gt"`) {
+		t.Errorf("String() = %q", c.String())
+	}
+}
+
+func TestDetectPrefixCollisions_Clean(t *testing.T) {
+	r := NewPrefixRegistry()
+	r.Register("gt", "gastown")
+	r.Register("bd", "beads")
+	r.Register("wy", "wyvern")
+
+	if got := r.DetectPrefixCollisions(); len(got) != 0 {
+		t.Errorf("collisions = %v, want none", got)
+	}
+	if got := NewPrefixRegistry().DetectPrefixCollisions(); len(got) != 0 {
+		t.Errorf("empty registry collisions = %v, want none", got)
+	}
+}
+
+func TestDetectPrefixCollisions_SessionNames(t *testing.T) {
+	r := NewPrefixRegistry()
+	r.Register("g", "garden")
+	r.Register("g-t", "gastown")
+	r.Register("hq", "headquarters")
+	r.Register("bd", "beads")
+	r.Register("bd", "beads-mirror")
+
+	reasons := make(map[string]string)
+	for _, c := range r.DetectPrefixCollisions() {
+		reasons[c.Prefix+"/"+c.Other] = c.Reason
+	}
+	for _, pair := range []string{"g/g-t", "hq/hq", "bd/bd"} {
+		if reasons[pair] == "" {
+			t.Errorf("missing collision %s in %v", pair, reasons)
+		}
+	}
+	if !strings.Contains(reasons["g/g-t"], "session names") {
+		t.Errorf("g/g-t reason = %q, want session names", reasons["g/g-t"])
+	}
+	if !strings.Contains(reasons["hq/hq"], "town-level") {
+		t.Errorf("hq reason = %q, want town-level", reasons["hq/hq"])
+	}
+}
@@ -3,6 +3,8 @@ package session
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
 )
 
@@ -107,9 +109,9 @@ func ParseSessionNameWithRegistry(session string, registry *PrefixRegistry) (*Ag
 		registry = NewPrefixRegistry()
 	}
 
-	// Check for town-level roles (hq- prefix)
-	if strings.HasPrefix(session, HQPrefix) {
-		suffix := strings.TrimPrefix(session, HQPrefix)
+	// Check for town-level roles (hq prefix)
+	if hq := HQPrefix(); strings.HasPrefix(session, hq) {
+		suffix := strings.TrimPrefix(session, hq)
 		switch suffix {
 		case "mayor":
 			return &AgentIdentity{Role: RoleMayor}, nil
@@ -120,7 +122,7 @@ func ParseSessionNameWithRegistry(session string, registry *PrefixRegistry) (*Ag
 		case "overseer":
 			return &AgentIdentity{Role: RoleOverseer}, nil
 		default:
-			return nil, fmt.Errorf("invalid session name %q: unknown hq- role", session)
+			return nil, fmt.Errorf("invalid session name %q: unknown hq role", session)
 		}
 	}
 
@@ -260,3 +262,94 @@ func (a *AgentIdentity) Address() string {
 func (a *AgentIdentity) GTRole() string {
 	return a.Address()
 }
+
+// currentTmuxSession returns the name of the tmux session this process runs in.
+// Var so tests can override it.
+var currentTmuxSession = func() (string, error) {
+	if os.Getenv("TMUX") == "" {
+		return "", fmt.Errorf("not in a tmux session")
+	}
+	out, err := exec.Command("tmux", "display-message", "-p", "#{session_name}").Output()
+	if err != nil {
+		return "", fmt.Errorf("getting tmux session name: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// IdentityFromEnv resolves the current agent's identity. GT_ROLE is parsed
+// as an address when set; otherwise the tmux session name is parsed.
+func IdentityFromEnv() (*AgentIdentity, error) {
+	if role := strings.TrimSpace(os.Getenv("GT_ROLE")); role != "" {
+		// deacon/boot is the boot watchdog, not a polecat in a "deacon" rig
+		if strings.TrimSuffix(role, "/") == "deacon/boot" {
+			return &AgentIdentity{Role: RoleDeacon, Name: "boot"}, nil
+		}
+		id, err := ParseAddress(role)
+		if err != nil {
+			return nil, fmt.Errorf("parsing GT_ROLE %q: %w", role, err)
+		}
+		return id, nil
+	}
+
+	sess, err := currentTmuxSession()
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine agent identity: GT_ROLE is not set and %w", err)
+	}
+	if sess == "" {
+		return nil, fmt.Errorf("cannot determine agent identity: GT_ROLE is not set and tmux session name is empty")
+	}
+	id, err := ParseSessionName(sess)
+	if err != nil {
+		return nil, fmt.Errorf("parsing tmux session %q: %w", sess, err)
+	}
+	return id, nil
+}
+
+// Equal reports whether a and other describe the same agent identity.
+// Two nil identities are equal; a nil and a non-nil identity are not.
+func (a *AgentIdentity) Equal(other *AgentIdentity) bool {
+	if a == nil || other == nil {
+		return a == other
+	}
+	return *a == *other
+}
+
+// roleOrder ranks roles for sorting, from town level down to workers.
+var roleOrder = map[Role]int{
+	RoleMayor:    0,
+	RoleDeacon:   1,
+	RoleOverseer: 2,
+	RoleWitness:  3,
+	RoleRefinery: 4,
+	RoleCrew:     5,
+	RolePolecat:  6,
+}
+
+// Less reports whether a sorts before other, ordering by role (town-level
+// roles first, polecats last), then rig, then name, then prefix.
+// A nil identity sorts before any non-nil identity.
+// Suitable for sort.Slice when maintaining deterministic agent rosters.
+func (a *AgentIdentity) Less(other *AgentIdentity) bool {
+	if a == nil || other == nil {
+		return a == nil && other != nil
+	}
+	if a.Role != other.Role {
+		ra, okA := roleOrder[a.Role]
+		rb, okB := roleOrder[other.Role]
+		switch {
+		case okA && okB:
+			return ra < rb
+		case okA != okB:
+			return okA // known roles sort before unknown ones
+		default:
+			return a.Role < other.Role
+		}
+	}
+	if a.Rig != other.Rig {
+		return a.Rig < other.Rig
+	}
+	if a.Name != other.Name {
+		return a.Name < other.Name
+	}
+	return a.Prefix < other.Prefix
+}
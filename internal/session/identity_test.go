@@ -1,6 +1,9 @@
 package session
 
 import (
+	"errors"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -420,3 +423,156 @@ func TestPrefixRegistry(t *testing.T) {
 		t.Errorf("RigForPrefix(zz) = %q, want %q", got, "zz")
 	}
 }
+
+func TestAgentIdentity_Equal(t *testing.T) {
+	toast := &AgentIdentity{Role: RolePolecat, Rig: "gastown", Name: "Toast", Prefix: "gt"}
+	toastCopy := &AgentIdentity{Role: RolePolecat, Rig: "gastown", Name: "Toast", Prefix: "gt"}
+	max := &AgentIdentity{Role: RoleCrew, Rig: "gastown", Name: "max", Prefix: "gt"}
+	var nilIdentity *AgentIdentity
+
+	tests := []struct {
+		name string
+		a, b *AgentIdentity
+		want bool
+	}{
+		{"same pointer", toast, toast, true},
+		{"equal values", toast, toastCopy, true},
+		{"different identities", toast, max, false},
+		{"both nil", nilIdentity, nil, true},
+		{"nil receiver", nilIdentity, toast, false},
+		{"nil argument", toast, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentIdentity_LessSortOrder(t *testing.T) {
+	identities := []*AgentIdentity{
+		{Role: RolePolecat, Rig: "gastown", Name: "Toast"},
+		{Role: RoleCrew, Rig: "gastown", Name: "max"},
+		{Role: RolePolecat, Rig: "beads", Name: "nux"},
+		nil,
+		{Role: RoleWitness, Rig: "gastown"},
+		{Role: RoleMayor},
+		{Role: RolePolecat, Rig: "gastown", Name: "Furiosa"},
+		{Role: RoleDeacon},
+		{Role: RoleRefinery, Rig: "beads"},
+		{Role: RoleWitness, Rig: "beads"},
+	}
+
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].Less(identities[j])
+	})
+
+	want := []string{
+		"<nil>",
+		"mayor",
+		"deacon",
+		"beads/witness",
+		"gastown/witness",
+		"beads/refinery",
+		"gastown/crew/max",
+		"beads/polecats/nux",
+		"gastown/polecats/Furiosa",
+		"gastown/polecats/Toast",
+	}
+	for i, id := range identities {
+		got := "<nil>"
+		if id != nil {
+			got = id.Address()
+		}
+		if got != want[i] {
+			t.Errorf("position %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestAgentIdentity_LessIsStrict(t *testing.T) {
+	a := &AgentIdentity{Role: RolePolecat, Rig: "gastown", Name: "Toast"}
+	b := &AgentIdentity{Role: RolePolecat, Rig: "gastown", Name: "Toast"}
+	if a.Less(b) || b.Less(a) {
+		t.Error("equal identities must not be Less than each other")
+	}
+	var nilIdentity *AgentIdentity
+	if nilIdentity.Less(nil) {
+		t.Error("nil must not be Less than nil")
+	}
+}
+
+// stubTmuxSession replaces currentTmuxSession for the duration of a test.
+func stubTmuxSession(t *testing.T, name string, err error) {
+	t.Helper()
+	orig := currentTmuxSession
+	currentTmuxSession = func() (string, error) { return name, err }
+	t.Cleanup(func() { currentTmuxSession = orig })
+}
+
+func TestIdentityFromEnv_GTRole(t *testing.T) {
+	stubTmuxSession(t, "", errors.New("tmux should not be consulted"))
+
+	tests := []struct {
+		role string
+		want AgentIdentity
+	}{
+		{"mayor", AgentIdentity{Role: RoleMayor}},
+		{"deacon", AgentIdentity{Role: RoleDeacon}},
+		{"deacon/boot", AgentIdentity{Role: RoleDeacon, Name: "boot"}},
+		{"gastown/witness", AgentIdentity{Role: RoleWitness, Rig: "gastown", Prefix: PrefixFor("gastown")}},
+		{"gastown/refinery", AgentIdentity{Role: RoleRefinery, Rig: "gastown", Prefix: PrefixFor("gastown")}},
+		{"beads/crew/jane", AgentIdentity{Role: RoleCrew, Rig: "beads", Name: "jane", Prefix: PrefixFor("beads")}},
+		{"gastown/polecats/Toast", AgentIdentity{Role: RolePolecat, Rig: "gastown", Name: "Toast", Prefix: PrefixFor("gastown")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			t.Setenv("GT_ROLE", tt.role)
+			got, err := IdentityFromEnv()
+			if err != nil {
+				t.Fatalf("IdentityFromEnv() error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("IdentityFromEnv() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentityFromEnv_InvalidGTRole(t *testing.T) {
+	stubTmuxSession(t, "hq-mayor", nil)
+	t.Setenv("GT_ROLE", "gastown/crew")
+
+	if _, err := IdentityFromEnv(); err == nil || !strings.Contains(err.Error(), "GT_ROLE") {
+		t.Errorf("IdentityFromEnv() error = %v, want GT_ROLE parse error", err)
+	}
+}
+
+func TestIdentityFromEnv_TmuxFallback(t *testing.T) {
+	t.Setenv("GT_ROLE", "")
+	stubTmuxSession(t, "hq-deacon", nil)
+
+	got, err := IdentityFromEnv()
+	if err != nil {
+		t.Fatalf("IdentityFromEnv() error: %v", err)
+	}
+	if got.Role != RoleDeacon {
+		t.Errorf("IdentityFromEnv() role = %q, want %q", got.Role, RoleDeacon)
+	}
+}
+
+func TestIdentityFromEnv_Unset(t *testing.T) {
+	t.Setenv("GT_ROLE", "")
+	stubTmuxSession(t, "", errors.New("not in a tmux session"))
+
+	_, err := IdentityFromEnv()
+	if err == nil {
+		t.Fatal("IdentityFromEnv() should error when GT_ROLE and tmux are both unavailable")
+	}
+	if !strings.Contains(err.Error(), "GT_ROLE is not set") {
+		t.Errorf("error %q should explain that GT_ROLE is not set", err)
+	}
+}
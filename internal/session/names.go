@@ -3,24 +3,59 @@ package session
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 )
 
 // DefaultPrefix is the default beads prefix used when no rig-specific prefix is known.
 const DefaultPrefix = "gt"
 
-// HQPrefix is the prefix for town-level services (Mayor, Deacon).
-const HQPrefix = "hq-"
+// DefaultHQPrefix is the default prefix for town-level services (Mayor, Deacon).
+const DefaultHQPrefix = "hq"
+
+var (
+	hqPrefixMu sync.RWMutex
+	hqPrefix   = DefaultHQPrefix
+)
+
+// HQPrefix returns the session name prefix for town-level services,
+// including the trailing dash (e.g., "hq-").
+func HQPrefix() string {
+	hqPrefixMu.RLock()
+	defer hqPrefixMu.RUnlock()
+	return hqPrefix + "-"
+}
+
+// SetHQPrefix sets the prefix used for town-level session names, so that
+// two towns on one machine can use distinct names (e.g., "acme" gives
+// "acme-mayor"). An empty prefix restores DefaultHQPrefix. Both session
+// name generation and ParseSessionName use it.
+//
+// The prefix must not be a registered rig prefix: "<prefix>-mayor" would
+// then also name a polecat called mayor, and parses as the Mayor.
+func SetHQPrefix(prefix string) error {
+	if prefix == "" {
+		prefix = DefaultHQPrefix
+	}
+	if strings.ContainsAny(prefix, "-.: \t\n") {
+		return fmt.Errorf("invalid hq prefix %q: must not contain '-', '.', ':' or whitespace", prefix)
+	}
+	hqPrefixMu.Lock()
+	defer hqPrefixMu.Unlock()
+	hqPrefix = prefix
+	return nil
+}
 
 // MayorSessionName returns the session name for the Mayor agent.
 // One mayor per machine - multi-town requires containers/VMs for isolation.
 func MayorSessionName() string {
-	return HQPrefix + "mayor"
+	return HQPrefix() + "mayor"
 }
 
 // DeaconSessionName returns the session name for the Deacon agent.
 // One deacon per machine - multi-town requires containers/VMs for isolation.
 func DeaconSessionName() string {
-	return HQPrefix + "deacon"
+	return HQPrefix() + "deacon"
 }
 
 // WitnessSessionName returns the session name for a rig's Witness agent.
@@ -50,12 +85,19 @@ func PolecatSessionName(rigPrefix, name string) string {
 // OverseerSessionName returns the session name for the human operator.
 // The overseer is the human who controls Gas Town, not an AI agent.
 func OverseerSessionName() string {
-	return HQPrefix + "overseer"
+	return HQPrefix() + "overseer"
+}
+
+// DogSessionName returns the session name for a Deacon dog. Dogs are
+// town-level, so they use the hq prefix; "dog-" rather than "deacon-"
+// avoids tmux prefix-matching collisions with the Deacon's session.
+func DogSessionName(name string) string {
+	return HQPrefix() + "dog-" + name
 }
 
 // BootSessionName returns the session name for the Boot watchdog.
-// Boot is town-level (launched by deacon), so it uses the hq- prefix.
+// Boot is town-level (launched by deacon), so it uses the hq prefix.
 // "hq-boot" avoids tmux prefix-matching collisions with "hq-deacon".
 func BootSessionName() string {
-	return HQPrefix + "boot"
+	return HQPrefix() + "boot"
 }
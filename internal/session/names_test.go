@@ -1,6 +1,7 @@
 package session
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -116,3 +117,64 @@ func TestDefaultPrefix(t *testing.T) {
 		t.Errorf("DefaultPrefix = %q, want %q", DefaultPrefix, want)
 	}
 }
+
+func TestSetHQPrefix_RoundTrip(t *testing.T) {
+	t.Cleanup(func() { _ = SetHQPrefix("") })
+
+	if err := SetHQPrefix("acme"); err != nil {
+		t.Fatalf("SetHQPrefix() error: %v", err)
+	}
+
+	tests := []struct {
+		session string
+		want    AgentIdentity
+	}{
+		{MayorSessionName(), AgentIdentity{Role: RoleMayor}},
+		{DeaconSessionName(), AgentIdentity{Role: RoleDeacon}},
+		{BootSessionName(), AgentIdentity{Role: RoleDeacon, Name: "boot"}},
+	}
+	for _, tt := range tests {
+		if !strings.HasPrefix(tt.session, "acme-") {
+			t.Errorf("session %q should use the acme- prefix", tt.session)
+		}
+		got, err := ParseSessionName(tt.session)
+		if err != nil {
+			t.Errorf("ParseSessionName(%q) error: %v", tt.session, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("ParseSessionName(%q) = %+v, want %+v", tt.session, *got, tt.want)
+		}
+		if name := got.SessionName(); name != tt.session {
+			t.Errorf("SessionName() = %q, want %q", name, tt.session)
+		}
+	}
+
+	if _, err := ParseSessionName("hq-mayor"); err == nil {
+		t.Error("default hq-mayor should not parse once the hq prefix is changed")
+	}
+
+	if got := DogSessionName("alpha"); got != "acme-dog-alpha" {
+		t.Errorf("DogSessionName() = %q, want acme-dog-alpha", got)
+	}
+
+	if err := SetHQPrefix(""); err != nil {
+		t.Fatalf("SetHQPrefix(\"\") error: %v", err)
+	}
+	if got := MayorSessionName(); got != "hq-mayor" {
+		t.Errorf("MayorSessionName() after reset = %q, want hq-mayor", got)
+	}
+}
+
+func TestSetHQPrefix_Invalid(t *testing.T) {
+	t.Cleanup(func() { _ = SetHQPrefix("") })
+
+	for _, prefix := range []string{"my-town", "a.b", "a:b", "a b"} {
+		if err := SetHQPrefix(prefix); err == nil {
+			t.Errorf("SetHQPrefix(%q) should be rejected", prefix)
+		}
+	}
+	if got := HQPrefix(); got != "hq-" {
+		t.Errorf("HQPrefix() = %q after invalid sets, want hq-", got)
+	}
+}
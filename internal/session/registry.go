@@ -174,7 +174,7 @@ func (r *PrefixRegistry) HasPrefix(sess string) bool {
 // IsKnownSession returns true if the session name belongs to Gas Town.
 // Checks for HQ prefix and registered rig prefixes from the default registry.
 func IsKnownSession(sess string) bool {
-	if strings.HasPrefix(sess, HQPrefix) {
+	if strings.HasPrefix(sess, HQPrefix()) {
 		return true
 	}
 	return defaultRegistry.HasPrefix(sess)
@@ -0,0 +1,20 @@
+package session
+
+import "github.com/steveyegge/gastown/internal/config"
+
+// ApplyTownSettings applies the session-related town settings: the polecat
+// assignee format and the hq session prefix. It also points
+// config.PolecatActor at PolecatAssignee, so BD_ACTOR matches the assignees
+// polecats are looked up by.
+func ApplyTownSettings(settings *config.TownSettings) error {
+	form, err := ParseAssigneeForm(settings.AssigneeFormat)
+	if err != nil {
+		return err
+	}
+	if err := SetHQPrefix(settings.HQPrefix); err != nil {
+		return err
+	}
+	AssigneeFormat = form
+	config.PolecatActor = PolecatAssignee
+	return nil
+}
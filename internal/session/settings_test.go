@@ -0,0 +1,21 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestApplyTownSettingsHQPrefix(t *testing.T) {
+	t.Cleanup(func() { _ = SetHQPrefix("") })
+
+	if err := ApplyTownSettings(&config.TownSettings{HQPrefix: "acme"}); err != nil {
+		t.Fatalf("ApplyTownSettings() error: %v", err)
+	}
+	if got := MayorSessionName(); got != "acme-mayor" {
+		t.Errorf("MayorSessionName() = %q, want acme-mayor", got)
+	}
+	if err := ApplyTownSettings(&config.TownSettings{HQPrefix: "my-town"}); err == nil {
+		t.Error("ApplyTownSettings() should reject an hq prefix containing '-'")
+	}
+}
@@ -0,0 +1,92 @@
+package style
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// spinnerFrames are drawn in turn, one per spinnerInterval.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner animates a one-line progress indicator while a slow operation
+// runs. It only draws when its writer is a terminal; otherwise Start and
+// Stop do nothing, so piped or scripted output is unchanged.
+type Spinner struct {
+	w       io.Writer
+	message string
+	enabled bool
+
+	mu      sync.Mutex
+	running bool
+	stopped bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewSpinner returns a spinner that draws message on w. It is enabled only
+// when w is a terminal.
+func NewSpinner(w io.Writer, message string) *Spinner {
+	f, ok := w.(*os.File)
+	return &Spinner{
+		w:       w,
+		message: message,
+		enabled: ok && term.IsTerminal(int(f.Fd())),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Enabled reports whether the spinner draws anything.
+func (s *Spinner) Enabled() bool {
+	return s.enabled
+}
+
+// Start begins drawing the spinner. It has no effect if the spinner is
+// disabled, already running, or stopped.
+func (s *Spinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled || s.running || s.stopped {
+		return
+	}
+	s.running = true
+	go s.run()
+}
+
+// Stop stops the spinner and erases its line, leaving the cursor at the
+// start of an empty line so following output, including errors, prints
+// cleanly. It is safe to call more than once, so callers can both defer it
+// and call it on success.
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	if !s.running {
+		return
+	}
+	s.running = false
+	close(s.stop)
+	<-s.done
+	fmt.Fprint(s.w, "\r\033[K")
+}
+
+func (s *Spinner) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		fmt.Fprintf(s.w, "\r%s %s", Info.Render(spinnerFrames[i%len(spinnerFrames)]), s.message)
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package style
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestSpinnerNoOpWithoutTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewSpinner(&buf, "Cloning...")
+	if s.Enabled() {
+		t.Fatal("spinner on a bytes.Buffer should be disabled")
+	}
+	s.Start()
+	s.Stop()
+	s.Stop()
+	if buf.Len() != 0 {
+		t.Errorf("disabled spinner wrote %q", buf.String())
+	}
+}
+
+func TestSpinnerNoOpOnPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	s := NewSpinner(w, "Cloning...")
+	if s.Enabled() {
+		t.Fatal("spinner on a pipe should be disabled")
+	}
+	s.Start()
+	s.Stop()
+	w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 0 {
+		t.Errorf("disabled spinner wrote %q", out)
+	}
+}
+
+func TestSpinnerStopErasesLine(t *testing.T) {
+	var buf bytes.Buffer
+	// Force-enable to exercise drawing without a terminal.
+	s := NewSpinner(&buf, "Cloning...")
+	s.enabled = true
+
+	s.Stop() // before Start: nothing to erase
+	if buf.Len() != 0 {
+		t.Errorf("Stop before Start wrote %q", buf.String())
+	}
+
+	s = NewSpinner(&buf, "Cloning...")
+	s.enabled = true
+	s.Start()
+	s.Stop()
+	s.Stop()
+	out := buf.String()
+	if !bytes.Contains(buf.Bytes(), []byte("Cloning...")) {
+		t.Errorf("spinner output %q should contain the message", out)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\r\033[K")) {
+		t.Errorf("spinner output %q should end by erasing the line", out)
+	}
+	s.Start() // no restart after Stop
+	if buf.String() != out {
+		t.Errorf("Start after Stop wrote %q", buf.String()[len(out):])
+	}
+}
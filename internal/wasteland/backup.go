@@ -0,0 +1,112 @@
+package wasteland
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Dump formats supported by DumpCommons.
+const (
+	DumpFormatSQL = "sql"
+	DumpFormatCSV = "csv"
+)
+
+// runDoltDump runs dolt dump with args in dbDir. Var so tests can stub it.
+var runDoltDump = func(dbDir string, args ...string) error {
+	cmd := exec.Command("dolt", append([]string{"dump"}, args...)...)
+	cmd.Dir = dbDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("dolt dump: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// BackupMetadata describes a commons dump. It is written next to the dump
+// as <name>.meta.json.
+type BackupMetadata struct {
+	CreatedAt     time.Time `json:"created_at"`
+	Format        string    `json:"format"`
+	Dump          string    `json:"dump"` // dump file (sql) or directory (csv), relative to the metadata file
+	Commit        string    `json:"commit"`
+	SchemaVersion string    `json:"schema_version,omitempty"`
+}
+
+// DumpCommons writes a timestamped dump of the commons database in dbDir to
+// outDir, along with a metadata file recording the commit it was taken at
+// and the commons schema version.
+//
+// The sql format writes commons-<timestamp>.sql; the csv format writes a
+// commons-<timestamp>/ directory with one file per table.
+func DumpCommons(dbDir, outDir, format string) error {
+	if format != DumpFormatSQL && format != DumpFormatCSV {
+		return fmt.Errorf("invalid dump format %q (want %s or %s)", format, DumpFormatSQL, DumpFormatCSV)
+	}
+
+	absOut, err := filepath.Abs(outDir)
+	if err != nil {
+		return fmt.Errorf("resolving output directory: %w", err)
+	}
+	if err := os.MkdirAll(absOut, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	commit, err := queryDoltValue(dbDir, "SELECT DOLT_HASHOF('HEAD') AS hash")
+	if err != nil {
+		return fmt.Errorf("reading commons commit: %w", err)
+	}
+	// Older commons without a _meta table still dump; the version is just unknown.
+	schemaVersion, _ := queryDoltValue(dbDir, "SELECT value FROM _meta WHERE `key` = 'schema_version'")
+
+	now := Clock().UTC()
+	name := "commons-" + now.Format("20060102T150405Z")
+	meta := BackupMetadata{
+		CreatedAt:     now,
+		Format:        format,
+		Commit:        commit,
+		SchemaVersion: schemaVersion,
+	}
+
+	switch format {
+	case DumpFormatSQL:
+		meta.Dump = name + ".sql"
+		err = runDoltDump(dbDir, "-r", "sql", "-fn", filepath.Join(absOut, meta.Dump))
+	case DumpFormatCSV:
+		meta.Dump = name
+		err = runDoltDump(dbDir, "-r", "csv", "-d", filepath.Join(absOut, meta.Dump))
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding backup metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(absOut, name+".meta.json"), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing backup metadata: %w", err)
+	}
+	return nil
+}
+
+// queryDoltValue runs a query returning a single value and returns it, or ""
+// if the query returns no rows.
+func queryDoltValue(dbDir, query string) (string, error) {
+	output, err := runDoltQuery(dbDir, query)
+	if err != nil {
+		return "", err
+	}
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		return "", fmt.Errorf("parsing query output: %w", err)
+	}
+	if len(records) < 2 || len(records[1]) == 0 {
+		return "", nil
+	}
+	return strings.TrimSpace(records[1][0]), nil
+}
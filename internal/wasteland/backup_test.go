@@ -0,0 +1,100 @@
+package wasteland
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubDoltDump replaces runDoltDump for the duration of a test, recording
+// each invocation's arguments.
+func stubDoltDump(t *testing.T) *[][]string {
+	t.Helper()
+	var calls [][]string
+	orig := runDoltDump
+	runDoltDump = func(dbDir string, args ...string) error {
+		calls = append(calls, args)
+		return nil
+	}
+	t.Cleanup(func() { runDoltDump = orig })
+	return &calls
+}
+
+func pinClock(t *testing.T, at time.Time) {
+	t.Helper()
+	orig := Clock
+	Clock = func() time.Time { return at }
+	t.Cleanup(func() { Clock = orig })
+}
+
+func TestDumpCommons(t *testing.T) {
+	at := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := []struct {
+		format   string
+		wantDump string
+		wantArgs func(outDir string) []string
+	}{
+		{DumpFormatSQL, "commons-20260304T050607Z.sql", func(outDir string) []string {
+			return []string{"-r", "sql", "-fn", filepath.Join(outDir, "commons-20260304T050607Z.sql")}
+		}},
+		{DumpFormatCSV, "commons-20260304T050607Z", func(outDir string) []string {
+			return []string{"-r", "csv", "-d", filepath.Join(outDir, "commons-20260304T050607Z")}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			pinClock(t, at)
+			stubDoltQuery(t, map[string]string{
+				"DOLT_HASHOF": "hash\nabc123def\n",
+				"_meta":       "value\n1.0\n",
+			})
+			dumps := stubDoltDump(t)
+			outDir := filepath.Join(t.TempDir(), "backups")
+
+			if err := DumpCommons(t.TempDir(), outDir, tt.format); err != nil {
+				t.Fatalf("DumpCommons() error: %v", err)
+			}
+
+			if len(*dumps) != 1 || !reflect.DeepEqual((*dumps)[0], tt.wantArgs(outDir)) {
+				t.Errorf("dolt dump calls = %v, want [%v]", *dumps, tt.wantArgs(outDir))
+			}
+
+			data, err := os.ReadFile(filepath.Join(outDir, "commons-20260304T050607Z.meta.json"))
+			if err != nil {
+				t.Fatalf("reading metadata: %v", err)
+			}
+			var meta BackupMetadata
+			if err := json.Unmarshal(data, &meta); err != nil {
+				t.Fatalf("parsing metadata: %v", err)
+			}
+			want := BackupMetadata{
+				CreatedAt:     at,
+				Format:        tt.format,
+				Dump:          tt.wantDump,
+				Commit:        "abc123def",
+				SchemaVersion: "1.0",
+			}
+			if !reflect.DeepEqual(meta, want) {
+				t.Errorf("metadata = %+v, want %+v", meta, want)
+			}
+		})
+	}
+}
+
+func TestDumpCommons_InvalidFormat(t *testing.T) {
+	dumps := stubDoltDump(t)
+
+	err := DumpCommons(t.TempDir(), t.TempDir(), "xml")
+	if err == nil || !strings.Contains(err.Error(), "invalid dump format") {
+		t.Errorf("DumpCommons() error = %v, want invalid format", err)
+	}
+	if len(*dumps) != 0 {
+		t.Errorf("dolt dump should not run for an invalid format, got %v", *dumps)
+	}
+}
@@ -0,0 +1,93 @@
+package wasteland
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cloneWaitDelay bounds how long a cancelled clone may hold its output open
+// after dolt is killed.
+const cloneWaitDelay = 2 * time.Second
+
+// CloneCommonsContext clones commonsRepo (a DoltHub path like
+// "hop/wl-commons") into a new temporary directory and returns the clone's
+// directory, streaming dolt's progress output to stderr. The caller owns
+// the clone and should remove filepath.Dir of the returned path when done.
+//
+// Cancelling ctx kills the clone and removes the temporary directory.
+func CloneCommonsContext(ctx context.Context, commonsRepo string) (string, error) {
+	return CloneCommonsContextProgress(ctx, commonsRepo, os.Stderr)
+}
+
+// CloneCommonsContextProgress is like CloneCommonsContext but streams
+// dolt's progress output to progress, which may be nil to discard it.
+func CloneCommonsContextProgress(ctx context.Context, commonsRepo string, progress io.Writer) (string, error) {
+	return cloneCommons(ctx, commonsRepo, "", progress)
+}
+
+// cloneCommons clones branch of commonsRepo, or its default branch if
+// branch is empty, streaming dolt's progress output to progress.
+func cloneCommons(ctx context.Context, commonsRepo, branch string, progress io.Writer) (string, error) {
+	_, db, err := ParseUpstream(commonsRepo)
+	if err != nil {
+		return "", err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "wl-commons-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temp directory: %w", err)
+	}
+	cloneDir := filepath.Join(tmpDir, db)
+
+	// Keep the tail of stderr for the error message while streaming it.
+	var stderr bytes.Buffer
+	var out io.Writer = &stderr
+	if progress != nil {
+		out = io.MultiWriter(progress, &stderr)
+	}
+
+	args := []string{"clone"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	cmd := exec.CommandContext(ctx, "dolt", append(args, commonsRepo, cloneDir)...)
+	cmd.Stderr = out
+	cmd.WaitDelay = cloneWaitDelay
+	if err := cmd.Run(); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("cloning %s: %w", commonsRepo, ctxErr)
+		}
+		return "", fmt.Errorf("dolt clone %s: %w (%s)", commonsRepo, err, strings.TrimSpace(stderr.String()))
+	}
+	return cloneDir, nil
+}
+
+// CloneCommonsWithFallbacks clones the first of refs that succeeds, so a
+// town can read from a mirror while the primary commons is down. Each ref
+// is cloned at branch, or at its default branch if branch is empty. It
+// returns the clone's directory (remove filepath.Dir of it when done) and
+// the ref that served it. If every ref fails the errors are joined.
+func CloneCommonsWithFallbacks(refs []string, branch string) (dir, usedRef string, err error) {
+	if len(refs) == 0 {
+		return "", "", fmt.Errorf("no commons to clone")
+	}
+
+	var errs []error
+	for _, ref := range refs {
+		dir, err := cloneCommons(context.Background(), ref, branch, nil)
+		if err == nil {
+			return dir, ref, nil
+		}
+		errs = append(errs, err)
+	}
+	return "", "", fmt.Errorf("all %d commons sources failed: %w", len(refs), errors.Join(errs...))
+}
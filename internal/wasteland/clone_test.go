@@ -0,0 +1,133 @@
+package wasteland
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// installFakeDolt puts a dolt script with the given body on PATH and points
+// TMPDIR at a fresh directory, which it returns.
+func installFakeDolt(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake dolt script requires a POSIX shell")
+	}
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "dolt"), []byte("#!/bin/sh\n"+body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	return tmp
+}
+
+func TestCloneCommonsContext(t *testing.T) {
+	tmp := installFakeDolt(t, `mkdir -p "$3"; echo "cloning $2" >&2`)
+	var progress strings.Builder
+
+	dir, err := CloneCommonsContextProgress(context.Background(), "hop/wl-commons", &progress)
+	if err != nil {
+		t.Fatalf("CloneCommonsContextProgress() error: %v", err)
+	}
+	if filepath.Base(dir) != "wl-commons" || !strings.HasPrefix(dir, tmp) {
+		t.Errorf("clone dir = %q, want wl-commons under %s", dir, tmp)
+	}
+	if !strings.Contains(progress.String(), "cloning hop/wl-commons") {
+		t.Errorf("progress = %q, want dolt's stderr streamed", progress.String())
+	}
+}
+
+func TestCloneCommonsContext_CancelCleansUp(t *testing.T) {
+	// The clone starts writing, then hangs until killed.
+	tmp := installFakeDolt(t, `mkdir -p "$3"; exec sleep 30`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := CloneCommonsContextProgress(ctx, "hop/wl-commons", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("cancelled clone took %v to return", elapsed)
+	}
+
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("temp dir not cleaned up: %v", entries)
+	}
+}
+
+func TestCloneCommonsContext_Failure(t *testing.T) {
+	tmp := installFakeDolt(t, `echo "repository not found" >&2; exit 1`)
+
+	_, err := CloneCommonsContextProgress(context.Background(), "hop/wl-commons", nil)
+	if err == nil || !strings.Contains(err.Error(), "repository not found") {
+		t.Errorf("error = %v, want dolt's stderr", err)
+	}
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("temp dir not cleaned up: %v", entries)
+	}
+}
+
+func TestCloneCommonsWithFallbacks(t *testing.T) {
+	// The primary is down; only the mirror clones.
+	installFakeDolt(t, `if [ "$2" = "hop/wl-commons" ]; then echo "repository not found" >&2; exit 1; fi
+mkdir -p "$3"`)
+
+	dir, used, err := CloneCommonsWithFallbacks([]string{"hop/wl-commons", "mirror/wl-commons", "other/wl-commons"}, "")
+	if err != nil {
+		t.Fatalf("CloneCommonsWithFallbacks() error: %v", err)
+	}
+	defer os.RemoveAll(filepath.Dir(dir))
+	if used != "mirror/wl-commons" {
+		t.Errorf("used = %q, want the first working fallback", used)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("clone dir %q missing: %v", dir, err)
+	}
+}
+
+func TestCloneCommonsWithFallbacks_AllFail(t *testing.T) {
+	installFakeDolt(t, `echo "down for maintenance" >&2; exit 1`)
+
+	_, _, err := CloneCommonsWithFallbacks([]string{"hop/wl-commons", "mirror/wl-commons"}, "")
+	if err == nil {
+		t.Fatal("expected an error when every source fails")
+	}
+	for _, ref := range []string{"hop/wl-commons", "mirror/wl-commons"} {
+		if !strings.Contains(err.Error(), ref) {
+			t.Errorf("error %q should mention %s", err, ref)
+		}
+	}
+}
+
+func TestCloneCommonsWithFallbacks_Branch(t *testing.T) {
+	tmp := installFakeDolt(t, `echo "$@" > "$TMPDIR/args"; mkdir -p "$5"`)
+
+	dir, _, err := CloneCommonsWithFallbacks([]string{"hop/wl-commons"}, "trunk")
+	if err != nil {
+		t.Fatalf("CloneCommonsWithFallbacks() error: %v", err)
+	}
+	defer os.RemoveAll(filepath.Dir(dir))
+
+	args, err := os.ReadFile(filepath.Join(tmp, "args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "clone --branch trunk hop/wl-commons " + dir; strings.TrimSpace(string(args)) != want {
+		t.Errorf("dolt args = %q, want %q", strings.TrimSpace(string(args)), want)
+	}
+}
@@ -0,0 +1,94 @@
+package wasteland
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CommonsConfig is the town's shared setting for which commons the wl
+// commands read, kept in settings/wasteland.json. Every field is optional.
+type CommonsConfig struct {
+	// Commons is the DoltHub path of the commons (e.g., "hop/wl-commons").
+	Commons string `json:"commons,omitempty"`
+
+	// Fallbacks are mirrors to read from, in order, when Commons cannot be
+	// cloned.
+	Fallbacks []string `json:"fallbacks,omitempty"`
+
+	// Branch is the commons branch to clone; empty clones the commons'
+	// default branch.
+	Branch string `json:"branch,omitempty"`
+
+	// AuthHint is shown when the commons cannot be reached, e.g. to point
+	// at the town's DoltHub credentials.
+	AuthHint string `json:"auth_hint,omitempty"`
+}
+
+// CommonsConfigPath returns the path of the town's commons config.
+func CommonsConfigPath(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "wasteland.json")
+}
+
+// LoadCommonsConfig loads the town's commons config. A town without one, or
+// an empty townRoot, gets an empty config.
+func LoadCommonsConfig(townRoot string) (*CommonsConfig, error) {
+	cfg := &CommonsConfig{}
+	if townRoot == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(CommonsConfigPath(townRoot))
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading commons config: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", CommonsConfigPath(townRoot), err)
+	}
+	for _, ref := range append([]string{cfg.Commons}, cfg.Fallbacks...) {
+		if ref != "" && !isCommonsRef(ref) {
+			return nil, fmt.Errorf("parsing %s: %q is not an org/database path", CommonsConfigPath(townRoot), ref)
+		}
+	}
+	return cfg, nil
+}
+
+func isCommonsRef(ref string) bool {
+	org, db, ok := strings.Cut(ref, "/")
+	return ok && org != "" && db != "" && !strings.Contains(db, "/")
+}
+
+// CommonsRef returns the commons to read: flag if set, then the config's
+// Commons, then the built-in default. The GASTOWN_WL_UPSTREAM environment
+// variable replaces only the built-in default (UpstreamCommons), e.g. to
+// point a town without a config at a private mirror; it never overrides
+// the town's config. cfg may be nil.
+func (cfg *CommonsConfig) CommonsRef(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	if cfg != nil && cfg.Commons != "" {
+		return cfg.Commons
+	}
+	if ref := os.Getenv("GASTOWN_WL_UPSTREAM"); ref != "" {
+		return ref
+	}
+	return UpstreamCommons
+}
+
+// FallbackRefs returns the mirrors to try after the commons: flags if any
+// were given, otherwise the config's Fallbacks. cfg may be nil.
+func (cfg *CommonsConfig) FallbackRefs(flags []string) []string {
+	if len(flags) > 0 {
+		return flags
+	}
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Fallbacks
+}
@@ -0,0 +1,108 @@
+package wasteland
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeCommonsConfig(t *testing.T, townRoot, content string) {
+	t.Helper()
+	path := CommonsConfigPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadCommonsConfig(t *testing.T) {
+	townRoot := t.TempDir()
+	writeCommonsConfig(t, townRoot, `{
+  "commons": "acme/wl-commons",
+  "fallbacks": ["mirror/wl-commons"],
+  "branch": "trunk",
+  "auth_hint": "run dolt login as acme-bot"
+}`)
+
+	cfg, err := LoadCommonsConfig(townRoot)
+	if err != nil {
+		t.Fatalf("LoadCommonsConfig() error: %v", err)
+	}
+	want := &CommonsConfig{
+		Commons:   "acme/wl-commons",
+		Fallbacks: []string{"mirror/wl-commons"},
+		Branch:    "trunk",
+		AuthHint:  "run dolt login as acme-bot",
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("LoadCommonsConfig() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadCommonsConfigMissing(t *testing.T) {
+	for _, townRoot := range []string{t.TempDir(), ""} {
+		cfg, err := LoadCommonsConfig(townRoot)
+		if err != nil {
+			t.Fatalf("LoadCommonsConfig(%q) error: %v", townRoot, err)
+		}
+		if !reflect.DeepEqual(cfg, &CommonsConfig{}) {
+			t.Errorf("LoadCommonsConfig(%q) = %+v, want an empty config", townRoot, cfg)
+		}
+	}
+}
+
+func TestLoadCommonsConfigInvalid(t *testing.T) {
+	tests := map[string]string{
+		"bad json":     `{"commons":`,
+		"bad commons":  `{"commons": "wl-commons"}`,
+		"bad fallback": `{"commons": "acme/wl-commons", "fallbacks": ["a/b/c"]}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			townRoot := t.TempDir()
+			writeCommonsConfig(t, townRoot, content)
+			if _, err := LoadCommonsConfig(townRoot); err == nil || !strings.Contains(err.Error(), "wasteland.json") {
+				t.Errorf("LoadCommonsConfig() error = %v, want one naming the file", err)
+			}
+		})
+	}
+}
+
+func TestCommonsRefPrecedence(t *testing.T) {
+	cfg := &CommonsConfig{Commons: "acme/wl-commons", Fallbacks: []string{"acme/mirror"}}
+
+	t.Setenv("GASTOWN_WL_UPSTREAM", "")
+	if got := cfg.CommonsRef("flag/wl-commons"); got != "flag/wl-commons" {
+		t.Errorf("flag: CommonsRef() = %q", got)
+	}
+	if got := cfg.CommonsRef(""); got != "acme/wl-commons" {
+		t.Errorf("config: CommonsRef() = %q", got)
+	}
+	var none *CommonsConfig
+	if got := none.CommonsRef(""); got != UpstreamCommons {
+		t.Errorf("default: CommonsRef() = %q, want %q", got, UpstreamCommons)
+	}
+
+	// The environment override only replaces the built-in default.
+	t.Setenv("GASTOWN_WL_UPSTREAM", "env/wl-commons")
+	if got := none.CommonsRef(""); got != "env/wl-commons" {
+		t.Errorf("env: CommonsRef() = %q, want the env override over the default", got)
+	}
+	if got := cfg.CommonsRef(""); got != "acme/wl-commons" {
+		t.Errorf("config over env: CommonsRef() = %q", got)
+	}
+	if got := cfg.CommonsRef("flag/wl-commons"); got != "flag/wl-commons" {
+		t.Errorf("flag over env: CommonsRef() = %q", got)
+	}
+
+	if got := cfg.FallbackRefs(nil); !reflect.DeepEqual(got, []string{"acme/mirror"}) {
+		t.Errorf("FallbackRefs(nil) = %v, want config fallbacks", got)
+	}
+	if got := cfg.FallbackRefs([]string{"flag/mirror"}); !reflect.DeepEqual(got, []string{"flag/mirror"}) {
+		t.Errorf("FallbackRefs(flags) = %v, want flag fallbacks", got)
+	}
+}
@@ -0,0 +1,147 @@
+package wasteland
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CheckResult is the outcome of one gt wl doctor check.
+type CheckResult struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"` // how to fix a failure
+}
+
+func passed(name, format string, args ...any) CheckResult {
+	return CheckResult{Name: name, OK: true, Message: fmt.Sprintf(format, args...)}
+}
+
+func failed(name, hint, format string, args ...any) CheckResult {
+	return CheckResult{Name: name, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+// CheckDoltInstalled checks that dolt runs and reports its version.
+func CheckDoltInstalled() CheckResult {
+	const name = "dolt installed"
+	output, err := runDolt("", "version")
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return failed(name, "install dolt: https://docs.dolthub.com/introduction/installation", "dolt not found in PATH")
+		}
+		return failed(name, "reinstall dolt: https://docs.dolthub.com/introduction/installation", "dolt version failed: %v (%s)", err, strings.TrimSpace(output))
+	}
+	// The first line is "dolt version X.Y.Z"; later lines may nag about updates.
+	version, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	return passed(name, "%s", strings.TrimSpace(version))
+}
+
+// CheckDoltHubAuth checks that dolt has DoltHub credentials that DoltHub
+// accepts.
+func CheckDoltHubAuth() CheckResult {
+	const name = "DoltHub auth"
+	output, err := runDolt("", "creds", "check")
+	if err != nil {
+		return failed(name, "run dolt login", "DoltHub rejected dolt's credentials: %s", strings.TrimSpace(output))
+	}
+	return passed(name, "dolt credentials accepted")
+}
+
+// CheckLocalFork checks that the town has joined a wasteland and that the
+// local clone of its fork exists with origin and upstream remotes.
+func CheckLocalFork(cfg *Config) CheckResult {
+	const name = "local fork"
+	if cfg == nil || cfg.LocalDir == "" {
+		return failed(name, "run gt wl join <upstream>", "this town has not joined a wasteland")
+	}
+	if _, err := os.Stat(filepath.Join(cfg.LocalDir, ".dolt")); err != nil {
+		return failed(name, "run gt wl join "+cfg.Upstream+" again", "local clone %s is missing", cfg.LocalDir)
+	}
+
+	output, err := runDolt(cfg.LocalDir, "remote", "-v")
+	if err != nil {
+		return failed(name, "check the clone with dolt remote -v", "listing remotes failed: %v (%s)", err, strings.TrimSpace(output))
+	}
+	remotes := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			remotes[fields[0]] = true
+		}
+	}
+	for _, remote := range []string{"origin", "upstream"} {
+		if !remotes[remote] {
+			hint := fmt.Sprintf("cd %s && dolt remote add upstream https://doltremoteapi.dolthub.com/%s", cfg.LocalDir, cfg.Upstream)
+			if remote == "origin" {
+				hint = fmt.Sprintf("cd %s && dolt remote add origin https://doltremoteapi.dolthub.com/%s/%s", cfg.LocalDir, cfg.ForkOrg, cfg.ForkDB)
+			}
+			return failed(name, hint, "clone %s has no %s remote", cfg.LocalDir, remote)
+		}
+	}
+	return passed(name, "%s (origin and upstream configured)", cfg.LocalDir)
+}
+
+// CheckCommonsReachable checks that the fork's origin can be fetched.
+func CheckCommonsReachable(cfg *Config) CheckResult {
+	const name = "commons reachable"
+	if cfg == nil {
+		return failed(name, "run gt wl join <upstream>", "skipped: no local fork")
+	}
+	if err := PreflightCommons(cfg.LocalDir); err != nil {
+		hint := "check your network connection and try again"
+		switch {
+		case errors.Is(err, ErrDoltHubAuth):
+			hint = "run dolt login"
+		case errors.Is(err, ErrCommonsNotFound):
+			hint = fmt.Sprintf("check that %s/%s exists on DoltHub", cfg.ForkOrg, cfg.ForkDB)
+		}
+		return failed(name, hint, "%v", err)
+	}
+	return passed(name, "fetched from origin")
+}
+
+// CheckSchemaVersion checks that the local fork's commons schema is the
+// version this binary understands, or one it can migrate from.
+func CheckSchemaVersion(cfg *Config) CheckResult {
+	const name = "schema version"
+	if cfg == nil {
+		return failed(name, "run gt wl join <upstream>", "skipped: no local fork")
+	}
+	version, err := queryDoltValue(cfg.LocalDir, "SELECT value FROM _meta WHERE `key` = 'schema_version'")
+	if err != nil {
+		return failed(name, "check the clone with dolt sql", "reading schema version: %v", err)
+	}
+	want := CommonsSchemaVersion()
+	switch {
+	case version == "":
+		return failed(name, "run gt wl sync to pull the upstream schema", "commons has no schema_version")
+	case version == want:
+		return passed(name, "%s", version)
+	}
+	if _, err := pendingCommonsMigrations(version); err == nil {
+		// Older schemas with a migration path are upgraded in place when
+		// gt first needs the newer columns, so they are not a failure.
+		return passed(name, "%s (older than %s; gt migrates it when needed)", version, want)
+	}
+	return failed(name, "upgrade gt", "commons schema %s is not one this gt understands (wants %s)", version, want)
+}
+
+// Doctor runs every wl doctor check in order. Checks that need a local
+// fork are skipped when CheckLocalFork fails.
+func Doctor(cfg *Config) []CheckResult {
+	results := []CheckResult{CheckDoltInstalled()}
+	if !results[0].OK {
+		return results
+	}
+	results = append(results, CheckDoltHubAuth())
+
+	fork := CheckLocalFork(cfg)
+	results = append(results, fork)
+	if !fork.OK {
+		return results
+	}
+	return append(results, CheckCommonsReachable(cfg), CheckSchemaVersion(cfg))
+}
@@ -0,0 +1,69 @@
+package wasteland
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestCheckDoltInstalled(t *testing.T) {
+	scriptDolt(t, map[string][]doltStep{"version": {{output: "dolt version 1.43.0\nWarning: out of date\n"}}})
+	if r := CheckDoltInstalled(); !r.OK || r.Message != "dolt version 1.43.0" {
+		t.Errorf("CheckDoltInstalled() = %+v, want OK with the version line", r)
+	}
+
+	scriptDolt(t, map[string][]doltStep{"version": {{err: fmt.Errorf("exec: %w", exec.ErrNotFound)}}})
+	if r := CheckDoltInstalled(); r.OK || !strings.Contains(r.Hint, "install dolt") {
+		t.Errorf("CheckDoltInstalled() = %+v, want a failure with an install hint", r)
+	}
+}
+
+func TestCheckLocalFork(t *testing.T) {
+	dir := makeDoltDir(t)
+	cfg := &Config{Upstream: "hop/wl-commons", ForkOrg: "alice-dev", ForkDB: "wl-commons", LocalDir: dir}
+
+	if r := CheckLocalFork(nil); r.OK || !strings.Contains(r.Hint, "gt wl join") {
+		t.Errorf("CheckLocalFork(nil) = %+v, want a join hint", r)
+	}
+
+	scriptDolt(t, map[string][]doltStep{"remote": {{output: "origin https://doltremoteapi.dolthub.com/alice-dev/wl-commons\n"}}})
+	r := CheckLocalFork(cfg)
+	if r.OK || !strings.Contains(r.Message, "no upstream remote") || !strings.Contains(r.Hint, "dolt remote add upstream") {
+		t.Errorf("CheckLocalFork() without upstream = %+v, want an add-remote hint", r)
+	}
+
+	scriptDolt(t, map[string][]doltStep{"remote": {{output: "origin https://x/alice-dev/wl-commons\nupstream https://x/hop/wl-commons\n"}}})
+	if r := CheckLocalFork(cfg); !r.OK {
+		t.Errorf("CheckLocalFork() = %+v, want OK", r)
+	}
+}
+
+func TestCheckSchemaVersion(t *testing.T) {
+	cfg := &Config{LocalDir: makeDoltDir(t)}
+
+	stubSchemaVersion(t, CommonsSchemaVersion())
+	if r := CheckSchemaVersion(cfg); !r.OK {
+		t.Errorf("CheckSchemaVersion() at the current version = %+v, want OK", r)
+	}
+
+	stubSchemaVersion(t, commonsBaseSchemaVersion)
+	if r := CheckSchemaVersion(cfg); !r.OK {
+		t.Errorf("CheckSchemaVersion() for a migratable schema = %+v, want OK", r)
+	}
+
+	stubSchemaVersion(t, "9.9")
+	if r := CheckSchemaVersion(cfg); r.OK || r.Hint != "upgrade gt" {
+		t.Errorf("CheckSchemaVersion() for a newer schema = %+v, want an upgrade hint", r)
+	}
+}
+
+func TestDoctorStopsWithoutDolt(t *testing.T) {
+	scriptDolt(t, map[string][]doltStep{"version": {{err: errors.New("exit status 127")}}})
+
+	results := Doctor(&Config{})
+	if len(results) != 1 || results[0].OK {
+		t.Errorf("Doctor() = %+v, want only the failed dolt check", results)
+	}
+}
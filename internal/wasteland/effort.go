@@ -0,0 +1,16 @@
+package wasteland
+
+// effortPoints are rough story points for EffortLevels, index for index.
+var effortPoints = []int{1, 3, 5, 8, 13}
+
+// EffortPoints returns a rough story-point estimate for a wanted item
+// effort level (trivial → 1 … epic → 13), for capacity planning. It
+// reports false for an unknown level.
+func EffortPoints(level string) (int, bool) {
+	for i, l := range EffortLevels {
+		if l == level {
+			return effortPoints[i], true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,29 @@
+package wasteland
+
+import "testing"
+
+func TestEffortPoints(t *testing.T) {
+	want := map[string]int{"trivial": 1, "small": 3, "medium": 5, "large": 8, "epic": 13}
+	for level, points := range want {
+		if got, ok := EffortPoints(level); !ok || got != points {
+			t.Errorf("EffortPoints(%q) = %d, %v; want %d, true", level, got, ok, points)
+		}
+	}
+
+	if len(effortPoints) != len(EffortLevels) {
+		t.Errorf("effortPoints has %d entries for %d effort levels", len(effortPoints), len(EffortLevels))
+	}
+	for i := 1; i < len(EffortLevels); i++ {
+		prev, _ := EffortPoints(EffortLevels[i-1])
+		cur, _ := EffortPoints(EffortLevels[i])
+		if cur <= prev {
+			t.Errorf("%s (%d) should outweigh %s (%d)", EffortLevels[i], cur, EffortLevels[i-1], prev)
+		}
+	}
+
+	for _, level := range []string{"", "huge", "Trivial"} {
+		if _, ok := EffortPoints(level); ok {
+			t.Errorf("EffortPoints(%q) should report an unknown level", level)
+		}
+	}
+}
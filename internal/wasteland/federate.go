@@ -0,0 +1,119 @@
+package wasteland
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+const (
+	// FederateLabel marks a local bead for posting to the commons.
+	FederateLabel = "federate"
+	// FederatedLabelPrefix prefixes the label recording the wanted ID a
+	// bead was posted as (e.g. "federated:w-abc123").
+	FederatedLabelPrefix = "federated:"
+)
+
+// ErrAlreadyFederated means a bead already carries a federated: marker.
+var ErrAlreadyFederated = errors.New("bead already federated")
+
+// insertWanted posts a wanted item; replaced in tests.
+var insertWanted = doltserver.InsertWanted
+
+// FederatedID returns the wanted ID recorded on issue by a previous
+// federation, or "" if it has none.
+func FederatedID(issue *beads.Issue) string {
+	for _, label := range issue.Labels {
+		if id, ok := strings.CutPrefix(label, FederatedLabelPrefix); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// WantedFromBead maps a bead to a wanted item posted by handle. Bugs stay
+// bugs and everything else becomes a feature. The effort comes from the
+// bead's effort: label, defaulting to medium, and its other labels become
+// tags, minus gt: bookkeeping and federation markers.
+func WantedFromBead(issue *beads.Issue, handle string) *doltserver.WantedItem {
+	itemType := "feature"
+	if issue.Type == "bug" {
+		itemType = "bug"
+	}
+
+	var tags []string
+	for _, label := range issue.Labels {
+		if label == FederateLabel || strings.HasPrefix(label, FederatedLabelPrefix) ||
+			strings.HasPrefix(label, beads.EffortLabelPrefix) || strings.HasPrefix(label, "gt:") {
+			continue
+		}
+		tags = append(tags, label)
+	}
+
+	effort, ok := beads.EffortOf(issue)
+	if !ok {
+		effort = "medium"
+	}
+
+	return &doltserver.WantedItem{
+		Title:       issue.Title,
+		Description: issue.Description,
+		Type:        itemType,
+		Priority:    issue.Priority,
+		Tags:        tags,
+		PostedBy:    handle,
+		EffortLevel: effort,
+	}
+}
+
+// FederateBead posts issue to the wl-commons database of the town at
+// commonsRef as a wanted item from handle and returns the new wanted ID.
+// A bead that already carries a federated: marker is not posted again;
+// its recorded ID is returned with ErrAlreadyFederated.
+func FederateBead(commonsRef string, issue *beads.Issue, handle string) (string, error) {
+	if id := FederatedID(issue); id != "" {
+		return id, fmt.Errorf("%w: %s is %s", ErrAlreadyFederated, issue.ID, id)
+	}
+
+	item := WantedFromBead(issue, handle)
+	item.ID = doltserver.GenerateWantedID(item.Title)
+	if err := insertWanted(commonsRef, item); err != nil {
+		return "", fmt.Errorf("posting %s: %w", issue.ID, err)
+	}
+	return item.ID, nil
+}
+
+// FederateLabeled posts every open bead labeled FederateLabel that has not
+// been federated yet, recording the wanted ID on the bead as a
+// federated:<id> label. It keeps going past individual failures and
+// returns the wanted IDs posted, keyed by bead ID, along with the joined
+// errors.
+func FederateLabeled(b *beads.Beads, commonsRef, handle string) (map[string]string, error) {
+	issues, err := b.IssuesWithLabel(FederateLabel)
+	if err != nil {
+		return nil, fmt.Errorf("listing beads to federate: %w", err)
+	}
+
+	posted := make(map[string]string)
+	var errs []error
+	for _, issue := range issues {
+		wantedID, err := FederateBead(commonsRef, issue, handle)
+		if errors.Is(err, ErrAlreadyFederated) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		posted[issue.ID] = wantedID
+
+		if err := b.Update(issue.ID, beads.UpdateOptions{AddLabels: []string{FederatedLabelPrefix + wantedID}}); err != nil {
+			// Without the marker the next pass would post it again.
+			errs = append(errs, fmt.Errorf("posted %s as %s but could not mark it federated: %w", issue.ID, wantedID, err))
+		}
+	}
+	return posted, errors.Join(errs...)
+}
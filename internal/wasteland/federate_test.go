@@ -0,0 +1,63 @@
+package wasteland
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+func TestWantedFromBead(t *testing.T) {
+	issue := &beads.Issue{
+		ID:          "gt-1",
+		Title:       "Fix auth",
+		Description: "Tokens expire early",
+		Type:        "bug",
+		Priority:    1,
+		Labels:      []string{"federate", "gt:bug", "auth", "effort:small", "go"},
+	}
+
+	item := WantedFromBead(issue, "alice")
+	if item.Title != "Fix auth" || item.Description != "Tokens expire early" {
+		t.Errorf("title/description = %q/%q, want the bead's", item.Title, item.Description)
+	}
+	if item.Type != "bug" || item.Priority != 1 || item.PostedBy != "alice" || item.EffortLevel != "small" {
+		t.Errorf("item = %+v, want a small bug, priority 1, posted by alice", item)
+	}
+	if strings.Join(item.Tags, ",") != "auth,go" {
+		t.Errorf("Tags = %v, want [auth go]", item.Tags)
+	}
+
+	if got := WantedFromBead(&beads.Issue{Type: "task"}, "alice"); got.Type != "feature" || got.EffortLevel != "medium" {
+		t.Errorf("unlabeled task maps to %s/%s, want feature/medium", got.Type, got.EffortLevel)
+	}
+}
+
+func TestFederateBead(t *testing.T) {
+	var posted []*doltserver.WantedItem
+	orig := insertWanted
+	insertWanted = func(townRoot string, item *doltserver.WantedItem) error {
+		posted = append(posted, item)
+		return nil
+	}
+	t.Cleanup(func() { insertWanted = orig })
+
+	id, err := FederateBead("/town", &beads.Issue{ID: "gt-1", Title: "Fix auth", Labels: []string{"federate"}}, "alice")
+	if err != nil {
+		t.Fatalf("FederateBead() error: %v", err)
+	}
+	if !strings.HasPrefix(id, "w-") || len(posted) != 1 || posted[0].ID != id {
+		t.Fatalf("FederateBead() = %q, posted %v; want the posted item's w- ID", id, posted)
+	}
+
+	again := &beads.Issue{ID: "gt-1", Title: "Fix auth", Labels: []string{"federate", FederatedLabelPrefix + id}}
+	gotID, err := FederateBead("/town", again, "alice")
+	if !errors.Is(err, ErrAlreadyFederated) || gotID != id {
+		t.Errorf("second FederateBead() = %q, %v; want %q, ErrAlreadyFederated", gotID, err, id)
+	}
+	if len(posted) != 1 {
+		t.Errorf("already-federated bead was posted again: %v", posted)
+	}
+}
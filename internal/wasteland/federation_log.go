@@ -0,0 +1,91 @@
+package wasteland
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Federation log actions, one per mutating wl command.
+const (
+	FederationPost     = "post"
+	FederationClaim    = "claim"
+	FederationComplete = "complete"
+)
+
+// FederationEvent is one entry in the town's federation log.
+type FederationEvent struct {
+	Time     time.Time `json:"time"`
+	WantedID string    `json:"wanted_id"`
+	Action   string    `json:"action"`
+	Commit   string    `json:"commit,omitempty"` // wl-commons commit the action produced
+	Rig      string    `json:"rig,omitempty"`
+}
+
+// FederationLogPath returns the path of the town's federation log.
+func FederationLogPath(townRoot string) string {
+	return filepath.Join(townRoot, ".gastown", "federation.log")
+}
+
+// AppendFederationLog appends entry to the town's federation log as one
+// JSON line, creating the log if needed. A zero Time is set from Clock.
+func AppendFederationLog(townRoot string, entry FederationEvent) error {
+	if entry.WantedID == "" || entry.Action == "" {
+		return errors.New("federation event needs a wanted ID and an action")
+	}
+	if entry.Time.IsZero() {
+		entry.Time = Clock().UTC()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding federation event: %w", err)
+	}
+
+	path := FederationLogPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating federation log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening federation log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing federation log: %w", err)
+	}
+	return f.Close()
+}
+
+// ReadFederationLog returns the town's federation log, oldest first. A
+// town that has never logged an event has an empty log.
+func ReadFederationLog(townRoot string) ([]FederationEvent, error) {
+	f, err := os.Open(FederationLogPath(townRoot))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening federation log: %w", err)
+	}
+	defer f.Close()
+
+	var events []FederationEvent
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event FederationEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("federation log line %d: %w", lineNo, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading federation log: %w", err)
+	}
+	return events, nil
+}
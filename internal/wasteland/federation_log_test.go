@@ -0,0 +1,85 @@
+package wasteland
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFederationLogRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+	at := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	pinClock(t, at)
+
+	events, err := ReadFederationLog(townRoot)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("ReadFederationLog() before any event = %v, %v; want empty", events, err)
+	}
+
+	want := []FederationEvent{
+		{WantedID: "w-1", Action: FederationPost, Commit: "abc123", Rig: "alice"},
+		{WantedID: "w-2", Action: FederationClaim, Commit: "def456", Rig: "alice", Time: at.Add(time.Hour)},
+		{WantedID: "w-2", Action: FederationComplete, Rig: "alice"},
+	}
+	for _, event := range want {
+		if err := AppendFederationLog(townRoot, event); err != nil {
+			t.Fatalf("AppendFederationLog(%+v) error: %v", event, err)
+		}
+	}
+
+	got, err := ReadFederationLog(townRoot)
+	if err != nil {
+		t.Fatalf("ReadFederationLog() error: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("read %d events, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if want[i].Time.IsZero() {
+			want[i].Time = at
+		}
+		if !got[i].Time.Equal(want[i].Time) || got[i].WantedID != want[i].WantedID ||
+			got[i].Action != want[i].Action || got[i].Commit != want[i].Commit || got[i].Rig != want[i].Rig {
+			t.Errorf("event %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	data, err := os.ReadFile(FederationLogPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("log has %d lines, want one per event:\n%s", lines, data)
+	}
+}
+
+func TestAppendFederationLogRequiresIDAndAction(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := AppendFederationLog(townRoot, FederationEvent{Action: FederationPost}); err == nil {
+		t.Error("event without a wanted ID should be rejected")
+	}
+	if err := AppendFederationLog(townRoot, FederationEvent{WantedID: "w-1"}); err == nil {
+		t.Error("event without an action should be rejected")
+	}
+	if _, err := os.Stat(FederationLogPath(townRoot)); !os.IsNotExist(err) {
+		t.Errorf("rejected events should not create the log: %v", err)
+	}
+}
+
+func TestReadFederationLogRejectsCorruptLine(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := AppendFederationLog(townRoot, FederationEvent{WantedID: "w-1", Action: FederationPost}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(FederationLogPath(townRoot), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{not json\n")
+	f.Close()
+
+	if _, err := ReadFederationLog(townRoot); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ReadFederationLog() error = %v, want line 2", err)
+	}
+}
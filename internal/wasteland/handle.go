@@ -0,0 +1,43 @@
+package wasteland
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// ResolveTownHandle returns the handle a town posts and claims under,
+// taking the first that is set:
+//
+//  1. the DOLTHUB_ORG environment variable
+//  2. the name in the town's mayor/town.json
+//  3. the base name of townRoot
+//
+// A missing town.json falls through to the base name; one that exists but
+// can't be loaded is an error.
+func ResolveTownHandle(townRoot string) (string, error) {
+	if org := strings.TrimSpace(doltserver.DoltHubOrg()); org != "" {
+		return org, nil
+	}
+
+	townCfg, err := config.LoadTownConfig(filepath.Join(townRoot, workspace.PrimaryMarker))
+	switch {
+	case err == nil:
+		if name := strings.TrimSpace(townCfg.Name); name != "" {
+			return name, nil
+		}
+	case !errors.Is(err, config.ErrNotFound):
+		return "", fmt.Errorf("resolving town handle: %w", err)
+	}
+
+	base := filepath.Base(filepath.Clean(townRoot))
+	if base == "." || base == string(filepath.Separator) {
+		return "", fmt.Errorf("resolving town handle: no DOLTHUB_ORG, town name, or directory name for %q", townRoot)
+	}
+	return base, nil
+}
@@ -0,0 +1,62 @@
+package wasteland
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTownJSON writes mayor/town.json under a new town directory named
+// dirName and returns the town root.
+func writeTownJSON(t *testing.T, dirName, contents string) string {
+	t.Helper()
+	townRoot := filepath.Join(t.TempDir(), dirName)
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if contents != "" {
+		if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return townRoot
+}
+
+func TestResolveTownHandle(t *testing.T) {
+	tests := []struct {
+		name     string
+		org      string
+		townJSON string
+		want     string
+	}{
+		{"DOLTHUB_ORG wins", "alice-dev", `{"type":"town","name":"mytown"}`, "alice-dev"},
+		{"town name", "", `{"type":"town","name":"mytown"}`, "mytown"},
+		{"tricky town name", "", `{"type":"town","name":"  Bob's \"Town\", v2 é  ","public_name":"name"}`, `Bob's "Town", v2 é`},
+		{"no town.json", "", "", "gt-home"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DOLTHUB_ORG", tt.org)
+			townRoot := writeTownJSON(t, "gt-home", tt.townJSON)
+
+			got, err := ResolveTownHandle(townRoot)
+			if err != nil {
+				t.Fatalf("ResolveTownHandle() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTownHandle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTownHandle_BrokenTownJSON(t *testing.T) {
+	t.Setenv("DOLTHUB_ORG", "")
+	townRoot := writeTownJSON(t, "gt-home", `{"name":`)
+
+	_, err := ResolveTownHandle(townRoot)
+	if err == nil || !strings.Contains(err.Error(), "town handle") {
+		t.Errorf("ResolveTownHandle() error = %v, want a town handle error", err)
+	}
+}
@@ -0,0 +1,119 @@
+package wasteland
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// commonsBaseSchemaVersion is the schema version of the first commons,
+// which commonsMigrations upgrade from.
+const commonsBaseSchemaVersion = "1.0"
+
+// commonsMigration upgrades the commons schema from one version to the
+// next. SQL must be idempotent (CREATE TABLE IF NOT EXISTS, INSERT IGNORE,
+// ...) so a migration interrupted before its version bump can be rerun.
+type commonsMigration struct {
+	From string
+	To   string
+	SQL  string
+}
+
+// commonsMigrations are applied in order by MigrateCommons; each From is the
+// previous To. Var so tests can supply fixtures.
+var commonsMigrations = []commonsMigration{
+	{From: commonsBaseSchemaVersion, To: doltserver.WLCommonsSchemaVersion, SQL: doltserver.WantedDedupKeyMigration},
+}
+
+// CommonsSchemaVersion returns the commons schema version this binary
+// migrates to.
+func CommonsSchemaVersion() string {
+	if len(commonsMigrations) == 0 {
+		return commonsBaseSchemaVersion
+	}
+	return commonsMigrations[len(commonsMigrations)-1].To
+}
+
+// MigrateCommons upgrades the commons database in dbDir to
+// CommonsSchemaVersion. The pending migrations and the _meta.schema_version
+// bump run in a single transaction, followed by one dolt commit, so the
+// database is never left between versions. It returns the versions before
+// and after; they are equal when nothing needed migrating.
+func MigrateCommons(dbDir string) (from, to string, err error) {
+	from, err = queryDoltValue(dbDir, "SELECT value FROM _meta WHERE `key` = 'schema_version'")
+	if err != nil {
+		return "", "", fmt.Errorf("reading commons schema version: %w", err)
+	}
+	return migrateCommonsFrom(from, func(script string) error {
+		if output, err := runDolt(dbDir, "sql", "-q", script); err != nil {
+			return fmt.Errorf("%w (%s)", err, strings.TrimSpace(output))
+		}
+		return nil
+	})
+}
+
+// localCommonsStatus and execLocalCommonsScript reach the town's local
+// wl-commons database. Vars so tests can stub them.
+var (
+	localCommonsStatus     = doltserver.WLCommonsStatus
+	execLocalCommonsScript = doltserver.ExecWLCommonsScript
+)
+
+// MigrateLocalCommons is MigrateCommons for the town's local wl-commons
+// database, which lives on the town's Dolt server rather than in a clone.
+// gt wl post runs it when InsertWanted reports
+// doltserver.ErrWLCommonsSchemaOutdated.
+func MigrateLocalCommons(townRoot string) (from, to string, err error) {
+	state, err := localCommonsStatus(townRoot)
+	if err != nil {
+		return "", "", fmt.Errorf("reading local wl-commons schema version: %w", err)
+	}
+	return migrateCommonsFrom(state.SchemaVersion, func(script string) error {
+		return execLocalCommonsScript(townRoot, script)
+	})
+}
+
+// migrateCommonsFrom builds the migration script from version from to
+// CommonsSchemaVersion and hands it to run.
+func migrateCommonsFrom(from string, run func(script string) error) (string, string, error) {
+	if from == "" {
+		return "", "", fmt.Errorf("commons has no schema_version in _meta")
+	}
+
+	target := CommonsSchemaVersion()
+	if from == target {
+		return from, from, nil
+	}
+
+	pending, err := pendingCommonsMigrations(from)
+	if err != nil {
+		return from, from, err
+	}
+
+	var script strings.Builder
+	script.WriteString("START TRANSACTION;\n")
+	for _, m := range pending {
+		fmt.Fprintf(&script, "-- %s -> %s\n%s\n", m.From, m.To, strings.TrimSpace(m.SQL))
+	}
+	fmt.Fprintf(&script, "UPDATE _meta SET value = '%s' WHERE `key` = 'schema_version';\n", target)
+	script.WriteString("COMMIT;\n")
+	script.WriteString("CALL DOLT_ADD('-A');\n")
+	fmt.Fprintf(&script, "CALL DOLT_COMMIT('-m', 'wl migrate: schema %s -> %s');\n", from, target)
+
+	if err := run(script.String()); err != nil {
+		return from, from, fmt.Errorf("migrating commons schema %s -> %s: %w", from, target, err)
+	}
+	return from, target, nil
+}
+
+// pendingCommonsMigrations returns the migrations that take version from to
+// CommonsSchemaVersion, in order.
+func pendingCommonsMigrations(from string) ([]commonsMigration, error) {
+	for i, m := range commonsMigrations {
+		if m.From == from {
+			return commonsMigrations[i:], nil
+		}
+	}
+	return nil, fmt.Errorf("no migration path from commons schema %s to %s (is this binary older than the commons?)", from, CommonsSchemaVersion())
+}
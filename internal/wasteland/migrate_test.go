@@ -0,0 +1,197 @@
+package wasteland
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// stubSchemaVersion answers schema_version queries with version.
+func stubSchemaVersion(t *testing.T, version string) {
+	t.Helper()
+	orig := runDoltQuery
+	runDoltQuery = func(dbDir, query string) (string, error) {
+		if !strings.Contains(query, "schema_version") {
+			return "", errors.New("unexpected query: " + query)
+		}
+		return "value\n" + version + "\n", nil
+	}
+	t.Cleanup(func() { runDoltQuery = orig })
+}
+
+func useCommonsMigrations(t *testing.T, migrations ...commonsMigration) {
+	t.Helper()
+	orig := commonsMigrations
+	commonsMigrations = migrations
+	t.Cleanup(func() { commonsMigrations = orig })
+}
+
+var badgesMigration = commonsMigration{
+	From: "1.0",
+	To:   "1.1",
+	SQL:  "CREATE TABLE IF NOT EXISTS badges (id VARCHAR(64) PRIMARY KEY, rig VARCHAR(255));",
+}
+
+func TestMigrateCommons_AppliesMigration(t *testing.T) {
+	useCommonsMigrations(t, badgesMigration)
+	stubSchemaVersion(t, "1.0")
+	calls := scriptDolt(t, nil)
+
+	from, to, err := MigrateCommons(t.TempDir())
+	if err != nil {
+		t.Fatalf("MigrateCommons() error: %v", err)
+	}
+	if from != "1.0" || to != "1.1" {
+		t.Errorf("MigrateCommons() = %q, %q; want 1.0, 1.1", from, to)
+	}
+	if len(*calls) != 1 || !strings.HasPrefix((*calls)[0], "sql -q ") {
+		t.Fatalf("dolt calls = %v, want one sql script", *calls)
+	}
+
+	script := (*calls)[0]
+	ordered := []string{
+		"START TRANSACTION;",
+		"CREATE TABLE IF NOT EXISTS badges",
+		"UPDATE _meta SET value = '1.1' WHERE `key` = 'schema_version';",
+		"COMMIT;",
+		"CALL DOLT_COMMIT('-m', 'wl migrate: schema 1.0 -> 1.1');",
+	}
+	pos := 0
+	for _, want := range ordered {
+		i := strings.Index(script[pos:], want)
+		if i < 0 {
+			t.Fatalf("script missing %q after offset %d:\n%s", want, pos, script)
+		}
+		pos += i + len(want)
+	}
+}
+
+func TestMigrateCommons_AppliesChainFromMiddle(t *testing.T) {
+	useCommonsMigrations(t, badgesMigration,
+		commonsMigration{From: "1.1", To: "1.2", SQL: "INSERT IGNORE INTO _meta (`key`, value) VALUES ('badges_enabled', 'true');"})
+	stubSchemaVersion(t, "1.1")
+	calls := scriptDolt(t, nil)
+
+	from, to, err := MigrateCommons(t.TempDir())
+	if err != nil {
+		t.Fatalf("MigrateCommons() error: %v", err)
+	}
+	if from != "1.1" || to != "1.2" {
+		t.Errorf("MigrateCommons() = %q, %q; want 1.1, 1.2", from, to)
+	}
+	if script := (*calls)[0]; strings.Contains(script, "badges (") || !strings.Contains(script, "badges_enabled") {
+		t.Errorf("script should apply only 1.1 -> 1.2:\n%s", script)
+	}
+}
+
+func TestMigrateCommons_UpToDate(t *testing.T) {
+	useCommonsMigrations(t, badgesMigration)
+	stubSchemaVersion(t, "1.1")
+	calls := scriptDolt(t, nil)
+
+	from, to, err := MigrateCommons(t.TempDir())
+	if err != nil {
+		t.Fatalf("MigrateCommons() error: %v", err)
+	}
+	if from != "1.1" || to != "1.1" {
+		t.Errorf("MigrateCommons() = %q, %q; want 1.1, 1.1", from, to)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("dolt calls = %v, want none", *calls)
+	}
+}
+
+func TestMigrateCommons_UnknownVersion(t *testing.T) {
+	useCommonsMigrations(t, badgesMigration)
+	stubSchemaVersion(t, "2.0")
+	calls := scriptDolt(t, nil)
+
+	if _, _, err := MigrateCommons(t.TempDir()); err == nil {
+		t.Error("MigrateCommons() should fail for a version with no migration path")
+	}
+	if len(*calls) != 0 {
+		t.Errorf("dolt calls = %v, want none", *calls)
+	}
+}
+
+func TestMigrateCommons_FailureKeepsVersion(t *testing.T) {
+	useCommonsMigrations(t, badgesMigration)
+	stubSchemaVersion(t, "1.0")
+	scriptDolt(t, map[string][]doltStep{"sql": {{"syntax error", errors.New("exit status 1")}}})
+
+	from, to, err := MigrateCommons(t.TempDir())
+	if err == nil {
+		t.Fatal("MigrateCommons() should report the failed script")
+	}
+	if from != "1.0" || to != "1.0" {
+		t.Errorf("MigrateCommons() = %q, %q; want 1.0, 1.0 after failure", from, to)
+	}
+}
+
+func TestCommonsSchemaVersionMatchesNewCommons(t *testing.T) {
+	// A freshly created commons must not need migrating.
+	if got := CommonsSchemaVersion(); got != doltserver.WLCommonsSchemaVersion {
+		t.Errorf("CommonsSchemaVersion() = %q, want the version EnsureWLCommons creates (%q)", got, doltserver.WLCommonsSchemaVersion)
+	}
+}
+
+// stubLocalCommons answers local schema_version reads with version and
+// records the scripts run against the local commons.
+func stubLocalCommons(t *testing.T, version string, runErr error) *[]string {
+	t.Helper()
+	origStatus, origExec := localCommonsStatus, execLocalCommonsScript
+	var scripts []string
+	localCommonsStatus = func(string) (doltserver.WLCommonsState, error) {
+		return doltserver.WLCommonsState{DBExists: true, SchemaInitialized: true, SchemaVersion: version}, nil
+	}
+	execLocalCommonsScript = func(_, script string) error {
+		scripts = append(scripts, script)
+		return runErr
+	}
+	t.Cleanup(func() { localCommonsStatus, execLocalCommonsScript = origStatus, origExec })
+	return &scripts
+}
+
+func TestMigrateLocalCommons(t *testing.T) {
+	useCommonsMigrations(t, badgesMigration)
+	scripts := stubLocalCommons(t, "1.0", nil)
+
+	from, to, err := MigrateLocalCommons(t.TempDir())
+	if err != nil {
+		t.Fatalf("MigrateLocalCommons() error: %v", err)
+	}
+	if from != "1.0" || to != "1.1" {
+		t.Errorf("MigrateLocalCommons() = %q, %q; want 1.0, 1.1", from, to)
+	}
+	if len(*scripts) != 1 || !strings.Contains((*scripts)[0], "CREATE TABLE IF NOT EXISTS badges") ||
+		!strings.Contains((*scripts)[0], "CALL DOLT_COMMIT('-m', 'wl migrate: schema 1.0 -> 1.1');") {
+		t.Errorf("scripts = %v, want the shared migration script", *scripts)
+	}
+}
+
+func TestMigrateLocalCommons_UpToDate(t *testing.T) {
+	useCommonsMigrations(t, badgesMigration)
+	scripts := stubLocalCommons(t, "1.1", nil)
+
+	if _, _, err := MigrateLocalCommons(t.TempDir()); err != nil {
+		t.Fatalf("MigrateLocalCommons() error: %v", err)
+	}
+	if len(*scripts) != 0 {
+		t.Errorf("scripts = %v, want none", *scripts)
+	}
+}
+
+func TestMigrateLocalCommons_Failure(t *testing.T) {
+	useCommonsMigrations(t, badgesMigration)
+	stubLocalCommons(t, "1.0", errors.New("exit status 1"))
+
+	from, to, err := MigrateLocalCommons(t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "1.0 -> 1.1") {
+		t.Fatalf("MigrateLocalCommons() error = %v, want the failed migration named", err)
+	}
+	if from != "1.0" || to != "1.0" {
+		t.Errorf("MigrateLocalCommons() = %q, %q; want 1.0, 1.0 after failure", from, to)
+	}
+}
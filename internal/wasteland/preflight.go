@@ -0,0 +1,69 @@
+package wasteland
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+var (
+	// ErrDoltHubAuth means DoltHub rejected the request for lack of
+	// credentials or permission. It is doltserver.ErrDoltAuth, so push and
+	// fetch failures can be tested for with either.
+	ErrDoltHubAuth = doltserver.ErrDoltAuth
+	// ErrCommonsNotFound means the commons repository does not exist on
+	// DoltHub, or is not visible to the current credentials.
+	ErrCommonsNotFound = errors.New("commons repository not found on DoltHub")
+)
+
+// runDolt runs dolt with args in dir and returns its combined output.
+// Var so tests can stub it.
+var runDolt = func(dir string, args ...string) (string, error) {
+	cmd := exec.Command("dolt", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	return string(output), err
+}
+
+// PreflightCommons cheaply checks that the local commons clone in localDir
+// can reach its origin on DoltHub, by fetching from it, before any slow or
+// user-visible work. A fetch proves read access only; push permission is
+// first tested by the push itself. Failures wrap ErrDoltHubAuth or
+// ErrCommonsNotFound when dolt's output identifies the cause.
+func PreflightCommons(localDir string) error {
+	if localDir == "" {
+		return fmt.Errorf("no local commons clone configured; run gt wl join first")
+	}
+	if _, err := os.Stat(filepath.Join(localDir, ".dolt")); err != nil {
+		return fmt.Errorf("local commons clone %s is missing; run gt wl join again", localDir)
+	}
+
+	output, err := runDolt(localDir, "fetch", "origin")
+	if err != nil {
+		return classifyRemoteError(output, err)
+	}
+	return nil
+}
+
+// classifyRemoteError maps a failed dolt remote operation to ErrDoltHubAuth
+// or ErrCommonsNotFound based on its output, falling back to a generic
+// connectivity error.
+func classifyRemoteError(output string, err error) error {
+	detail := strings.TrimSpace(output)
+	lower := strings.ToLower(detail)
+
+	if doltserver.IsAuthFailure(detail) {
+		return fmt.Errorf("%w: %s", ErrDoltHubAuth, detail)
+	}
+	for _, pattern := range []string{"not found", "does not exist", "404"} {
+		if strings.Contains(lower, pattern) {
+			return fmt.Errorf("%w: %s", ErrCommonsNotFound, detail)
+		}
+	}
+	return fmt.Errorf("cannot reach commons: %w (%s)", err, detail)
+}
@@ -0,0 +1,61 @@
+package wasteland
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPreflightCommons(t *testing.T) {
+	failed := errors.New("exit status 1")
+
+	tests := []struct {
+		name    string
+		output  string
+		err     error
+		wantErr error
+	}{
+		{"ok", "", nil, nil},
+		{"no credentials", "error: could not find credentials for dolthub.com; run dolt login", failed, ErrDoltHubAuth},
+		{"permission denied", "rpc error: code = PermissionDenied desc = permission denied", failed, ErrDoltHubAuth},
+		{"unauthenticated", "rpc error: code = Unauthenticated desc = invalid token", failed, ErrDoltHubAuth},
+		{"repo not found", "rpc error: code = NotFound desc = repository not found", failed, ErrCommonsNotFound},
+		{"does not exist", "remote 'origin' database alice/wl-commons does not exist", failed, ErrCommonsNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := scriptDolt(t, map[string][]doltStep{"fetch": {{tt.output, tt.err}}})
+
+			err := PreflightCommons(makeDoltDir(t))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("PreflightCommons() error: %v", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PreflightCommons() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(*calls) != 1 || (*calls)[0] != "fetch origin" {
+				t.Errorf("dolt calls = %v, want one fetch origin", *calls)
+			}
+		})
+	}
+}
+
+func TestPreflightCommons_NetworkError(t *testing.T) {
+	scriptDolt(t, map[string][]doltStep{"fetch": {{"dial tcp: lookup doltremoteapi.dolthub.com: no such host", errors.New("exit status 1")}}})
+
+	err := PreflightCommons(makeDoltDir(t))
+	if err == nil || errors.Is(err, ErrDoltHubAuth) || errors.Is(err, ErrCommonsNotFound) {
+		t.Errorf("PreflightCommons() error = %v, want an unclassified connectivity error", err)
+	}
+}
+
+func TestPreflightCommons_MissingClone(t *testing.T) {
+	calls := scriptDolt(t, nil)
+
+	if err := PreflightCommons(t.TempDir()); err == nil {
+		t.Error("PreflightCommons() should fail without a dolt clone")
+	}
+	if len(*calls) != 0 {
+		t.Errorf("missing clone should not run dolt, got %v", *calls)
+	}
+}
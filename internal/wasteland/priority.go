@@ -0,0 +1,38 @@
+package wasteland
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PriorityLabels are the human labels for wanted-item priorities, indexed by
+// priority. Beads uses the same 0-4 scale, shown as P0-P4.
+var PriorityLabels = []string{"critical", "high", "medium", "low", "backlog"}
+
+// LabelForPriority returns the human label for a 0-4 priority
+// (e.g., 1 → "high"). Out-of-range priorities are returned as a number.
+func LabelForPriority(priority int) string {
+	if priority < 0 || priority >= len(PriorityLabels) {
+		return strconv.Itoa(priority)
+	}
+	return PriorityLabels[priority]
+}
+
+// PriorityForLabel parses a priority given as a label ("high"), a number
+// ("1") or a beads-style name ("P1"). Matching is case-insensitive.
+func PriorityForLabel(label string) (int, error) {
+	s := strings.ToLower(strings.TrimSpace(label))
+	for i, l := range PriorityLabels {
+		if s == l {
+			return i, nil
+		}
+	}
+
+	num := strings.TrimPrefix(s, "p")
+	if n, err := strconv.Atoi(num); err == nil && n >= 0 && n < len(PriorityLabels) {
+		return n, nil
+	}
+
+	return 0, fmt.Errorf("invalid priority %q: must be 0-4 or one of %s", label, strings.Join(PriorityLabels, ", "))
+}
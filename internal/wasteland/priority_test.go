@@ -0,0 +1,68 @@
+package wasteland
+
+import "testing"
+
+func TestPriorityLabelRoundTrip(t *testing.T) {
+	for p := range PriorityLabels {
+		label := LabelForPriority(p)
+		got, err := PriorityForLabel(label)
+		if err != nil {
+			t.Fatalf("PriorityForLabel(%q) error: %v", label, err)
+		}
+		if got != p {
+			t.Errorf("PriorityForLabel(LabelForPriority(%d)) = %d", p, got)
+		}
+	}
+}
+
+func TestLabelForPriority(t *testing.T) {
+	tests := []struct {
+		priority int
+		want     string
+	}{
+		{0, "critical"},
+		{1, "high"},
+		{2, "medium"},
+		{3, "low"},
+		{4, "backlog"},
+		{7, "7"},
+		{-1, "-1"},
+	}
+	for _, tt := range tests {
+		if got := LabelForPriority(tt.priority); got != tt.want {
+			t.Errorf("LabelForPriority(%d) = %q, want %q", tt.priority, got, tt.want)
+		}
+	}
+}
+
+func TestPriorityForLabel(t *testing.T) {
+	tests := []struct {
+		label string
+		want  int
+	}{
+		{"critical", 0},
+		{"High", 1},
+		{" medium ", 2},
+		{"3", 3},
+		{"P4", 4},
+		{"p0", 0},
+	}
+	for _, tt := range tests {
+		got, err := PriorityForLabel(tt.label)
+		if err != nil {
+			t.Errorf("PriorityForLabel(%q) error: %v", tt.label, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("PriorityForLabel(%q) = %d, want %d", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestPriorityForLabel_Invalid(t *testing.T) {
+	for _, label := range []string{"", "urgent", "5", "-1", "P9", "pp1"} {
+		if _, err := PriorityForLabel(label); err == nil {
+			t.Errorf("PriorityForLabel(%q) should error", label)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package wasteland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/retry"
+)
+
+// pushAttempts is how many times PushLocalCommits pushes before giving up
+// on a fork whose origin keeps moving underneath it.
+const pushAttempts = 3
+
+// LocalCommitsAhead fetches origin and returns how many commits the local
+// main branch of the commons clone in localDir has that origin/main does not.
+func LocalCommitsAhead(localDir string) (int, error) {
+	if output, err := runDolt(localDir, "fetch", "origin"); err != nil {
+		return 0, fmt.Errorf("fetching origin: %w", classifyRemoteError(output, err))
+	}
+	value, err := queryDoltValue(localDir, "SELECT COUNT(*) AS ahead FROM dolt_log('origin/main..main')")
+	if err != nil {
+		return 0, fmt.Errorf("counting local commits: %w", err)
+	}
+	ahead, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("counting local commits: unexpected count %q", value)
+	}
+	return ahead, nil
+}
+
+// PushLocalCommits pushes local commits in the commons clone in localDir to
+// origin and returns how many were pushed. The count is everything origin
+// lacks, so it includes upstream commits merged by an earlier pull; call
+// LocalCommitsAhead before pulling to count only local work. Nothing is
+// pushed when the clone is not ahead of origin. If origin rejects the push as non-fast-forward,
+// origin is pulled and the push retried, up to pushAttempts times. Push
+// failures wrap doltserver's ErrDoltAuth, ErrDoltNonFastForward or
+// ErrDoltPush.
+func PushLocalCommits(localDir string) (int, error) {
+	// No backoff: the pull before each retry is what lets the push succeed.
+	policy := retry.Policy{
+		MaxAttempts: pushAttempts,
+		Retryable: func(err error) bool {
+			return errors.Is(err, doltserver.ErrDoltNonFastForward)
+		},
+	}
+
+	var pushed int
+	attempt := 0
+	err := retry.Do(context.Background(), policy, func() error {
+		attempt++
+		if attempt > 1 {
+			if output, err := runDolt(localDir, "pull", "origin", "main"); err != nil {
+				return fmt.Errorf("pulling origin after rejected push: %w (%s)", err, strings.TrimSpace(output))
+			}
+		}
+
+		ahead, err := LocalCommitsAhead(localDir)
+		if err != nil {
+			return err
+		}
+		if ahead == 0 {
+			return nil
+		}
+
+		output, err := runDolt(localDir, "push", "origin", "main")
+		if err != nil {
+			return fmt.Errorf("pushing to origin: %w", doltserver.ClassifyPushError(output, err))
+		}
+		pushed = ahead
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return pushed, nil
+}
@@ -0,0 +1,123 @@
+package wasteland
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// stubAheadCounts answers successive commit-count queries with counts.
+func stubAheadCounts(t *testing.T, counts ...string) {
+	t.Helper()
+	orig := runDoltQuery
+	runDoltQuery = func(dbDir, query string) (string, error) {
+		if !strings.Contains(query, "origin/main..main") || len(counts) == 0 {
+			return "", errors.New("unexpected query: " + query)
+		}
+		n := counts[0]
+		counts = counts[1:]
+		return "ahead\n" + n + "\n", nil
+	}
+	t.Cleanup(func() { runDoltQuery = orig })
+}
+
+const rejectedPush = "error: failed to push some refs to 'https://doltremoteapi.dolthub.com/alice/wl-commons'\n" +
+	"hint: Updates were rejected because the tip of your current branch is behind"
+
+func TestPushLocalCommits_NothingAhead(t *testing.T) {
+	calls := scriptDolt(t, nil)
+	stubAheadCounts(t, "0")
+
+	pushed, err := PushLocalCommits(t.TempDir())
+	if err != nil {
+		t.Fatalf("PushLocalCommits() error: %v", err)
+	}
+	if pushed != 0 {
+		t.Errorf("pushed = %d, want 0", pushed)
+	}
+	if want := []string{"fetch origin"}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("dolt calls = %v, want %v", *calls, want)
+	}
+}
+
+func TestPushLocalCommits_Ahead(t *testing.T) {
+	calls := scriptDolt(t, nil)
+	stubAheadCounts(t, "2")
+
+	pushed, err := PushLocalCommits(t.TempDir())
+	if err != nil {
+		t.Fatalf("PushLocalCommits() error: %v", err)
+	}
+	if pushed != 2 {
+		t.Errorf("pushed = %d, want 2", pushed)
+	}
+	if want := []string{"fetch origin", "push origin main"}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("dolt calls = %v, want %v", *calls, want)
+	}
+}
+
+func TestPushLocalCommits_RetriesNonFastForward(t *testing.T) {
+	failed := errors.New("exit status 1")
+	calls := scriptDolt(t, map[string][]doltStep{
+		"push": {{rejectedPush, failed}, {"", nil}},
+	})
+	// The re-pull merges origin, so the second count includes a merge commit.
+	stubAheadCounts(t, "1", "2")
+
+	pushed, err := PushLocalCommits(t.TempDir())
+	if err != nil {
+		t.Fatalf("PushLocalCommits() error: %v", err)
+	}
+	if pushed != 2 {
+		t.Errorf("pushed = %d, want 2", pushed)
+	}
+	want := []string{"fetch origin", "push origin main", "pull origin main", "fetch origin", "push origin main"}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("dolt calls = %v, want %v", *calls, want)
+	}
+}
+
+func TestPushLocalCommits_GivesUpAfterRepeatedRejection(t *testing.T) {
+	failed := errors.New("exit status 1")
+	var rejections []doltStep
+	for range pushAttempts {
+		rejections = append(rejections, doltStep{rejectedPush, failed})
+	}
+	calls := scriptDolt(t, map[string][]doltStep{"push": rejections})
+	stubAheadCounts(t, "1", "1", "1")
+
+	_, err := PushLocalCommits(t.TempDir())
+	if !errors.Is(err, doltserver.ErrDoltNonFastForward) {
+		t.Errorf("PushLocalCommits() error = %v, want ErrDoltNonFastForward", err)
+	}
+	var pushes, pulls int
+	for _, c := range *calls {
+		switch c {
+		case "push origin main":
+			pushes++
+		case "pull origin main":
+			pulls++
+		}
+	}
+	if pushes != pushAttempts || pulls != pushAttempts-1 {
+		t.Errorf("pushes = %d, pulls = %d, want %d and %d", pushes, pulls, pushAttempts, pushAttempts-1)
+	}
+}
+
+func TestPushLocalCommits_OtherFailureNotRetried(t *testing.T) {
+	calls := scriptDolt(t, map[string][]doltStep{
+		"push": {{"rpc error: code = PermissionDenied desc = permission denied", errors.New("exit status 1")}},
+	})
+	stubAheadCounts(t, "1")
+
+	_, err := PushLocalCommits(t.TempDir())
+	if !errors.Is(err, doltserver.ErrDoltAuth) {
+		t.Errorf("PushLocalCommits() error = %v, want ErrDoltAuth", err)
+	}
+	if want := []string{"fetch origin", "push origin main"}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("dolt calls = %v, want %v", *calls, want)
+	}
+}
@@ -0,0 +1,76 @@
+package wasteland
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sandboxTiers lists the sandbox tiers from least to most privileged.
+var sandboxTiers = []string{"none", "restricted", "trusted", "privileged"}
+
+// ValidSandboxTiers returns the valid values for a wanted item's
+// sandbox_min_tier, ordered from least to most privileged, so a tier's
+// index can be compared with >=.
+func ValidSandboxTiers() []string {
+	tiers := make([]string, len(sandboxTiers))
+	copy(tiers, sandboxTiers)
+	return tiers
+}
+
+// IsValidTier reports whether tier is a known sandbox tier.
+func IsValidTier(tier string) bool {
+	for _, t := range sandboxTiers {
+		if t == tier {
+			return true
+		}
+	}
+	return false
+}
+
+// SandboxTier is a sandbox privilege level. Tiers are ordered, so a worker
+// can take a wanted item when its tier is at least the item's minimum.
+type SandboxTier int
+
+// Sandbox tiers, from least to most privileged.
+const (
+	SandboxTierNone SandboxTier = iota
+	SandboxTierRestricted
+	SandboxTierTrusted
+	SandboxTierPrivileged
+)
+
+// ParseSandboxTier parses a tier name as listed by ValidSandboxTiers.
+func ParseSandboxTier(s string) (SandboxTier, error) {
+	for i, t := range sandboxTiers {
+		if t == s {
+			return SandboxTier(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid sandbox tier %q: must be one of %s", s, strings.Join(sandboxTiers, ", "))
+}
+
+// String returns the tier's name.
+func (t SandboxTier) String() string {
+	if t < 0 || int(t) >= len(sandboxTiers) {
+		return fmt.Sprintf("SandboxTier(%d)", int(t))
+	}
+	return sandboxTiers[t]
+}
+
+// AtLeast reports whether t is as privileged as other or more.
+func (t SandboxTier) AtLeast(other SandboxTier) bool {
+	return t >= other
+}
+
+// TiersUpTo returns the names of all tiers that t satisfies, i.e. every
+// tier from none up to and including t.
+func (t SandboxTier) TiersUpTo() []string {
+	n := int(t) + 1
+	if n < 0 {
+		n = 0
+	}
+	if n > len(sandboxTiers) {
+		n = len(sandboxTiers)
+	}
+	return ValidSandboxTiers()[:n]
+}
@@ -0,0 +1,91 @@
+package wasteland
+
+import "testing"
+
+func TestValidSandboxTiers_Order(t *testing.T) {
+	want := []string{"none", "restricted", "trusted", "privileged"}
+	got := ValidSandboxTiers()
+	if len(got) != len(want) {
+		t.Fatalf("ValidSandboxTiers() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ValidSandboxTiers()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// Callers must not be able to reorder the shared list.
+	got[0] = "mutated"
+	if ValidSandboxTiers()[0] != "none" {
+		t.Error("ValidSandboxTiers() returned the internal slice")
+	}
+}
+
+func TestIsValidTier(t *testing.T) {
+	for _, tier := range ValidSandboxTiers() {
+		if !IsValidTier(tier) {
+			t.Errorf("IsValidTier(%q) = false, want true", tier)
+		}
+	}
+	for _, tier := range []string{"", "None", "admin", "trusted "} {
+		if IsValidTier(tier) {
+			t.Errorf("IsValidTier(%q) = true, want false", tier)
+		}
+	}
+}
+
+func TestParseSandboxTier(t *testing.T) {
+	tests := []struct {
+		in   string
+		want SandboxTier
+	}{
+		{"none", SandboxTierNone},
+		{"restricted", SandboxTierRestricted},
+		{"trusted", SandboxTierTrusted},
+		{"privileged", SandboxTierPrivileged},
+	}
+	for _, tt := range tests {
+		got, err := ParseSandboxTier(tt.in)
+		if err != nil {
+			t.Errorf("ParseSandboxTier(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSandboxTier(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+		if got.String() != tt.in {
+			t.Errorf("%v.String() = %q, want %q", got, got.String(), tt.in)
+		}
+	}
+
+	if _, err := ParseSandboxTier("root"); err == nil {
+		t.Error("ParseSandboxTier(\"root\") should error")
+	}
+}
+
+func TestSandboxTier_AtLeast(t *testing.T) {
+	tiers := []SandboxTier{SandboxTierNone, SandboxTierRestricted, SandboxTierTrusted, SandboxTierPrivileged}
+	for i, a := range tiers {
+		for j, b := range tiers {
+			if got, want := a.AtLeast(b), i >= j; got != want {
+				t.Errorf("%v.AtLeast(%v) = %v, want %v", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestSandboxTier_TiersUpTo(t *testing.T) {
+	got := SandboxTierTrusted.TiersUpTo()
+	want := []string{"none", "restricted", "trusted"}
+	if len(got) != len(want) {
+		t.Fatalf("TiersUpTo() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TiersUpTo()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if got := SandboxTierNone.TiersUpTo(); len(got) != 1 || got[0] != "none" {
+		t.Errorf("SandboxTierNone.TiersUpTo() = %v, want [none]", got)
+	}
+}
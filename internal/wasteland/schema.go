@@ -0,0 +1,44 @@
+package wasteland
+
+import (
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/jsonschema"
+)
+
+// WantedStatuses are the lifecycle states of a wanted item.
+var WantedStatuses = []string{
+	string(WantedOpen), string(WantedClaimed), string(WantedInReview), string(WantedCompleted), string(WantedWithdrawn),
+}
+
+// WantedTypes are the accepted wanted item types.
+var WantedTypes = []string{"feature", "bug", "design", "rfc", "docs"}
+
+// EffortLevels are the accepted wanted item effort levels, smallest first.
+// They are shared with local beads' effort: labels.
+var EffortLevels = beads.EffortLevels
+
+// WantedSchema returns a JSON Schema describing a wanted item as stored in
+// the commons wanted table. Properties are generated from
+// doltserver.WantedItem; enums come from the lists in this package.
+func WantedSchema() *jsonschema.Schema {
+	priorities := make([]any, len(PriorityLabels))
+	for i := range PriorityLabels {
+		priorities[i] = i
+	}
+	return jsonschema.FromStruct("WantedItem", doltserver.WantedItem{}, map[string][]any{
+		"status":           toAny(WantedStatuses),
+		"type":             toAny(WantedTypes),
+		"effort_level":     toAny(EffortLevels),
+		"priority":         priorities,
+		"sandbox_min_tier": toAny(sandboxTiers),
+	})
+}
+
+func toAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package wasteland
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/jsonschema"
+)
+
+func TestWantedSchema_IncludesAllFields(t *testing.T) {
+	s := WantedSchema()
+
+	typ := reflect.TypeOf(doltserver.WantedItem{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := jsonschema.PropertyName(typ.Field(i))
+		if name == "" {
+			continue
+		}
+		if _, ok := s.Properties[name]; !ok {
+			t.Errorf("schema missing property %q for field %s", name, typ.Field(i).Name)
+		}
+	}
+}
+
+func TestWantedSchema_Enums(t *testing.T) {
+	s := WantedSchema()
+
+	for prop, want := range map[string]int{
+		"status":           len(WantedStatuses),
+		"type":             len(WantedTypes),
+		"effort_level":     len(EffortLevels),
+		"priority":         len(PriorityLabels),
+		"sandbox_min_tier": len(ValidSandboxTiers()),
+	} {
+		p, ok := s.Properties[prop]
+		if !ok {
+			t.Errorf("schema missing %q", prop)
+			continue
+		}
+		if len(p.Enum) != want {
+			t.Errorf("%s enum = %v, want %d values", prop, p.Enum, want)
+		}
+	}
+	// Columns are named as in the wanted table.
+	for _, col := range []string{"id", "posted_by", "claimed_by", "sandbox_required"} {
+		if _, ok := s.Properties[col]; !ok {
+			t.Errorf("schema missing column %q", col)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package wasteland
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// runDoltQuery runs a SQL query against the dolt database in dbDir and
+// returns its CSV output. Var so tests can stub query results.
+var runDoltQuery = func(dbDir, query string) (string, error) {
+	cmd := exec.Command("dolt", "sql", "-r", "csv", "-q", query)
+	cmd.Dir = dbDir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("dolt sql: %w (%s)", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("dolt sql: %w", err)
+	}
+	return string(output), nil
+}
+
+// topPostersLimit caps the number of posters reported by CommonsStats.
+const topPostersLimit = 10
+
+// Count is a labeled count in a commons summary.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Stats summarizes the wanted board of a commons database.
+type Stats struct {
+	Total      int     `json:"total"`
+	ByStatus   []Count `json:"by_status"`
+	ByProject  []Count `json:"by_project"`
+	ByType     []Count `json:"by_type"`
+	TopPosters []Count `json:"top_posters"`
+}
+
+// CommonsStats aggregates wanted-item counts by status, project, and type,
+// plus the most active posters, from the commons clone in dbDir.
+// Items with no project, type, or poster are counted under "(none)".
+func CommonsStats(dbDir string) (*Stats, error) {
+	stats := &Stats{}
+
+	groups := []struct {
+		column string
+		limit  int
+		dest   *[]Count
+	}{
+		{"status", 0, &stats.ByStatus},
+		{"project", 0, &stats.ByProject},
+		{"type", 0, &stats.ByType},
+		{"posted_by", topPostersLimit, &stats.TopPosters},
+	}
+
+	for _, g := range groups {
+		query := fmt.Sprintf(
+			"SELECT COALESCE(%s, '') AS name, COUNT(*) AS count FROM wanted GROUP BY name ORDER BY count DESC, name ASC",
+			g.column)
+		if g.limit > 0 {
+			query += fmt.Sprintf(" LIMIT %d", g.limit)
+		}
+
+		output, err := runDoltQuery(dbDir, query)
+		if err != nil {
+			return nil, fmt.Errorf("counting wanted by %s: %w", g.column, err)
+		}
+		counts, err := parseCounts(output)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s counts: %w", g.column, err)
+		}
+		*g.dest = counts
+	}
+
+	for _, c := range stats.ByStatus {
+		stats.Total += c.Count
+	}
+
+	return stats, nil
+}
+
+// parseCounts parses "name,count" CSV rows (with header) into counts.
+func parseCounts(output string) ([]Count, error) {
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := []Count{}
+	for i, rec := range records {
+		if i == 0 {
+			continue // header
+		}
+		if len(rec) < 2 {
+			return nil, fmt.Errorf("row %d: expected name,count, got %q", i, rec)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(rec[1]))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid count %q", i, rec[1])
+		}
+		name := rec[0]
+		if name == "" {
+			name = "(none)"
+		}
+		counts = append(counts, Count{Name: name, Count: n})
+	}
+	return counts, nil
+}
@@ -0,0 +1,96 @@
+package wasteland
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// stubDoltQuery replaces runDoltQuery for the duration of a test, answering
+// each query with the output of the first response whose key it contains.
+func stubDoltQuery(t *testing.T, responses map[string]string) *[]string {
+	t.Helper()
+	var queries []string
+	orig := runDoltQuery
+	runDoltQuery = func(dbDir, query string) (string, error) {
+		queries = append(queries, query)
+		for key, out := range responses {
+			if strings.Contains(query, key) {
+				return out, nil
+			}
+		}
+		return "", fmt.Errorf("unexpected query: %s", query)
+	}
+	t.Cleanup(func() { runDoltQuery = orig })
+	return &queries
+}
+
+func TestCommonsStats(t *testing.T) {
+	stubDoltQuery(t, map[string]string{
+		"COALESCE(status":    "name,count\nopen,5\nclaimed,2\ncompleted,1\n",
+		"COALESCE(project":   "name,count\ngastown,4\n,3\nbeads,1\n",
+		"COALESCE(type":      "name,count\nbug,6\nfeature,2\n",
+		"COALESCE(posted_by": "name,count\nalice-dev,5\nbob,3\n",
+	})
+
+	stats, err := CommonsStats(t.TempDir())
+	if err != nil {
+		t.Fatalf("CommonsStats() error: %v", err)
+	}
+
+	if stats.Total != 8 {
+		t.Errorf("Total = %d, want 8", stats.Total)
+	}
+	wantStatus := []Count{{"open", 5}, {"claimed", 2}, {"completed", 1}}
+	if !reflect.DeepEqual(stats.ByStatus, wantStatus) {
+		t.Errorf("ByStatus = %+v, want %+v", stats.ByStatus, wantStatus)
+	}
+	wantProject := []Count{{"gastown", 4}, {"(none)", 3}, {"beads", 1}}
+	if !reflect.DeepEqual(stats.ByProject, wantProject) {
+		t.Errorf("ByProject = %+v, want %+v", stats.ByProject, wantProject)
+	}
+	if len(stats.ByType) != 2 || stats.ByType[0] != (Count{"bug", 6}) {
+		t.Errorf("ByType = %+v, want bug first", stats.ByType)
+	}
+	if len(stats.TopPosters) != 2 || stats.TopPosters[0] != (Count{"alice-dev", 5}) {
+		t.Errorf("TopPosters = %+v, want alice-dev first", stats.TopPosters)
+	}
+}
+
+func TestCommonsStats_TopPostersLimited(t *testing.T) {
+	queries := stubDoltQuery(t, map[string]string{"COALESCE(": "name,count\n"})
+
+	if _, err := CommonsStats(t.TempDir()); err != nil {
+		t.Fatalf("CommonsStats() error: %v", err)
+	}
+	var posterQuery string
+	for _, q := range *queries {
+		if strings.Contains(q, "posted_by") {
+			posterQuery = q
+		}
+	}
+	if !strings.HasSuffix(posterQuery, fmt.Sprintf("LIMIT %d", topPostersLimit)) {
+		t.Errorf("poster query = %q, want LIMIT %d", posterQuery, topPostersLimit)
+	}
+}
+
+func TestCommonsStats_EmptyBoard(t *testing.T) {
+	stubDoltQuery(t, map[string]string{"COALESCE(": "name,count\n"})
+
+	stats, err := CommonsStats(t.TempDir())
+	if err != nil {
+		t.Fatalf("CommonsStats() error: %v", err)
+	}
+	if stats.Total != 0 || len(stats.ByStatus) != 0 {
+		t.Errorf("stats = %+v, want empty", stats)
+	}
+}
+
+func TestCommonsStats_BadCount(t *testing.T) {
+	stubDoltQuery(t, map[string]string{"COALESCE(": "name,count\nopen,lots\n"})
+
+	if _, err := CommonsStats(t.TempDir()); err == nil {
+		t.Fatal("CommonsStats() with invalid count should error")
+	}
+}
@@ -0,0 +1,34 @@
+package wasteland
+
+// WantedStatus is the lifecycle state of a wanted item.
+type WantedStatus string
+
+// Wanted item statuses. Items move open → claimed → in_review → completed;
+// open and claimed items can also be withdrawn.
+const (
+	WantedOpen      WantedStatus = "open"
+	WantedClaimed   WantedStatus = "claimed"
+	WantedInReview  WantedStatus = "in_review"
+	WantedCompleted WantedStatus = "completed"
+	WantedWithdrawn WantedStatus = "withdrawn"
+)
+
+// wantedTransitions lists the statuses each status may move to. Claims can
+// be released back to open, and a review can send work back to claimed.
+// Completed and withdrawn items are final.
+var wantedTransitions = map[WantedStatus][]WantedStatus{
+	WantedOpen:     {WantedClaimed, WantedWithdrawn},
+	WantedClaimed:  {WantedInReview, WantedOpen, WantedWithdrawn},
+	WantedInReview: {WantedCompleted, WantedClaimed},
+}
+
+// CanTransition reports whether a wanted item may move from one status to
+// another. Unknown statuses cannot transition.
+func CanTransition(from, to WantedStatus) bool {
+	for _, next := range wantedTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
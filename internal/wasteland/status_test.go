@@ -0,0 +1,29 @@
+package wasteland
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	legal := map[[2]WantedStatus]bool{
+		{WantedOpen, WantedClaimed}:       true,
+		{WantedOpen, WantedWithdrawn}:     true,
+		{WantedClaimed, WantedInReview}:   true,
+		{WantedClaimed, WantedOpen}:       true,
+		{WantedClaimed, WantedWithdrawn}:  true,
+		{WantedInReview, WantedCompleted}: true,
+		{WantedInReview, WantedClaimed}:   true,
+	}
+
+	all := []WantedStatus{WantedOpen, WantedClaimed, WantedInReview, WantedCompleted, WantedWithdrawn}
+	for _, from := range all {
+		for _, to := range all {
+			want := legal[[2]WantedStatus{from, to}]
+			if got := CanTransition(from, to); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+
+	if CanTransition("bogus", WantedClaimed) || CanTransition(WantedOpen, "bogus") {
+		t.Error("unknown statuses should not transition")
+	}
+}
@@ -0,0 +1,45 @@
+package wasteland
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// doltStep is one scripted runDolt result.
+type doltStep struct {
+	output string
+	err    error
+}
+
+// scriptDolt replaces runDolt with a stub that answers each command from
+// script, keyed by its first argument (e.g. "fetch", "push"), one step per
+// invocation. Commands without a step left succeed silently. It records
+// each call's arguments, space-joined.
+func scriptDolt(t *testing.T, script map[string][]doltStep) *[]string {
+	t.Helper()
+	var calls []string
+	orig := runDolt
+	runDolt = func(dir string, args ...string) (string, error) {
+		calls = append(calls, strings.Join(args, " "))
+		steps := script[args[0]]
+		if len(steps) == 0 {
+			return "", nil
+		}
+		script[args[0]] = steps[1:]
+		return steps[0].output, steps[0].err
+	}
+	t.Cleanup(func() { runDolt = orig })
+	return &calls
+}
+
+// makeDoltDir returns a temp directory that looks like a dolt clone.
+func makeDoltDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".dolt"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
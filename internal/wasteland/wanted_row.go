@@ -0,0 +1,129 @@
+package wasteland
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// WantedColumns are the wanted table columns that ScanWantedRow maps onto
+// doltserver.WantedItem, in a convenient SELECT order.
+var WantedColumns = []string{
+	"id", "title", "description", "project", "type", "priority", "tags",
+	"posted_by", "claimed_by", "status", "effort_level",
+	"sandbox_required", "sandbox_min_tier",
+}
+
+// ScanWantedRow builds a WantedItem from one query result row, matching
+// values to fields by column name. Columns not in WantedColumns are
+// ignored, and NULL (empty) values leave the field at its zero value. tags
+// is decoded from its JSON array and sandbox_required from 0/1 or
+// true/false.
+func ScanWantedRow(cols []string, row []string) (*doltserver.WantedItem, error) {
+	if len(cols) != len(row) {
+		return nil, fmt.Errorf("wanted row has %d values for %d columns", len(row), len(cols))
+	}
+
+	item := &doltserver.WantedItem{}
+	for i, col := range cols {
+		value := row[i]
+		if value == "" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(col)) {
+		case "id":
+			item.ID = value
+		case "title":
+			item.Title = value
+		case "description":
+			item.Description = value
+		case "project":
+			item.Project = value
+		case "type":
+			item.Type = value
+		case "priority":
+			priority, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("wanted priority %q: %w", value, err)
+			}
+			item.Priority = priority
+		case "tags":
+			if value == "null" {
+				continue
+			}
+			if err := json.Unmarshal([]byte(value), &item.Tags); err != nil {
+				return nil, fmt.Errorf("wanted tags %q: %w", value, err)
+			}
+		case "posted_by":
+			item.PostedBy = value
+		case "claimed_by":
+			item.ClaimedBy = value
+		case "status":
+			item.Status = value
+		case "effort_level":
+			item.EffortLevel = value
+		case "sandbox_required":
+			required, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("wanted sandbox_required %q: %w", value, err)
+			}
+			item.SandboxRequired = required
+		case "sandbox_min_tier":
+			item.SandboxMinTier = value
+		}
+	}
+	return item, nil
+}
+
+// ScanWantedJSON builds WantedItems from `dolt sql -r json` output
+// ({"rows": [{column: value, ...}]}). Values are converted to their text
+// form and mapped as in ScanWantedRow, so JSON columns such as tags may be
+// either nested arrays or JSON strings.
+func ScanWantedJSON(data []byte) ([]*doltserver.WantedItem, error) {
+	var result struct {
+		Rows []map[string]any `json:"rows"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parsing wanted rows: %w", err)
+	}
+
+	items := make([]*doltserver.WantedItem, 0, len(result.Rows))
+	for _, r := range result.Rows {
+		cols := make([]string, 0, len(r))
+		row := make([]string, 0, len(r))
+		for col, v := range r {
+			text, err := jsonValueText(v)
+			if err != nil {
+				return nil, fmt.Errorf("wanted column %s: %w", col, err)
+			}
+			cols = append(cols, col)
+			row = append(row, text)
+		}
+		item, err := ScanWantedRow(cols, row)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// jsonValueText renders a decoded JSON value as dolt's CSV output would.
+func jsonValueText(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
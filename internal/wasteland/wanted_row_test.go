@@ -0,0 +1,109 @@
+package wasteland
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+func TestScanWantedRow(t *testing.T) {
+	row := []string{"w-abc", "Fix auth", "Tokens, expiring early", "gastown", "bug", "1",
+		`["go", "auth"]`, "alice", "", "open", "small", "1", "trusted"}
+
+	item, err := ScanWantedRow(WantedColumns, row)
+	if err != nil {
+		t.Fatalf("ScanWantedRow() error: %v", err)
+	}
+	want := &doltserver.WantedItem{
+		ID:              "w-abc",
+		Title:           "Fix auth",
+		Description:     "Tokens, expiring early",
+		Project:         "gastown",
+		Type:            "bug",
+		Priority:        1,
+		Tags:            []string{"go", "auth"},
+		PostedBy:        "alice",
+		Status:          "open",
+		EffortLevel:     "small",
+		SandboxRequired: true,
+		SandboxMinTier:  "trusted",
+	}
+	if !reflect.DeepEqual(item, want) {
+		t.Errorf("ScanWantedRow() = %+v, want %+v", item, want)
+	}
+}
+
+func TestScanWantedRow_NullsAndBooleans(t *testing.T) {
+	cols := []string{"id", "tags", "sandbox_required", "priority", "created_at"}
+	tests := []struct {
+		name         string
+		row          []string
+		wantTags     []string
+		wantRequired bool
+	}{
+		{"null tags", []string{"w-1", "", "0", "", "2026-01-01"}, nil, false},
+		{"json null tags", []string{"w-1", "null", "false", "2", ""}, nil, false},
+		{"empty tags", []string{"w-1", "[]", "true", "2", ""}, []string{}, true},
+		{"tinyint true", []string{"w-1", `["x"]`, "1", "2", ""}, []string{"x"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := ScanWantedRow(cols, tt.row)
+			if err != nil {
+				t.Fatalf("ScanWantedRow() error: %v", err)
+			}
+			if !reflect.DeepEqual(item.Tags, tt.wantTags) {
+				t.Errorf("Tags = %#v, want %#v", item.Tags, tt.wantTags)
+			}
+			if item.SandboxRequired != tt.wantRequired {
+				t.Errorf("SandboxRequired = %v, want %v", item.SandboxRequired, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestScanWantedRow_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cols []string
+		row  []string
+	}{
+		{"length mismatch", []string{"id", "title"}, []string{"w-1"}},
+		{"bad priority", []string{"priority"}, []string{"high"}},
+		{"bad tags", []string{"tags"}, []string{"go,auth"}},
+		{"bad boolean", []string{"sandbox_required"}, []string{"maybe"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ScanWantedRow(tt.cols, tt.row); err == nil {
+				t.Error("ScanWantedRow() should fail")
+			}
+		})
+	}
+}
+
+func TestScanWantedJSON(t *testing.T) {
+	data := []byte(`{"rows": [
+		{"id": "w-1", "title": "Fix auth", "priority": 1, "tags": ["go", "auth"], "sandbox_required": 1, "claimed_by": null},
+		{"id": "w-2", "title": "Docs", "priority": 3, "tags": "[\"docs\"]", "sandbox_required": false, "sandbox_min_tier": null}
+	]}`)
+
+	items, err := ScanWantedJSON(data)
+	if err != nil {
+		t.Fatalf("ScanWantedJSON() error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if got := items[0]; got.ID != "w-1" || got.Priority != 1 || !reflect.DeepEqual(got.Tags, []string{"go", "auth"}) || !got.SandboxRequired || got.ClaimedBy != "" {
+		t.Errorf("items[0] = %+v", got)
+	}
+	if got := items[1]; got.ID != "w-2" || got.Priority != 3 || !reflect.DeepEqual(got.Tags, []string{"docs"}) || got.SandboxRequired {
+		t.Errorf("items[1] = %+v", got)
+	}
+
+	if _, err := ScanWantedJSON([]byte("not json")); err == nil {
+		t.Error("ScanWantedJSON() should reject malformed output")
+	}
+}
@@ -18,8 +18,17 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
 )
 
+// UpstreamCommons is the DoltHub path of the default Wasteland commons.
+const UpstreamCommons = "hop/wl-commons"
+
+// Clock returns the current time for timestamps this package records.
+// Tests may replace it to get deterministic values.
+var Clock = time.Now
+
 // Config holds the wasteland configuration for a rig.
 type Config struct {
 	// Upstream is the DoltHub path of the upstream commons (e.g., "steveyegge/wl-commons").
@@ -202,13 +211,14 @@ func RegisterRig(localDir string, handle, dolthubOrg, displayName, ownerEmail, g
 	return nil
 }
 
-// PushToOrigin pushes the local clone to origin main.
+// PushToOrigin pushes the local clone to origin main. Failures wrap
+// doltserver's ErrDoltAuth, ErrDoltNonFastForward or ErrDoltPush.
 func PushToOrigin(localDir string) error {
 	cmd := exec.Command("dolt", "push", "origin", "main")
 	cmd.Dir = localDir
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("dolt push: %w (%s)", err, strings.TrimSpace(string(output)))
+		return doltserver.ClassifyPushError(string(output), err)
 	}
 	return nil
 }
@@ -252,7 +262,24 @@ func LocalCloneDir(townRoot, upstreamOrg, upstreamDB string) string {
 	return filepath.Join(WastelandDir(townRoot), upstreamOrg, upstreamDB)
 }
 
-// escapeSQLString escapes single quotes in SQL strings.
+// escapeSQLString escapes backslashes and single quotes for a single-quoted
+// SQL string literal. Dolt, like MySQL, treats a backslash as an escape, so
+// a trailing one would otherwise swallow the closing quote.
 func escapeSQLString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
 	return strings.ReplaceAll(s, "'", "''")
 }
+
+// BuildInClause returns a SQL "column IN ('a','b')" condition with each value
+// escaped. The column name is not escaped and must come from trusted code.
+// An empty values slice is an error, since "IN ()" is invalid SQL.
+func BuildInClause(column string, values []string) (string, error) {
+	if len(values) == 0 {
+		return "", fmt.Errorf("building IN clause for %s: no values", column)
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + escapeSQLString(v) + "'"
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(quoted, ",")), nil
+}
@@ -103,6 +103,9 @@ func TestEscapeSQLString(t *testing.T) {
 		{"hello", "hello"},
 		{"it's", "it''s"},
 		{"it''s", "it''''s"},
+		{`C:\dir`, `C:\\dir`},
+		{`trailing\`, `trailing\\`},
+		{`\'`, `\\''`},
 		{"", ""},
 	}
 	for _, tt := range tests {
@@ -189,3 +192,35 @@ func TestConfigPath(t *testing.T) {
 		t.Errorf("ConfigPath = %q, want %q", got, want)
 	}
 }
+
+func TestBuildInClause(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{"single", []string{"gastown"}, "project IN ('gastown')"},
+		{"multiple", []string{"gastown", "beads"}, "project IN ('gastown','beads')"},
+		{"escapes quotes", []string{"o'brien", "x' OR '1'='1"}, "project IN ('o''brien','x'' OR ''1''=''1')"},
+		{"escapes backslashes", []string{`x\`, `\' OR 1=1 -- `}, `project IN ('x\\','\\'' OR 1=1 -- ')`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildInClause("project", tt.values)
+			if err != nil {
+				t.Fatalf("BuildInClause() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BuildInClause() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildInClause_Empty(t *testing.T) {
+	for _, values := range [][]string{nil, {}} {
+		if _, err := BuildInClause("project", values); err == nil {
+			t.Errorf("BuildInClause(%v) should error", values)
+		}
+	}
+}
@@ -763,8 +763,7 @@ func (f *LiveConvoyFetcher) FetchWorkers() ([]WorkerRow, error) {
 		}
 
 		// Look up assigned issue for this worker
-		// Assignee format: "rigname/polecats/workername"
-		assignee := fmt.Sprintf("%s/polecats/%s", rig, workerName)
+		assignee := session.PolecatAssignee(rig, workerName)
 		var issueID, issueTitle string
 		if issue, ok := assignedIssues[assignee]; ok {
 			issueID = issue.ID
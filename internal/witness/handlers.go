@@ -965,7 +965,43 @@ type DetectZombiePolecatsResult struct {
 //   - If git state is clean (no unpushed work): auto-nuke
 //   - If git state is dirty (unpushed/uncommitted work): escalate to Mayor via
 //     EscalateRecoveryNeeded, create cleanup wisp
+//
+// Use ScanZombiePolecats to detect zombies without acting on them.
 func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZombiePolecatsResult {
+	return detectZombiePolecats(workDir, rigName, router, false)
+}
+
+// ScanZombiePolecats runs the same detection as DetectZombiePolecats but takes
+// no action: nothing is nuked, escalated, or reset. Each zombie's Action is the
+// one a real sweep would have taken, marked as not taken.
+func ScanZombiePolecats(workDir, rigName string) *DetectZombiePolecatsResult {
+	return detectZombiePolecats(workDir, rigName, nil, true)
+}
+
+// dryRunAction marks action as one a dry-run sweep did not take.
+func dryRunAction(action string) string {
+	return action + " [dry-run, not taken]"
+}
+
+// nukeZombie nukes the polecat behind zombie and, if recoverBead is set,
+// resets its abandoned hook bead for re-dispatch (gt-c3lgp). failAction
+// prefixes the action when the nuke fails. In a dry run it only marks the
+// action as not taken.
+func nukeZombie(workDir, rigName string, zombie *ZombieResult, failAction string, recoverBead, dryRun bool, router *mail.Router) {
+	if dryRun {
+		zombie.Action = dryRunAction(zombie.Action)
+		return
+	}
+	if err := NukePolecat(workDir, rigName, zombie.PolecatName); err != nil {
+		zombie.Error = err
+		zombie.Action = fmt.Sprintf("%s: %v", failAction, err)
+	}
+	if recoverBead {
+		zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, zombie.HookBead, zombie.PolecatName, router)
+	}
+}
+
+func detectZombiePolecats(workDir, rigName string, router *mail.Router, dryRun bool) *DetectZombiePolecatsResult {
 	result := &DetectZombiePolecatsResult{}
 
 	townRoot, err := workspace.Find(workDir)
@@ -1006,7 +1042,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 		doneIntent := extractDoneIntent(labels)
 
 		if sessionAlive {
-			if zombie, found := detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, sessionName, t, doneIntent, router); found {
+			if zombie, found := detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, sessionName, t, doneIntent, router, dryRun); found {
 				result.Zombies = append(result.Zombies, zombie)
 			}
 
@@ -1023,12 +1059,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 					HookBead:    deadAgentHookBead,
 					Action:      "killed-agent-dead-session",
 				}
-				if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-					zombie.Error = err
-					zombie.Action = fmt.Sprintf("kill-agent-dead-session-failed: %v", err)
-				}
-				// Reset abandoned bead for re-dispatch (gt-c3lgp)
-				zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, deadAgentHookBead, polecatName, router)
+				nukeZombie(workDir, rigName, &zombie, "kill-agent-dead-session-failed", true, dryRun, router)
 				result.Zombies = append(result.Zombies, zombie)
 			} else {
 				// Agent is alive. Check if the hooked bead has been closed.
@@ -1042,10 +1073,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 						HookBead:    hookBead,
 						Action:      "nuke-bead-closed-polecat",
 					}
-					if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-						zombie.Error = err
-						zombie.Action = fmt.Sprintf("nuke-bead-closed-failed: %v", err)
-					}
+					nukeZombie(workDir, rigName, &zombie, "nuke-bead-closed-failed", false, dryRun, router)
 					result.Zombies = append(result.Zombies, zombie)
 				} else {
 					// Agent is alive and bead is not closed — check for hung session.
@@ -1063,11 +1091,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 								HookBead:    hungHookBead,
 								Action:      fmt.Sprintf("killed-hung-session (inactive %dm)", inactiveMinutes),
 							}
-							if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-								zombie.Error = err
-								zombie.Action = fmt.Sprintf("kill-hung-session-failed: %v", err)
-							}
-							zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, hungHookBead, polecatName, router)
+							nukeZombie(workDir, rigName, &zombie, "kill-hung-session-failed", true, dryRun, router)
 							result.Zombies = append(result.Zombies, zombie)
 						}
 					}
@@ -1076,7 +1100,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 			continue // Either handled or not a zombie
 		}
 
-		if zombie, found := detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, sessionName, t, doneIntent, detectedAt, router); found {
+		if zombie, found := detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, sessionName, t, doneIntent, detectedAt, router, dryRun); found {
 			result.Zombies = append(result.Zombies, zombie)
 		}
 	}
@@ -1086,7 +1110,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 
 // detectZombieLiveSession checks a polecat with a live tmux session for zombie indicators:
 // stuck done-intent, dead agent process, or closed bead while still running.
-func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, sessionName string, t *tmux.Tmux, doneIntent *DoneIntent, router *mail.Router) (ZombieResult, bool) {
+func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, sessionName string, t *tmux.Tmux, doneIntent *DoneIntent, router *mail.Router, dryRun bool) (ZombieResult, bool) {
 	// Check for done-intent stuck too long (polecat hung in gt done).
 	if doneIntent != nil && time.Since(doneIntent.Timestamp) > 60*time.Second {
 		_, stuckHookBead := getAgentBeadState(workDir, agentBeadID)
@@ -1096,11 +1120,7 @@ func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, session
 			HookBead:    stuckHookBead,
 			Action:      fmt.Sprintf("killed-stuck-session (done-intent age=%v)", time.Since(doneIntent.Timestamp).Round(time.Second)),
 		}
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-			zombie.Error = err
-			zombie.Action = fmt.Sprintf("kill-stuck-session-failed: %v", err)
-		}
-		zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, stuckHookBead, polecatName, router)
+		nukeZombie(workDir, rigName, &zombie, "kill-stuck-session-failed", true, dryRun, router)
 		return zombie, true
 	}
 
@@ -1113,11 +1133,7 @@ func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, session
 			HookBead:    deadAgentHookBead,
 			Action:      "killed-agent-dead-session",
 		}
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-			zombie.Error = err
-			zombie.Action = fmt.Sprintf("kill-agent-dead-session-failed: %v", err)
-		}
-		zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, deadAgentHookBead, polecatName, router)
+		nukeZombie(workDir, rigName, &zombie, "kill-agent-dead-session-failed", true, dryRun, router)
 		return zombie, true
 	}
 
@@ -1130,10 +1146,7 @@ func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, session
 			HookBead:    hookBead,
 			Action:      "nuke-bead-closed-polecat",
 		}
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-			zombie.Error = err
-			zombie.Action = fmt.Sprintf("nuke-bead-closed-failed: %v", err)
-		}
+		nukeZombie(workDir, rigName, &zombie, "nuke-bead-closed-failed", false, dryRun, router)
 		return zombie, true
 	}
 
@@ -1142,7 +1155,7 @@ func detectZombieLiveSession(workDir, rigName, polecatName, agentBeadID, session
 
 // detectZombieDeadSession checks a polecat with a dead tmux session for zombie indicators:
 // stale done-intent, or active agent state / hooked bead with no session.
-func detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, sessionName string, t *tmux.Tmux, doneIntent *DoneIntent, detectedAt time.Time, router *mail.Router) (ZombieResult, bool) {
+func detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, sessionName string, t *tmux.Tmux, doneIntent *DoneIntent, detectedAt time.Time, router *mail.Router, dryRun bool) (ZombieResult, bool) {
 	// Done-intent: polecat was trying to exit.
 	if doneIntent != nil {
 		age := time.Since(doneIntent.Timestamp)
@@ -1156,11 +1169,7 @@ func detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, session
 			HookBead:    diHookBead,
 			Action:      fmt.Sprintf("auto-nuked (done-intent age=%v, type=%s)", age.Round(time.Second), doneIntent.ExitType),
 		}
-		if err := NukePolecat(workDir, rigName, polecatName); err != nil {
-			zombie.Error = err
-			zombie.Action = fmt.Sprintf("nuke-failed (done-intent): %v", err)
-		}
-		zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, diHookBead, polecatName, router)
+		nukeZombie(workDir, rigName, &zombie, "nuke-failed (done-intent)", true, dryRun, router)
 		return zombie, true
 	}
 
@@ -1182,6 +1191,10 @@ func detectZombieDeadSession(workDir, rigName, polecatName, agentBeadID, session
 	}
 
 	cleanupStatus := getCleanupStatus(workDir, rigName, polecatName)
+	if dryRun {
+		zombie.Action = dryRunAction(plannedZombieCleanup(cleanupStatus))
+		return zombie, true
+	}
 	handleZombieCleanup(workDir, rigName, polecatName, hookBead, cleanupStatus, router, &zombie)
 	zombie.BeadRecovered = resetAbandonedBead(workDir, rigName, hookBead, polecatName, router)
 	return zombie, true
@@ -1195,6 +1208,18 @@ func isZombieState(agentState, hookBead string) bool {
 	return agentState == "working" || agentState == "running" || agentState == "spawning"
 }
 
+// plannedZombieCleanup describes the action handleZombieCleanup would take
+// for a zombie with the given cleanup_status.
+func plannedZombieCleanup(cleanupStatus string) string {
+	switch cleanupStatus {
+	case "clean", "":
+		return "auto-nuke-if-clean"
+	case "has_uncommitted", "has_stash", "has_unpushed":
+		return fmt.Sprintf("escalate (cleanup_status=%s)", cleanupStatus)
+	}
+	return "investigate"
+}
+
 // handleZombieCleanup determines the cleanup action for a confirmed zombie based on
 // its cleanup_status. Clean or empty status → auto-nuke. Dirty status → escalate.
 func handleZombieCleanup(workDir, rigName, polecatName, hookBead, cleanupStatus string, router *mail.Router, zombie *ZombieResult) {
@@ -1349,15 +1374,12 @@ func getAgentBeadState(workDir, agentBeadID string) (agentState, hookBead string
 	}
 
 	// Parse JSON response — bd show --json returns an array
-	var issues []struct {
-		AgentState string `json:"agent_state"`
-		HookBead   string `json:"hook_bead"`
-	}
+	var issues []beads.Issue
 	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
 		return "", ""
 	}
 
-	return issues[0].AgentState, issues[0].HookBead
+	return beads.AgentStateOf(&issues[0]), issues[0].HookBead
 }
 
 // getBeadStatus returns the status of a bead (e.g., "open", "closed", "hooked").
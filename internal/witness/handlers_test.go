@@ -1249,3 +1249,55 @@ esac
 	}
 }
 
+
+func TestScanZombiePolecats_DirectoryScanning(t *testing.T) {
+	tmpDir := t.TempDir()
+	rigName := "testrig"
+	polecatsDir := filepath.Join(tmpDir, rigName, "polecats")
+	for _, name := range []string{"alpha", "bravo"} {
+		if err := os.MkdirAll(filepath.Join(polecatsDir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result := ScanZombiePolecats(tmpDir, rigName)
+
+	if result.Checked != 2 {
+		t.Errorf("Checked = %d, want 2", result.Checked)
+	}
+	for _, name := range []string{"alpha", "bravo"} {
+		if _, err := os.Stat(filepath.Join(polecatsDir, name)); err != nil {
+			t.Errorf("dry-run scan touched polecat %s: %v", name, err)
+		}
+	}
+}
+
+func TestPlannedZombieCleanup(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{"", "auto-nuke-if-clean"},
+		{"clean", "auto-nuke-if-clean"},
+		{"has_unpushed", "escalate (cleanup_status=has_unpushed)"},
+		{"has_stash", "escalate (cleanup_status=has_stash)"},
+		{"weird", "investigate"},
+	}
+	for _, tt := range tests {
+		if got := plannedZombieCleanup(tt.status); got != tt.want {
+			t.Errorf("plannedZombieCleanup(%q) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestNukeZombieDryRunTakesNoAction(t *testing.T) {
+	zombie := ZombieResult{PolecatName: "alpha", HookBead: "gt-1", Action: "killed-hung-session"}
+	nukeZombie("/nonexistent", "testrig", &zombie, "kill-hung-session-failed", true, true, nil)
+
+	if zombie.Action != "killed-hung-session [dry-run, not taken]" {
+		t.Errorf("Action = %q, want dry-run marker", zombie.Action)
+	}
+	if zombie.Error != nil || zombie.BeadRecovered {
+		t.Errorf("dry run acted: Error=%v BeadRecovered=%v", zombie.Error, zombie.BeadRecovered)
+	}
+}
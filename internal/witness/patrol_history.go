@@ -0,0 +1,87 @@
+package witness
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// patrolRunTimeLayout names patrol run files. It is the ISO 8601 basic
+// format in UTC with fixed-width nanoseconds: no colons, which Windows and
+// some sync tools reject in file names, unique per run, and sorting
+// chronologically.
+const patrolRunTimeLayout = "20060102T150405.000000000Z"
+
+// patrolClock returns the time a patrol is recorded at. Var so tests can pin it.
+var patrolClock = time.Now
+
+// PatrolRun is one recorded patrol of a rig.
+type PatrolRun struct {
+	Rig      string          `json:"rig"`
+	At       time.Time       `json:"at"`
+	Receipts []PatrolReceipt `json:"receipts"`
+}
+
+// RecordPatrol writes a patrol's receipts to <dir>/<rig>/<timestamp>.json
+// and returns the file's path. An empty receipt list is recorded too, so
+// the history shows clean patrols as well as zombies.
+func RecordPatrol(rig string, receipts []PatrolReceipt, dir string) (string, error) {
+	if rig == "" {
+		return "", fmt.Errorf("recording patrol: rig is required")
+	}
+	if receipts == nil {
+		receipts = []PatrolReceipt{}
+	}
+
+	run := PatrolRun{Rig: rig, At: patrolClock().UTC(), Receipts: receipts}
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding patrol run: %w", err)
+	}
+
+	rigDir := filepath.Join(dir, rig)
+	if err := os.MkdirAll(rigDir, 0755); err != nil {
+		return "", fmt.Errorf("creating patrol history directory: %w", err)
+	}
+	path := filepath.Join(rigDir, run.At.Format(patrolRunTimeLayout)+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("writing patrol run: %w", err)
+	}
+	return path, nil
+}
+
+// LoadPatrols reads the patrol runs recorded for rig under dir, oldest
+// first. A rig with no history returns no runs and no error.
+func LoadPatrols(rig, dir string) ([]PatrolRun, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, rig))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading patrol history: %w", err)
+	}
+
+	var runs []PatrolRun
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, rig, e.Name())
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the history dir
+		if err != nil {
+			return nil, fmt.Errorf("reading patrol run: %w", err)
+		}
+		var run PatrolRun
+		if err := json.Unmarshal(data, &run); err != nil {
+			return nil, fmt.Errorf("parsing patrol run %s: %w", path, err)
+		}
+		runs = append(runs, run)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].At.Before(runs[j].At) })
+	return runs, nil
+}
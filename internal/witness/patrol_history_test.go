@@ -0,0 +1,109 @@
+package witness
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func pinPatrolClock(t *testing.T, at time.Time) {
+	t.Helper()
+	orig := patrolClock
+	patrolClock = func() time.Time { return at }
+	t.Cleanup(func() { patrolClock = orig })
+}
+
+func TestRecordPatrol_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC)
+	pinPatrolClock(t, at)
+	receipts := []PatrolReceipt{{
+		Rig:               "gastown",
+		Polecat:           "atlas",
+		Verdict:           PatrolVerdictStale,
+		RecommendedAction: "auto-nuked",
+		Evidence:          PatrolReceiptEvidence{AgentState: "working", HookBead: "gt-abc"},
+	}}
+
+	path, err := RecordPatrol("gastown", receipts, dir)
+	if err != nil {
+		t.Fatalf("RecordPatrol() error: %v", err)
+	}
+	if want := filepath.Join(dir, "gastown", "20260203T040506.000000000Z.json"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+
+	runs, err := LoadPatrols("gastown", dir)
+	if err != nil {
+		t.Fatalf("LoadPatrols() error: %v", err)
+	}
+	want := []PatrolRun{{Rig: "gastown", At: at, Receipts: receipts}}
+	if !reflect.DeepEqual(runs, want) {
+		t.Errorf("LoadPatrols() = %+v, want %+v", runs, want)
+	}
+}
+
+func TestRecordPatrol_EmptyReceipts(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := RecordPatrol("gastown", nil, dir); err != nil {
+		t.Fatalf("RecordPatrol() error: %v", err)
+	}
+	runs, err := LoadPatrols("gastown", dir)
+	if err != nil {
+		t.Fatalf("LoadPatrols() error: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Receipts == nil || len(runs[0].Receipts) != 0 {
+		t.Errorf("LoadPatrols() = %+v, want one clean run", runs)
+	}
+}
+
+func TestLoadPatrols_SortsChronologically(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	// Record out of order, including a sub-second gap.
+	offsets := []time.Duration{2 * time.Hour, 0, 500 * time.Millisecond, time.Hour}
+	for _, off := range offsets {
+		pinPatrolClock(t, base.Add(off))
+		if _, err := RecordPatrol("gastown", nil, dir); err != nil {
+			t.Fatalf("RecordPatrol() error: %v", err)
+		}
+	}
+
+	runs, err := LoadPatrols("gastown", dir)
+	if err != nil {
+		t.Fatalf("LoadPatrols() error: %v", err)
+	}
+	if len(runs) != len(offsets) {
+		t.Fatalf("LoadPatrols() returned %d runs, want %d", len(runs), len(offsets))
+	}
+	for i, want := range []time.Duration{0, 500 * time.Millisecond, time.Hour, 2 * time.Hour} {
+		if !runs[i].At.Equal(base.Add(want)) {
+			t.Errorf("runs[%d].At = %v, want %v", i, runs[i].At, base.Add(want))
+		}
+	}
+}
+
+func TestLoadPatrols_NoHistory(t *testing.T) {
+	runs, err := LoadPatrols("gastown", t.TempDir())
+	if err != nil || runs != nil {
+		t.Errorf("LoadPatrols() = %v, %v; want nil, nil", runs, err)
+	}
+}
+
+func TestLoadPatrols_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "gastown"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "gastown", "bad.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadPatrols("gastown", dir); err == nil {
+		t.Error("LoadPatrols() should fail on a corrupt run file")
+	}
+}
@@ -1,13 +1,32 @@
 package witness
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/style"
+)
 
 // PatrolVerdict classifies witness patrol outcomes for machine consumers.
 type PatrolVerdict string
 
 const (
-	PatrolVerdictStale  PatrolVerdict = "stale"
+	// PatrolVerdictStale: the polecat was doing work when it died.
+	PatrolVerdictStale PatrolVerdict = "stale"
+	// PatrolVerdictOrphan: no sign of recent work; cleanup is routine.
 	PatrolVerdictOrphan PatrolVerdict = "orphan"
+	// PatrolVerdictConfused: handling the zombie failed, so its state after
+	// the patrol is unknown and needs a human look.
+	PatrolVerdictConfused PatrolVerdict = "confused"
+)
+
+// Receipt output formats accepted by RenderReceipts.
+const (
+	ReceiptFormatTable = "table"
+	ReceiptFormatJSON  = "json"
+	ReceiptFormatJSONL = "jsonl"
 )
 
 // PatrolReceiptEvidence captures the primary evidence fields for a verdict.
@@ -27,25 +46,61 @@ type PatrolReceipt struct {
 	Evidence          PatrolReceiptEvidence `json:"evidence"`
 }
 
-func receiptVerdictForZombie(z ZombieResult) PatrolVerdict {
-	if strings.TrimSpace(z.HookBead) != "" {
-		return PatrolVerdictStale
+// ZombiePolicy tunes how zombie patrol results are classified into verdicts.
+type ZombiePolicy struct {
+	// ActiveStates are agent states that indicate the polecat was recently
+	// doing work. Zombies in these states are classified as stale.
+	ActiveStates []string
+
+	// HookMeansStale classifies any zombie with hooked work as stale,
+	// regardless of its agent state.
+	HookMeansStale bool
+}
+
+// DefaultZombiePolicy returns the standard patrol classification policy.
+// All states from DetectZombiePolecats that indicate a polecat was recently
+// active are classified as stale. States without evidence of recent work
+// (e.g. "idle") fall through to orphan.
+func DefaultZombiePolicy() ZombiePolicy {
+	return ZombiePolicy{
+		ActiveStates: []string{
+			"working", "running", "spawning",
+			"stuck-in-done", "agent-dead-in-session",
+			"bead-closed-still-running", "done-intent-dead",
+		},
+		HookMeansStale: true,
+	}
+}
+
+// Verdict classifies a zombie patrol result under this policy. A result
+// whose action failed is confused whatever its state.
+func (p ZombiePolicy) Verdict(z ZombieResult) PatrolVerdict {
+	if z.Error != nil {
+		return PatrolVerdictConfused
 	}
-	// All states from DetectZombiePolecats that indicate a polecat was recently
-	// active are classified as stale. States without evidence of recent work
-	// (e.g. "idle") fall through to orphan.
-	switch z.AgentState {
-	case "working", "running", "spawning",
-		"stuck-in-done", "agent-dead-in-session",
-		"bead-closed-still-running", "done-intent-dead":
+	if p.HookMeansStale && strings.TrimSpace(z.HookBead) != "" {
 		return PatrolVerdictStale
-	default:
-		return PatrolVerdictOrphan
 	}
+	for _, state := range p.ActiveStates {
+		if z.AgentState == state {
+			return PatrolVerdictStale
+		}
+	}
+	return PatrolVerdictOrphan
+}
+
+func receiptVerdictForZombie(z ZombieResult) PatrolVerdict {
+	return DefaultZombiePolicy().Verdict(z)
 }
 
 // BuildPatrolReceipt projects a zombie patrol result into a stable JSON-ready receipt.
 func BuildPatrolReceipt(rigName string, z ZombieResult) PatrolReceipt {
+	return BuildPatrolReceiptWithPolicy(rigName, z, DefaultZombiePolicy())
+}
+
+// BuildPatrolReceiptWithPolicy is like BuildPatrolReceipt but classifies the
+// verdict using the given policy, letting rigs tune patrol sensitivity.
+func BuildPatrolReceiptWithPolicy(rigName string, z ZombieResult, policy ZombiePolicy) PatrolReceipt {
 	action := strings.TrimSpace(z.Action)
 	if action == "" {
 		action = "investigate"
@@ -54,7 +109,7 @@ func BuildPatrolReceipt(rigName string, z ZombieResult) PatrolReceipt {
 	receipt := PatrolReceipt{
 		Rig:               rigName,
 		Polecat:           z.PolecatName,
-		Verdict:           receiptVerdictForZombie(z),
+		Verdict:           policy.Verdict(z),
 		RecommendedAction: action,
 		Evidence: PatrolReceiptEvidence{
 			AgentState:    z.AgentState,
@@ -72,12 +127,82 @@ func BuildPatrolReceipt(rigName string, z ZombieResult) PatrolReceipt {
 
 // BuildPatrolReceipts returns machine-readable patrol verdicts for all detected zombies.
 func BuildPatrolReceipts(rigName string, result *DetectZombiePolecatsResult) []PatrolReceipt {
+	return BuildPatrolReceiptsWithPolicy(rigName, result, DefaultZombiePolicy())
+}
+
+// BuildPatrolReceiptsWithPolicy is like BuildPatrolReceipts but classifies
+// verdicts using the given policy.
+func BuildPatrolReceiptsWithPolicy(rigName string, result *DetectZombiePolecatsResult, policy ZombiePolicy) []PatrolReceipt {
 	if result == nil || len(result.Zombies) == 0 {
 		return nil
 	}
 	receipts := make([]PatrolReceipt, 0, len(result.Zombies))
 	for _, zombie := range result.Zombies {
-		receipts = append(receipts, BuildPatrolReceipt(rigName, zombie))
+		receipts = append(receipts, BuildPatrolReceiptWithPolicy(rigName, zombie, policy))
 	}
 	return receipts
 }
+
+// RenderReceipts writes patrol receipts to w in the given format:
+// "table" (human-readable, verdicts colored), "json" (indented array),
+// or "jsonl" (one receipt per line).
+func RenderReceipts(receipts []PatrolReceipt, format string, w io.Writer) error {
+	switch format {
+	case ReceiptFormatTable:
+		return renderReceiptTable(receipts, w)
+	case ReceiptFormatJSON:
+		if receipts == nil {
+			receipts = []PatrolReceipt{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(receipts)
+	case ReceiptFormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, r := range receipts {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown receipt format %q: must be one of %s, %s, %s",
+			format, ReceiptFormatTable, ReceiptFormatJSON, ReceiptFormatJSONL)
+	}
+}
+
+func renderReceiptTable(receipts []PatrolReceipt, w io.Writer) error {
+	if len(receipts) == 0 {
+		_, err := fmt.Fprintln(w, "No zombie polecats detected.")
+		return err
+	}
+
+	tbl := style.NewTable(
+		style.Column{Name: "RIG", Width: 12},
+		style.Column{Name: "POLECAT", Width: 14},
+		style.Column{Name: "VERDICT", Width: 8},
+		style.Column{Name: "STATE", Width: 24},
+		style.Column{Name: "HOOK", Width: 14},
+		style.Column{Name: "ACTION", Width: 24},
+	)
+	for _, r := range receipts {
+		tbl.AddRow(r.Rig, r.Polecat, styleVerdict(r.Verdict), r.Evidence.AgentState, r.Evidence.HookBead, r.RecommendedAction)
+	}
+
+	_, err := fmt.Fprint(w, tbl.Render())
+	return err
+}
+
+// styleVerdict colors a verdict for terminal display.
+func styleVerdict(v PatrolVerdict) string {
+	switch v {
+	case PatrolVerdictStale:
+		return style.Warning.Render(string(v))
+	case PatrolVerdictConfused:
+		return style.Error.Render(string(v))
+	case PatrolVerdictOrphan:
+		return style.Dim.Render(string(v))
+	default:
+		return string(v)
+	}
+}
@@ -1,8 +1,10 @@
 package witness
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -46,6 +48,19 @@ func TestBuildPatrolReceipt_ErrorIncludedInEvidence(t *testing.T) {
 	}
 }
 
+func TestBuildPatrolReceipt_ConfusedVerdictOnError(t *testing.T) {
+	receipt := BuildPatrolReceipt("gastown", ZombieResult{
+		PolecatName: "nux",
+		AgentState:  "idle",
+		HookBead:    "gt-abc123",
+		Error:       errors.New("nuke failed"),
+	})
+
+	if receipt.Verdict != PatrolVerdictConfused {
+		t.Fatalf("Verdict = %q, want %q", receipt.Verdict, PatrolVerdictConfused)
+	}
+}
+
 func TestReceiptVerdictForZombie_AllStates(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -172,3 +187,108 @@ func TestBuildPatrolReceipts_DeterministicStaleOrphanOrdering(t *testing.T) {
 		t.Fatalf("second receipt = %+v, want polecat=echo verdict=%q", receipts[1], PatrolVerdictOrphan)
 	}
 }
+
+func TestRenderReceipts_JSON(t *testing.T) {
+	receipts := []PatrolReceipt{
+		{Rig: "gastown", Polecat: "atlas", Verdict: PatrolVerdictStale, RecommendedAction: "auto-nuked"},
+		{Rig: "gastown", Polecat: "echo", Verdict: PatrolVerdictOrphan, RecommendedAction: "investigate"},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderReceipts(receipts, ReceiptFormatJSON, &buf); err != nil {
+		t.Fatalf("RenderReceipts(json) error: %v", err)
+	}
+
+	var decoded []PatrolReceipt
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, buf.String())
+	}
+	if len(decoded) != 2 || decoded[0].Polecat != "atlas" || decoded[1].Verdict != PatrolVerdictOrphan {
+		t.Errorf("decoded = %+v, want atlas/stale and echo/orphan", decoded)
+	}
+}
+
+func TestRenderReceipts_JSONEmptyIsArray(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderReceipts(nil, ReceiptFormatJSON, &buf); err != nil {
+		t.Fatalf("RenderReceipts(json) error: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("empty JSON output = %q, want []", got)
+	}
+}
+
+func TestRenderReceipts_JSONL(t *testing.T) {
+	receipts := []PatrolReceipt{
+		{Rig: "gastown", Polecat: "atlas", Verdict: PatrolVerdictStale},
+		{Rig: "gastown", Polecat: "echo", Verdict: PatrolVerdictOrphan},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderReceipts(receipts, ReceiptFormatJSONL, &buf); err != nil {
+		t.Fatalf("RenderReceipts(jsonl) error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var r PatrolReceipt
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v", i, err)
+		}
+		if r.Polecat != receipts[i].Polecat {
+			t.Errorf("line %d polecat = %q, want %q", i, r.Polecat, receipts[i].Polecat)
+		}
+	}
+}
+
+func TestRenderReceipts_UnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderReceipts(nil, "yaml", &buf); err == nil {
+		t.Fatal("RenderReceipts with unknown format should error")
+	}
+}
+
+func TestBuildPatrolReceiptWithPolicy_CustomActiveState(t *testing.T) {
+	z := ZombieResult{PolecatName: "echo", AgentState: "idle"}
+
+	if got := BuildPatrolReceipt("gastown", z).Verdict; got != PatrolVerdictOrphan {
+		t.Fatalf("default policy verdict = %q, want %q", got, PatrolVerdictOrphan)
+	}
+
+	policy := DefaultZombiePolicy()
+	policy.ActiveStates = append(policy.ActiveStates, "idle")
+	if got := BuildPatrolReceiptWithPolicy("gastown", z, policy).Verdict; got != PatrolVerdictStale {
+		t.Errorf("custom policy verdict = %q, want %q", got, PatrolVerdictStale)
+	}
+}
+
+func TestZombiePolicy_HookMeansStaleDisabled(t *testing.T) {
+	z := ZombieResult{PolecatName: "atlas", AgentState: "idle", HookBead: "gt-abc123"}
+
+	policy := DefaultZombiePolicy()
+	policy.HookMeansStale = false
+	if got := policy.Verdict(z); got != PatrolVerdictOrphan {
+		t.Errorf("Verdict with HookMeansStale=false = %q, want %q", got, PatrolVerdictOrphan)
+	}
+}
+
+func TestBuildPatrolReceiptsWithPolicy(t *testing.T) {
+	result := &DetectZombiePolecatsResult{
+		Zombies: []ZombieResult{
+			{PolecatName: "atlas", AgentState: "idle"},
+			{PolecatName: "echo", AgentState: "nuking"},
+		},
+	}
+	policy := ZombiePolicy{ActiveStates: []string{"nuking"}}
+
+	receipts := BuildPatrolReceiptsWithPolicy("gastown", result, policy)
+	if len(receipts) != 2 {
+		t.Fatalf("got %d receipts, want 2", len(receipts))
+	}
+	if receipts[0].Verdict != PatrolVerdictOrphan || receipts[1].Verdict != PatrolVerdictStale {
+		t.Errorf("verdicts = %q, %q; want orphan, stale", receipts[0].Verdict, receipts[1].Verdict)
+	}
+}
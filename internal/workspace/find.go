@@ -11,8 +11,14 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 )
 
+// ErrNotInWorkspace indicates a command was run outside a Gas Town
+// workspace. Commands wrap it so callers can test with errors.Is.
+var ErrNotInWorkspace = errors.New("not in a Gas Town workspace")
+
 // ErrNotFound indicates no workspace was found.
-var ErrNotFound = errors.New("not in a Gas Town workspace")
+//
+// Deprecated: use ErrNotInWorkspace.
+var ErrNotFound = ErrNotInWorkspace
 
 // Markers used to detect a Gas Town workspace.
 const (
@@ -79,7 +85,7 @@ func FindOrError(startDir string) (string, error) {
 		return "", err
 	}
 	if root == "" {
-		return "", ErrNotFound
+		return "", ErrNotInWorkspace
 	}
 	return root, nil
 }